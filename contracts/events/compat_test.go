@@ -0,0 +1,335 @@
+package events
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	inventoryv1 "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
+	orderv1 "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// update records the golden payloads of new entries. Existing payloads must
+// never be rewritten: they stand for messages already in the streams.
+var update = flag.Bool("update", false, "write missing golden payloads")
+
+var (
+	createdAt = timestamppb.New(time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC))
+	updatedAt = timestamppb.New(time.Date(2025, 3, 15, 18, 2, 11, 0, time.UTC))
+)
+
+// goldenPayloads lists a payload of every event type for every supported
+// schema version. The legacy payloads were published before the envelope and
+// the event IDs existed and are decoded without a version; they cover the
+// event types and statuses that existed then.
+var goldenPayloads = []struct {
+	file    string
+	version string
+	want    proto.Message
+}{
+	{"legacy/order_created.pb", "", &orderv1.OrderEvent{
+		Id:     "67d3f5a5c2a1b4e3f0a1b2c3",
+		UserId: "67d3f1e2c2a1b4e3f0a1b2a0",
+		Items: []*orderv1.OrderItem{
+			{ProductId: "67d3f0aac2a1b4e3f0a1b290", Quantity: 2, Price: 19.99},
+		},
+		Total:     39.98,
+		Status:    orderv1.OrderStatus_S_PENDING,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		EventType: orderv1.OrderEventType_CREATED,
+	}},
+	{"legacy/order_updated.pb", "", &orderv1.OrderEvent{
+		Id:     "67d3f5a5c2a1b4e3f0a1b2c3",
+		UserId: "67d3f1e2c2a1b4e3f0a1b2a0",
+		Items: []*orderv1.OrderItem{
+			{ProductId: "67d3f0aac2a1b4e3f0a1b290", Quantity: 2, Price: 19.99},
+		},
+		Total:     39.98,
+		Status:    orderv1.OrderStatus_S_COMPLETED,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		EventType: orderv1.OrderEventType_UPDATED,
+	}},
+	{"legacy/order_cancelled.pb", "", &orderv1.OrderEvent{
+		Id:     "67d3f5a5c2a1b4e3f0a1b2c4",
+		UserId: "67d3f1e2c2a1b4e3f0a1b2a0",
+		Items: []*orderv1.OrderItem{
+			{ProductId: "67d3f0aac2a1b4e3f0a1b291", Quantity: 1, Price: 5},
+		},
+		Total:     5,
+		Status:    orderv1.OrderStatus_S_CANCELLED,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		EventType: orderv1.OrderEventType_CANCELLED,
+	}},
+	{"legacy/order_deleted.pb", "", &orderv1.OrderEvent{
+		Id:        "67d3f5a5c2a1b4e3f0a1b2c4",
+		UserId:    "67d3f1e2c2a1b4e3f0a1b2a0",
+		Total:     5,
+		Status:    orderv1.OrderStatus_S_CANCELLED,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		EventType: orderv1.OrderEventType_DELETED,
+	}},
+	{"legacy/inventory_created.pb", "", &inventoryv1.InventoryEvent{
+		Id:          "67d3f0aac2a1b4e3f0a1b290",
+		Name:        "Mechanical keyboard",
+		Description: "Tenkeyless, brown switches",
+		CategoryId:  "67d3ef10c2a1b4e3f0a1b280",
+		Price:       89.5,
+		Stock:       20,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		EventType:   inventoryv1.InventoryEventType_CREATED,
+	}},
+	{"legacy/inventory_updated.pb", "", &inventoryv1.InventoryEvent{
+		Id:          "67d3f0aac2a1b4e3f0a1b290",
+		Name:        "Mechanical keyboard",
+		Description: "Tenkeyless, brown switches",
+		CategoryId:  "67d3ef10c2a1b4e3f0a1b280",
+		Price:       89.5,
+		Stock:       12,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		EventType:   inventoryv1.InventoryEventType_UPDATED,
+	}},
+	{"legacy/inventory_deleted.pb", "", &inventoryv1.InventoryEvent{
+		Id:          "67d3f0aac2a1b4e3f0a1b290",
+		Name:        "Mechanical keyboard",
+		Description: "Tenkeyless, brown switches",
+		CategoryId:  "67d3ef10c2a1b4e3f0a1b280",
+		Price:       89.5,
+		Stock:       12,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		EventType:   inventoryv1.InventoryEventType_DELETED,
+	}},
+	{"v1/order_created.pb", SchemaV1, &orderv1.OrderEvent{
+		Id:     "67d3f5a5c2a1b4e3f0a1b2c3",
+		UserId: "67d3f1e2c2a1b4e3f0a1b2a0",
+		Items: []*orderv1.OrderItem{
+			{ProductId: "67d3f0aac2a1b4e3f0a1b290", Quantity: 2, Price: 19.99},
+			{ProductId: "67d3f0aac2a1b4e3f0a1b291", Quantity: 1, Price: 5},
+		},
+		Total:     44.98,
+		Status:    orderv1.OrderStatus_S_PENDING,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		EventType: orderv1.OrderEventType_CREATED,
+		EventId:   "67d3f5a5c2a1b4e3f0a1b2d0",
+		Sequence:  1,
+	}},
+	{"v1/order_updated.pb", SchemaV1, &orderv1.OrderEvent{
+		Id:        "67d3f5a5c2a1b4e3f0a1b2c3",
+		UserId:    "67d3f1e2c2a1b4e3f0a1b2a0",
+		Total:     44.98,
+		Status:    orderv1.OrderStatus_S_SHIPPED,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		EventType: orderv1.OrderEventType_UPDATED,
+		EventId:   "67d3f5a5c2a1b4e3f0a1b2d1",
+		Sequence:  2,
+	}},
+	{"v1/order_cancelled.pb", SchemaV1, &orderv1.OrderEvent{
+		Id:        "67d3f5a5c2a1b4e3f0a1b2c3",
+		UserId:    "67d3f1e2c2a1b4e3f0a1b2a0",
+		Total:     44.98,
+		Status:    orderv1.OrderStatus_S_CANCELLED,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		EventType: orderv1.OrderEventType_CANCELLED,
+		EventId:   "67d3f5a5c2a1b4e3f0a1b2d2",
+		Sequence:  3,
+	}},
+	{"v1/order_cancelled_reason.pb", SchemaV1, &orderv1.OrderEvent{
+		Id:     "67d3f5a5c2a1b4e3f0a1b2c4",
+		UserId: "67d3f1e2c2a1b4e3f0a1b2a0",
+		Items: []*orderv1.OrderItem{
+			{ProductId: "67d3f0aac2a1b4e3f0a1b290", Quantity: 1, Price: 19.99},
+		},
+		Total:        19.99,
+		Status:       orderv1.OrderStatus_S_CANCELLED,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		EventType:    orderv1.OrderEventType_CANCELLED,
+		EventId:      "67d3f5a5c2a1b4e3f0a1b2d4",
+		Sequence:     3,
+		CancelReason: "ordered by mistake",
+	}},
+	{"v1/order_return_refunded.pb", SchemaV1, &orderv1.OrderEvent{
+		Id:     "67d3f5a5c2a1b4e3f0a1b2c5",
+		UserId: "67d3f1e2c2a1b4e3f0a1b2a0",
+		Items: []*orderv1.OrderItem{
+			{ProductId: "67d3f0aac2a1b4e3f0a1b290", Quantity: 2, Price: 19.99, RefundedQuantity: 1},
+			{ProductId: "67d3f0aac2a1b4e3f0a1b291", Quantity: 1, Price: 5},
+		},
+		Total:         44.98,
+		RefundedTotal: 19.99,
+		Status:        orderv1.OrderStatus_S_DELIVERED,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+		EventType:     orderv1.OrderEventType_RETURN_REFUNDED,
+		EventId:       "67d3f5a5c2a1b4e3f0a1b2d5",
+		Sequence:      7,
+		OrderReturn: &orderv1.OrderReturn{
+			Id: "67d3f6b0c2a1b4e3f0a1b2e0",
+			Items: []*orderv1.OrderItem{
+				{ProductId: "67d3f0aac2a1b4e3f0a1b290", Quantity: 1, Price: 19.99},
+			},
+			Amount:    19.99,
+			Status:    orderv1.ReturnStatus_R_REFUNDED,
+			Reason:    "arrived damaged",
+			Restocked: true,
+		},
+	}},
+	{"v1/order_deleted.pb", SchemaV1, &orderv1.OrderEvent{
+		Id:        "67d3f5a5c2a1b4e3f0a1b2c3",
+		UserId:    "67d3f1e2c2a1b4e3f0a1b2a0",
+		Status:    orderv1.OrderStatus_S_CANCELLED,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		EventType: orderv1.OrderEventType_DELETED,
+		EventId:   "67d3f5a5c2a1b4e3f0a1b2d3",
+		Sequence:  4,
+	}},
+	{"v1/inventory_created.pb", SchemaV1, &inventoryv1.InventoryEvent{
+		Id:          "67d3f0aac2a1b4e3f0a1b290",
+		Name:        "Mechanical keyboard",
+		Description: "Tenkeyless, brown switches",
+		CategoryId:  "67d3ef10c2a1b4e3f0a1b280",
+		Price:       89.5,
+		Stock:       40,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		EventType:   inventoryv1.InventoryEventType_CREATED,
+		EventId:     "67d3f0aac2a1b4e3f0a1b2a0",
+		Sequence:    1,
+	}},
+	{"v1/inventory_updated.pb", SchemaV1, &inventoryv1.InventoryEvent{
+		Id:          "67d3f0aac2a1b4e3f0a1b290",
+		Name:        "Mechanical keyboard",
+		Description: "Tenkeyless, brown switches",
+		CategoryId:  "67d3ef10c2a1b4e3f0a1b281",
+		Price:       79.5,
+		Stock:       38,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		EventType:   inventoryv1.InventoryEventType_UPDATED,
+		EventId:     "67d3f0aac2a1b4e3f0a1b2a1",
+		Sequence:    2,
+	}},
+	{"v1/inventory_deleted.pb", SchemaV1, &inventoryv1.InventoryEvent{
+		Id:          "67d3f0aac2a1b4e3f0a1b290",
+		Name:        "Mechanical keyboard",
+		Description: "Tenkeyless, brown switches",
+		CategoryId:  "67d3ef10c2a1b4e3f0a1b281",
+		Price:       79.5,
+		Stock:       38,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		EventType:   inventoryv1.InventoryEventType_DELETED,
+		EventId:     "67d3f0aac2a1b4e3f0a1b2a2",
+		Sequence:    3,
+	}},
+}
+
+func TestGoldenPayloads(t *testing.T) {
+	for _, tc := range goldenPayloads {
+		t.Run(tc.file, func(t *testing.T) {
+			path := filepath.Join("testdata", tc.file)
+			if *update {
+				writeGolden(t, path, tc.want)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden payload: %v", err)
+			}
+
+			var got proto.Message
+			switch tc.want.(type) {
+			case *orderv1.OrderEvent:
+				got, err = DecodeOrderEvent(tc.version, data)
+			case *inventoryv1.InventoryEvent:
+				got, err = DecodeInventoryEvent(tc.version, data)
+			default:
+				t.Fatalf("no decoder for %T", tc.want)
+			}
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !proto.Equal(got, tc.want) {
+				t.Errorf("decoded\n%v\nwant\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func writeGolden(t *testing.T, path string, msg proto.Message) {
+	t.Helper()
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal golden payload: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnsupportedSchemaVersion(t *testing.T) {
+	if _, err := DecodeOrderEvent("2", nil); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Errorf("DecodeOrderEvent error = %v, want %v", err, ErrUnsupportedSchemaVersion)
+	}
+	if _, err := DecodeInventoryEvent("2", nil); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Errorf("DecodeInventoryEvent error = %v, want %v", err, ErrUnsupportedSchemaVersion)
+	}
+}
+
+func TestEventTypesHaveEnvelopeTypes(t *testing.T) {
+	for value, name := range orderv1.OrderEventType_name {
+		if OrderTypes[orderv1.OrderEventType(value)] == "" {
+			t.Errorf("order event type %s has no envelope type", name)
+		}
+	}
+	for value, name := range inventoryv1.InventoryEventType_name {
+		if InventoryTypes[inventoryv1.InventoryEventType(value)] == "" {
+			t.Errorf("inventory event type %s has no envelope type", name)
+		}
+	}
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	want := Envelope{
+		ID:            "67d3f5a5c2a1b4e3f0a1b2d0",
+		Type:          TypeOrderCreated,
+		Source:        SourceOrderService,
+		SchemaVersion: CurrentSchemaVersion,
+		Time:          time.Date(2025, 3, 14, 9, 26, 53, 120000000, time.UTC),
+		TraceID:       "3f0c1b7e9d2a4c58b1e6f0a2d4c6e8f0",
+		ContentType:   ContentTypeProtobuf,
+	}
+
+	got, err := ParseEnvelope(want.Headers())
+	if err != nil {
+		t.Fatalf("ParseEnvelope: %v", err)
+	}
+	if got != want {
+		t.Errorf("ParseEnvelope = %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseEnvelope(nil); !errors.Is(err, ErrMissingEnvelope) {
+		t.Errorf("ParseEnvelope(nil) error = %v, want %v", err, ErrMissingEnvelope)
+	}
+}
@@ -0,0 +1,110 @@
+// Package events defines the events the services exchange over NATS: the
+// envelope carried in the message headers and the versioned payload schemas
+// in the order and inventory subpackages.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Headers of the event envelope, modelled on the CloudEvents NATS binding.
+// Every event message carries them, so consumers can route, trace and
+// deduplicate messages without decoding the payload.
+const (
+	HeaderID            = "ce-id"
+	HeaderType          = "ce-type"
+	HeaderSource        = "ce-source"
+	HeaderSpecVersion   = "ce-specversion"
+	HeaderSchemaVersion = "ce-schemaversion"
+	HeaderTime          = "ce-time"
+	HeaderTraceID       = "ce-traceid"
+	HeaderContentType   = "content-type"
+
+	SpecVersion         = "1.0"
+	ContentTypeProtobuf = "application/protobuf"
+)
+
+// Event types.
+const (
+	TypeOrderCreated   = "order.created"
+	TypeOrderUpdated   = "order.updated"
+	TypeOrderCancelled = "order.cancelled"
+	TypeOrderDeleted   = "order.deleted"
+
+	TypeReturnRequested = "order.return.requested"
+	TypeReturnApproved  = "order.return.approved"
+	TypeReturnRejected  = "order.return.rejected"
+	TypeReturnReceived  = "order.return.received"
+	TypeReturnRefunded  = "order.return.refunded"
+
+	TypeProductCreated = "product.created"
+	TypeProductUpdated = "product.updated"
+	TypeProductDeleted = "product.deleted"
+)
+
+// Sources name the publishing services.
+const (
+	SourceOrderService     = "order-service"
+	SourceInventoryService = "inventory-service"
+)
+
+var ErrMissingEnvelope = errors.New("message has no event envelope")
+
+// Envelope describes the event a message carries.
+type Envelope struct {
+	ID            string
+	Type          string
+	Source        string
+	SchemaVersion string
+	Time          time.Time
+	TraceID       string
+	ContentType   string
+}
+
+// Headers returns the envelope as message headers.
+func (e Envelope) Headers() map[string]string {
+	headers := map[string]string{
+		HeaderID:            e.ID,
+		HeaderType:          e.Type,
+		HeaderSource:        e.Source,
+		HeaderSpecVersion:   SpecVersion,
+		HeaderSchemaVersion: e.SchemaVersion,
+		HeaderTime:          e.Time.UTC().Format(time.RFC3339Nano),
+		HeaderContentType:   e.ContentType,
+	}
+	if e.TraceID != "" {
+		headers[HeaderTraceID] = e.TraceID
+	}
+	return headers
+}
+
+// ParseEnvelope reads the envelope from message headers. Messages published
+// before the envelope was introduced have no headers and return
+// ErrMissingEnvelope.
+func ParseEnvelope(headers map[string]string) (Envelope, error) {
+	if headers[HeaderType] == "" {
+		return Envelope{}, ErrMissingEnvelope
+	}
+
+	e := Envelope{
+		ID:            headers[HeaderID],
+		Type:          headers[HeaderType],
+		Source:        headers[HeaderSource],
+		SchemaVersion: headers[HeaderSchemaVersion],
+		TraceID:       headers[HeaderTraceID],
+		ContentType:   headers[HeaderContentType],
+	}
+	if e.ID == "" {
+		return Envelope{}, fmt.Errorf("envelope of %s event has no %s header", e.Type, HeaderID)
+	}
+	if v := headers[HeaderTime]; v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return Envelope{}, fmt.Errorf("invalid %s header %q: %w", HeaderTime, v, err)
+		}
+		e.Time = t
+	}
+	return e, nil
+}
@@ -0,0 +1,641 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: events/order/v1/events.proto
+
+package orderv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderEventType int32
+
+const (
+	OrderEventType_CREATED          OrderEventType = 0
+	OrderEventType_UPDATED          OrderEventType = 1
+	OrderEventType_CANCELLED        OrderEventType = 2
+	OrderEventType_DELETED          OrderEventType = 3
+	OrderEventType_RETURN_REQUESTED OrderEventType = 4
+	OrderEventType_RETURN_APPROVED  OrderEventType = 5
+	OrderEventType_RETURN_REJECTED  OrderEventType = 6
+	OrderEventType_RETURN_RECEIVED  OrderEventType = 7
+	OrderEventType_RETURN_REFUNDED  OrderEventType = 8
+)
+
+// Enum value maps for OrderEventType.
+var (
+	OrderEventType_name = map[int32]string{
+		0: "CREATED",
+		1: "UPDATED",
+		2: "CANCELLED",
+		3: "DELETED",
+		4: "RETURN_REQUESTED",
+		5: "RETURN_APPROVED",
+		6: "RETURN_REJECTED",
+		7: "RETURN_RECEIVED",
+		8: "RETURN_REFUNDED",
+	}
+	OrderEventType_value = map[string]int32{
+		"CREATED":          0,
+		"UPDATED":          1,
+		"CANCELLED":        2,
+		"DELETED":          3,
+		"RETURN_REQUESTED": 4,
+		"RETURN_APPROVED":  5,
+		"RETURN_REJECTED":  6,
+		"RETURN_RECEIVED":  7,
+		"RETURN_REFUNDED":  8,
+	}
+)
+
+func (x OrderEventType) Enum() *OrderEventType {
+	p := new(OrderEventType)
+	*p = x
+	return p
+}
+
+func (x OrderEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_order_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (OrderEventType) Type() protoreflect.EnumType {
+	return &file_events_order_v1_events_proto_enumTypes[0]
+}
+
+func (x OrderEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderEventType.Descriptor instead.
+func (OrderEventType) EnumDescriptor() ([]byte, []int) {
+	return file_events_order_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+type ReturnStatus int32
+
+const (
+	ReturnStatus_R_REQUESTED ReturnStatus = 0
+	ReturnStatus_R_APPROVED  ReturnStatus = 1
+	ReturnStatus_R_REJECTED  ReturnStatus = 2
+	ReturnStatus_R_RECEIVED  ReturnStatus = 3
+	ReturnStatus_R_REFUNDED  ReturnStatus = 4
+)
+
+// Enum value maps for ReturnStatus.
+var (
+	ReturnStatus_name = map[int32]string{
+		0: "R_REQUESTED",
+		1: "R_APPROVED",
+		2: "R_REJECTED",
+		3: "R_RECEIVED",
+		4: "R_REFUNDED",
+	}
+	ReturnStatus_value = map[string]int32{
+		"R_REQUESTED": 0,
+		"R_APPROVED":  1,
+		"R_REJECTED":  2,
+		"R_RECEIVED":  3,
+		"R_REFUNDED":  4,
+	}
+)
+
+func (x ReturnStatus) Enum() *ReturnStatus {
+	p := new(ReturnStatus)
+	*p = x
+	return p
+}
+
+func (x ReturnStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReturnStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_order_v1_events_proto_enumTypes[1].Descriptor()
+}
+
+func (ReturnStatus) Type() protoreflect.EnumType {
+	return &file_events_order_v1_events_proto_enumTypes[1]
+}
+
+func (x ReturnStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReturnStatus.Descriptor instead.
+func (ReturnStatus) EnumDescriptor() ([]byte, []int) {
+	return file_events_order_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_S_PENDING    OrderStatus = 0
+	OrderStatus_S_COMPLETED  OrderStatus = 1
+	OrderStatus_S_CANCELLED  OrderStatus = 2
+	OrderStatus_S_PAID       OrderStatus = 3
+	OrderStatus_S_PROCESSING OrderStatus = 4
+	OrderStatus_S_SHIPPED    OrderStatus = 5
+	OrderStatus_S_DELIVERED  OrderStatus = 6
+	OrderStatus_S_REFUNDED   OrderStatus = 7
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "S_PENDING",
+		1: "S_COMPLETED",
+		2: "S_CANCELLED",
+		3: "S_PAID",
+		4: "S_PROCESSING",
+		5: "S_SHIPPED",
+		6: "S_DELIVERED",
+		7: "S_REFUNDED",
+	}
+	OrderStatus_value = map[string]int32{
+		"S_PENDING":    0,
+		"S_COMPLETED":  1,
+		"S_CANCELLED":  2,
+		"S_PAID":       3,
+		"S_PROCESSING": 4,
+		"S_SHIPPED":    5,
+		"S_DELIVERED":  6,
+		"S_REFUNDED":   7,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_order_v1_events_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_events_order_v1_events_proto_enumTypes[2]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_events_order_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+type OrderEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items     []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Total     float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
+	Status    OrderStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=order.events.v1.OrderStatus" json:"status,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	EventType OrderEventType         `protobuf:"varint,8,opt,name=event_type,json=eventType,proto3,enum=order.events.v1.OrderEventType" json:"event_type,omitempty"`
+	// Unique ID of the event.
+	EventId string `protobuf:"bytes,9,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Position of the event among the events of the order, starting at 1.
+	Sequence int64 `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Reason given for the cancellation of a CANCELLED event.
+	CancelReason string `protobuf:"bytes,11,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	// Amount refunded for returned items so far; the net total is total minus
+	// refunded_total.
+	RefundedTotal float64 `protobuf:"fixed64,12,opt,name=refunded_total,json=refundedTotal,proto3" json:"refunded_total,omitempty"`
+	// The return a RETURN_* event is about, in its state after the event.
+	OrderReturn   *OrderReturn `protobuf:"bytes,13,opt,name=order_return,json=orderReturn,proto3" json:"order_return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderEvent) Reset() {
+	*x = OrderEvent{}
+	mi := &file_events_order_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderEvent) ProtoMessage() {}
+
+func (x *OrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_order_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return file_events_order_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrderEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *OrderEvent) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_S_PENDING
+}
+
+func (x *OrderEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetEventType() OrderEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return OrderEventType_CREATED
+}
+
+func (x *OrderEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderEvent) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetRefundedTotal() float64 {
+	if x != nil {
+		return x.RefundedTotal
+	}
+	return 0
+}
+
+func (x *OrderEvent) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		return x.OrderReturn
+	}
+	return nil
+}
+
+type OrderItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price     float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	// Units of the item refunded through returns so far.
+	RefundedQuantity int32 `protobuf:"varint,4,opt,name=refunded_quantity,json=refundedQuantity,proto3" json:"refunded_quantity,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_events_order_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_events_order_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_events_order_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderItem) GetRefundedQuantity() int32 {
+	if x != nil {
+		return x.RefundedQuantity
+	}
+	return 0
+}
+
+type OrderReturn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items         []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        ReturnStatus           `protobuf:"varint,4,opt,name=status,proto3,enum=order.events.v1.ReturnStatus" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Restocked     bool                   `protobuf:"varint,6,opt,name=restocked,proto3" json:"restocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderReturn) Reset() {
+	*x = OrderReturn{}
+	mi := &file_events_order_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderReturn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderReturn) ProtoMessage() {}
+
+func (x *OrderReturn) ProtoReflect() protoreflect.Message {
+	mi := &file_events_order_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderReturn.ProtoReflect.Descriptor instead.
+func (*OrderReturn) Descriptor() ([]byte, []int) {
+	return file_events_order_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderReturn) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *OrderReturn) GetStatus() ReturnStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReturnStatus_R_REQUESTED
+}
+
+func (x *OrderReturn) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetRestocked() bool {
+	if x != nil {
+		return x.Restocked
+	}
+	return false
+}
+
+var File_events_order_v1_events_proto protoreflect.FileDescriptor
+
+const file_events_order_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1cevents/order/v1/events.proto\x12\x0forder.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xad\x04\n" +
+	"\n" +
+	"OrderEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x120\n" +
+	"\x05items\x18\x03 \x03(\v2\x1a.order.events.v1.OrderItemR\x05items\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x01R\x05total\x124\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1c.order.events.v1.OrderStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\n" +
+	"event_type\x18\b \x01(\x0e2\x1f.order.events.v1.OrderEventTypeR\teventType\x12\x19\n" +
+	"\bevent_id\x18\t \x01(\tR\aeventId\x12\x1a\n" +
+	"\bsequence\x18\n" +
+	" \x01(\x03R\bsequence\x12#\n" +
+	"\rcancel_reason\x18\v \x01(\tR\fcancelReason\x12%\n" +
+	"\x0erefunded_total\x18\f \x01(\x01R\rrefundedTotal\x12?\n" +
+	"\forder_return\x18\r \x01(\v2\x1c.order.events.v1.OrderReturnR\vorderReturn\"\x89\x01\n" +
+	"\tOrderItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12+\n" +
+	"\x11refunded_quantity\x18\x04 \x01(\x05R\x10refundedQuantity\"\xd4\x01\n" +
+	"\vOrderReturn\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x05items\x18\x02 \x03(\v2\x1a.order.events.v1.OrderItemR\x05items\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x125\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1d.order.events.v1.ReturnStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1c\n" +
+	"\trestocked\x18\x06 \x01(\bR\trestocked*\xb0\x01\n" +
+	"\x0eOrderEventType\x12\v\n" +
+	"\aCREATED\x10\x00\x12\v\n" +
+	"\aUPDATED\x10\x01\x12\r\n" +
+	"\tCANCELLED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x03\x12\x14\n" +
+	"\x10RETURN_REQUESTED\x10\x04\x12\x13\n" +
+	"\x0fRETURN_APPROVED\x10\x05\x12\x13\n" +
+	"\x0fRETURN_REJECTED\x10\x06\x12\x13\n" +
+	"\x0fRETURN_RECEIVED\x10\a\x12\x13\n" +
+	"\x0fRETURN_REFUNDED\x10\b*_\n" +
+	"\fReturnStatus\x12\x0f\n" +
+	"\vR_REQUESTED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"R_APPROVED\x10\x01\x12\x0e\n" +
+	"\n" +
+	"R_REJECTED\x10\x02\x12\x0e\n" +
+	"\n" +
+	"R_RECEIVED\x10\x03\x12\x0e\n" +
+	"\n" +
+	"R_REFUNDED\x10\x04*\x8c\x01\n" +
+	"\vOrderStatus\x12\r\n" +
+	"\tS_PENDING\x10\x00\x12\x0f\n" +
+	"\vS_COMPLETED\x10\x01\x12\x0f\n" +
+	"\vS_CANCELLED\x10\x02\x12\n" +
+	"\n" +
+	"\x06S_PAID\x10\x03\x12\x10\n" +
+	"\fS_PROCESSING\x10\x04\x12\r\n" +
+	"\tS_SHIPPED\x10\x05\x12\x0f\n" +
+	"\vS_DELIVERED\x10\x06\x12\x0e\n" +
+	"\n" +
+	"S_REFUNDED\x10\aBPZNgithub.com/mephirious/advanced-programming-2/contracts/events/order/v1;orderv1b\x06proto3"
+
+var (
+	file_events_order_v1_events_proto_rawDescOnce sync.Once
+	file_events_order_v1_events_proto_rawDescData []byte
+)
+
+func file_events_order_v1_events_proto_rawDescGZIP() []byte {
+	file_events_order_v1_events_proto_rawDescOnce.Do(func() {
+		file_events_order_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_order_v1_events_proto_rawDesc), len(file_events_order_v1_events_proto_rawDesc)))
+	})
+	return file_events_order_v1_events_proto_rawDescData
+}
+
+var file_events_order_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_events_order_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_events_order_v1_events_proto_goTypes = []any{
+	(OrderEventType)(0),           // 0: order.events.v1.OrderEventType
+	(ReturnStatus)(0),             // 1: order.events.v1.ReturnStatus
+	(OrderStatus)(0),              // 2: order.events.v1.OrderStatus
+	(*OrderEvent)(nil),            // 3: order.events.v1.OrderEvent
+	(*OrderItem)(nil),             // 4: order.events.v1.OrderItem
+	(*OrderReturn)(nil),           // 5: order.events.v1.OrderReturn
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_events_order_v1_events_proto_depIdxs = []int32{
+	4, // 0: order.events.v1.OrderEvent.items:type_name -> order.events.v1.OrderItem
+	2, // 1: order.events.v1.OrderEvent.status:type_name -> order.events.v1.OrderStatus
+	6, // 2: order.events.v1.OrderEvent.created_at:type_name -> google.protobuf.Timestamp
+	6, // 3: order.events.v1.OrderEvent.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 4: order.events.v1.OrderEvent.event_type:type_name -> order.events.v1.OrderEventType
+	5, // 5: order.events.v1.OrderEvent.order_return:type_name -> order.events.v1.OrderReturn
+	4, // 6: order.events.v1.OrderReturn.items:type_name -> order.events.v1.OrderItem
+	1, // 7: order.events.v1.OrderReturn.status:type_name -> order.events.v1.ReturnStatus
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_events_order_v1_events_proto_init() }
+func file_events_order_v1_events_proto_init() {
+	if File_events_order_v1_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_order_v1_events_proto_rawDesc), len(file_events_order_v1_events_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_order_v1_events_proto_goTypes,
+		DependencyIndexes: file_events_order_v1_events_proto_depIdxs,
+		EnumInfos:         file_events_order_v1_events_proto_enumTypes,
+		MessageInfos:      file_events_order_v1_events_proto_msgTypes,
+	}.Build()
+	File_events_order_v1_events_proto = out.File
+	file_events_order_v1_events_proto_goTypes = nil
+	file_events_order_v1_events_proto_depIdxs = nil
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+
+	inventoryv1 "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
+	orderv1 "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Schema versions of the event payloads, sent in the ce-schemaversion header.
+// Producers publish the current version; consumers decode every supported one
+// into the current types.
+const (
+	SchemaV1 = "1"
+
+	CurrentSchemaVersion = SchemaV1
+)
+
+var ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+// OrderTypes maps the order event types to envelope types.
+var OrderTypes = map[orderv1.OrderEventType]string{
+	orderv1.OrderEventType_CREATED:   TypeOrderCreated,
+	orderv1.OrderEventType_UPDATED:   TypeOrderUpdated,
+	orderv1.OrderEventType_CANCELLED: TypeOrderCancelled,
+	orderv1.OrderEventType_DELETED:   TypeOrderDeleted,
+
+	orderv1.OrderEventType_RETURN_REQUESTED: TypeReturnRequested,
+	orderv1.OrderEventType_RETURN_APPROVED:  TypeReturnApproved,
+	orderv1.OrderEventType_RETURN_REJECTED:  TypeReturnRejected,
+	orderv1.OrderEventType_RETURN_RECEIVED:  TypeReturnReceived,
+	orderv1.OrderEventType_RETURN_REFUNDED:  TypeReturnRefunded,
+}
+
+// InventoryTypes maps the product event types to envelope types.
+var InventoryTypes = map[inventoryv1.InventoryEventType]string{
+	inventoryv1.InventoryEventType_CREATED: TypeProductCreated,
+	inventoryv1.InventoryEventType_UPDATED: TypeProductUpdated,
+	inventoryv1.InventoryEventType_DELETED: TypeProductDeleted,
+}
+
+// DecodeOrderEvent decodes an order event payload of the schema version. An
+// empty version stands for messages without envelope, which carry version 1
+// payloads.
+func DecodeOrderEvent(version string, data []byte) (*orderv1.OrderEvent, error) {
+	switch version {
+	case "", SchemaV1:
+		var event orderv1.OrderEvent
+		if err := proto.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode order event: %w", err)
+		}
+		return &event, nil
+	default:
+		return nil, fmt.Errorf("%w %q of order events", ErrUnsupportedSchemaVersion, version)
+	}
+}
+
+// DecodeInventoryEvent decodes a product event payload of the schema version.
+// An empty version stands for messages without envelope, which carry version
+// 1 payloads.
+func DecodeInventoryEvent(version string, data []byte) (*inventoryv1.InventoryEvent, error) {
+	switch version {
+	case "", SchemaV1:
+		var event inventoryv1.InventoryEvent
+		if err := proto.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode inventory event: %w", err)
+		}
+		return &event, nil
+	default:
+		return nil, fmt.Errorf("%w %q of inventory events", ErrUnsupportedSchemaVersion, version)
+	}
+}
@@ -0,0 +1,5 @@
+
+67d3f5a5c2a1b4e3f0a1b2c367d3f1e2c2a1b4e3f0a1b2a0%
+67d3f0aac2a1b4e3f0a1b290=
+ףp3@!=
+ףpC@(2Ͼ:׾@
\ No newline at end of file
@@ -0,0 +1,3 @@
+
+67d3f5a5c2a1b4e3f0a1b2c367d3f1e2c2a1b4e3f0a1b2a0!=
+ףp}F@(2Ͼ:׾@J67d3f5a5c2a1b4e3f0a1b2d2P
\ No newline at end of file
@@ -0,0 +1,5 @@
+
+67d3f5a5c2a1b4e3f0a1b2c467d3f1e2c2a1b4e3f0a1b2a0%
+67d3f0aac2a1b4e3f0a1b290=
+ףp3@!=
+ףp3@(2Ͼ:׾@J67d3f5a5c2a1b4e3f0a1b2d4PZordered by mistake
\ No newline at end of file
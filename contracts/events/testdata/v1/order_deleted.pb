@@ -0,0 +1,2 @@
+
+67d3f5a5c2a1b4e3f0a1b2c367d3f1e2c2a1b4e3f0a1b2a0(2Ͼ:׾@J67d3f5a5c2a1b4e3f0a1b2d3P
\ No newline at end of file
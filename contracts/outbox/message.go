@@ -0,0 +1,33 @@
+// Package outbox implements the transactional outbox the inventory and order
+// services publish their events through: a MongoDB repository the events are
+// written to in the transaction that changes the aggregate, and a relay that
+// publishes them to JetStream.
+package outbox
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSent    Status = "sent"
+)
+
+// Message is an event waiting to be published to NATS. It is written in the
+// same transaction as the change it describes.
+type Message struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	Subject       string             `bson:"subject"`
+	Headers       map[string]string  `bson:"headers,omitempty"`
+	Payload       []byte             `bson:"payload"`
+	Status        Status             `bson:"status"`
+	Attempts      int                `bson:"attempts"`
+	LastError     string             `bson:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at"`
+	CreatedAt     time.Time          `bson:"created_at"`
+	SentAt        *time.Time         `bson:"sent_at,omitempty"`
+}
@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/contracts/streams"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	relayBatchSize      = 100
+	relayMaxBackoff     = 5 * time.Minute
+	relayPublishTimeout = 30 * time.Second
+)
+
+// Store holds the messages a Relay publishes.
+type Store interface {
+	GetPendingMessages(ctx context.Context, now time.Time, limit int) ([]Message, error)
+	MarkSent(ctx context.Context, id primitive.ObjectID) error
+	MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string, nextAttemptAt time.Time) error
+}
+
+// Relay publishes pending outbox messages to JetStream. A message is marked
+// sent only after the stream acknowledged it, so delivery is at least once.
+// The outbox ID is the event ID and is used as message ID, which lets the
+// stream drop duplicates published again within its duplicate window.
+type Relay struct {
+	js       jetstream.JetStream
+	store    Store
+	interval time.Duration
+}
+
+func NewRelay(js jetstream.JetStream, store Store, interval time.Duration) *Relay {
+	return &Relay{
+		js:       js,
+		store:    store,
+		interval: interval,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.relay(ctx); err != nil {
+					log.Printf("Outbox relay: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// relay publishes one batch of due messages. It stops at the first failure to
+// keep the messages in order; the failed message is retried with backoff, and
+// the store holds back the messages after it until it was published.
+func (r *Relay) relay(ctx context.Context) error {
+	messages, err := r.store.GetPendingMessages(ctx, time.Now(), relayBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get pending messages: %w", err)
+	}
+
+	for _, msg := range messages {
+		if err := r.publish(ctx, msg); err != nil {
+			next := time.Now().Add(backoff(msg.Attempts))
+			if markErr := r.store.MarkFailed(ctx, msg.ID, err.Error(), next); markErr != nil {
+				log.Printf("Failed to mark outbox message %s as failed: %v", msg.ID.Hex(), markErr)
+			}
+			return fmt.Errorf("failed to publish outbox message %s to %s: %w", msg.ID.Hex(), msg.Subject, err)
+		}
+
+		if err := r.store.MarkSent(ctx, msg.ID); err != nil {
+			return fmt.Errorf("failed to mark outbox message %s as sent: %w", msg.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+func (r *Relay) publish(ctx context.Context, msg Message) error {
+	ctx, cancel := context.WithTimeout(ctx, relayPublishTimeout)
+	defer cancel()
+
+	_, err := r.js.PublishMsg(ctx, &nats.Msg{
+		Subject: msg.Subject,
+		Header:  streams.NewHeader(msg.Headers),
+		Data:    msg.Payload,
+	}, jetstream.WithMsgID(msg.ID.Hex()))
+	return err
+}
+
+func backoff(attempts int) time.Duration {
+	d := time.Second << min(attempts, 16)
+	return min(d, relayMaxBackoff)
+}
@@ -0,0 +1,126 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// sentRetention is how long published messages are kept before Mongo removes them.
+const sentRetention = 7 * 24 * time.Hour
+
+// Repository stores the outbox in the "outbox" collection and the event
+// sequence numbers of the aggregates in "event_sequences".
+type Repository struct {
+	collection   *mongo.Collection
+	sequenceColl *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) *Repository {
+	return &Repository{
+		collection:   db.Collection("outbox"),
+		sequenceColl: db.Collection("event_sequences"),
+	}
+}
+
+// AddMessage stores a message for publishing. The ID is the event ID and is
+// used as the JetStream message ID; the headers carry the event envelope.
+func (r *Repository) AddMessage(ctx context.Context, id primitive.ObjectID, subject string, headers map[string]string, payload []byte) error {
+	now := time.Now()
+	_, err := r.collection.InsertOne(ctx, Message{
+		ID:            id,
+		Subject:       subject,
+		Headers:       headers,
+		Payload:       payload,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+	return err
+}
+
+// NextSequence increments and returns the event sequence number of an
+// aggregate. Called in the transaction that changes the aggregate, it numbers
+// the events of the aggregate in commit order: concurrent transactions
+// conflict on the counter and are retried.
+func (r *Repository) NextSequence(ctx context.Context, aggregateID string) (int64, error) {
+	var counter struct {
+		Sequence int64 `bson:"sequence"`
+	}
+	err := r.sequenceColl.FindOneAndUpdate(ctx,
+		bson.M{"_id": aggregateID},
+		bson.M{"$inc": bson.M{"sequence": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Sequence, nil
+}
+
+// GetPendingMessages returns the messages due for publishing in the order they
+// were written. It stops before the first pending message that is not due
+// yet, so no message is published ahead of an older one that backs off.
+func (r *Repository) GetPendingMessages(ctx context.Context, now time.Time, limit int) ([]Message, error) {
+	filter := bson.M{"status": StatusPending}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []Message
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	for i, msg := range messages {
+		if msg.NextAttemptAt.After(now) {
+			return messages[:i], nil
+		}
+	}
+	return messages, nil
+}
+
+func (r *Repository) MarkSent(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set":   bson.M{"status": StatusSent, "sent_at": time.Now()},
+			"$inc":   bson.M{"attempts": 1},
+			"$unset": bson.M{"last_error": ""},
+		},
+	)
+	return err
+}
+
+func (r *Repository) MarkFailed(ctx context.Context, id primitive.ObjectID, lastError string, nextAttemptAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{"last_error": lastError, "next_attempt_at": nextAttemptAt},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+	return err
+}
+
+func (r *Repository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "sent_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(sentRetention.Seconds())),
+		},
+	})
+	return err
+}
@@ -0,0 +1,83 @@
+// Package streams defines the JetStream streams that carry the events and
+// converts between NATS message headers and event envelope headers.
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// DuplicateWindow is how long the streams remember message IDs to drop
+// duplicates. The outbox relays publish every event with its event ID as
+// message ID and publish it again when they could not mark it as sent, which
+// happens on their next run or, after a crash, when the service is back. The
+// window covers a day of that; later duplicates reach the consumers, which
+// ignore them by event ID or sequence.
+const DuplicateWindow = 24 * time.Hour
+
+// ErrInvalidEvent marks events that can never be processed. Consumers
+// terminate them instead of having them redelivered.
+var ErrInvalidEvent = errors.New("invalid event")
+
+// Streams that hold the domain events. Every service makes sure the streams it
+// publishes to or consumes from exist, so they are defined once for all of
+// them.
+var (
+	Orders = jetstream.StreamConfig{
+		Name:       "ORDERS",
+		Subjects:   []string{"order.events"},
+		Storage:    jetstream.FileStorage,
+		Duplicates: DuplicateWindow,
+	}
+	Inventory = jetstream.StreamConfig{
+		Name:       "INVENTORY",
+		Subjects:   []string{"inventory.events"},
+		Storage:    jetstream.FileStorage,
+		Duplicates: DuplicateWindow,
+	}
+)
+
+// Ensure creates the streams or updates them to their configuration.
+func Ensure(ctx context.Context, js jetstream.JetStream, streams ...jetstream.StreamConfig) error {
+	for _, cfg := range streams {
+		if _, err := js.CreateOrUpdateStream(ctx, cfg); err != nil {
+			return fmt.Errorf("failed to create stream %s: %w", cfg.Name, err)
+		}
+	}
+	return nil
+}
+
+// HeaderMap converts NATS message headers into event envelope headers. Only
+// the first value of every header is kept.
+func HeaderMap(header nats.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		headers[key] = header.Get(key)
+	}
+	return headers
+}
+
+// NewHeader converts event envelope headers into NATS message headers.
+func NewHeader(headers map[string]string) nats.Header {
+	header := nats.Header{}
+	for key, value := range headers {
+		header.Set(key, value)
+	}
+	return header
+}
+
+// RedeliveryDelay is how long a consumer waits before a message that failed
+// on its delivered-th delivery is delivered again. It backs off exponentially
+// from one second, capped at a minute.
+func RedeliveryDelay(delivered uint64) time.Duration {
+	d := time.Second << min(delivered-1, 6)
+	return min(d, time.Minute)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -13,7 +14,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/mephirious/advanced-programming-2/gateway-service/internal/auth"
+	"github.com/mephirious/advanced-programming-2/gateway-service/internal/middleware"
+	"github.com/mephirious/advanced-programming-2/gateway-service/internal/response"
 	inventorypb "github.com/mephirious/advanced-programming-2/gateway-service/proto/inventory"
 	orderpb "github.com/mephirious/advanced-programming-2/gateway-service/proto/order"
 	statpb "github.com/mephirious/advanced-programming-2/gateway-service/proto/statistics"
@@ -21,6 +26,7 @@ import (
 
 func main() {
 	r := gin.Default()
+	r.Use(middleware.RequestID())
 	gin.SetMode(getEnv("GIN_MODE", "release"))
 
 	orderConn, err := grpc.NewClient(getEnv("ORDER_SERVICE_GRPC", "localhost:8002"), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -29,6 +35,7 @@ func main() {
 	}
 	defer orderConn.Close()
 	orderClient := orderpb.NewOrderServiceClient(orderConn)
+	cartClient := orderpb.NewCartServiceClient(orderConn)
 
 	inventoryConn, err := grpc.NewClient(getEnv("INVENTORY_SERVICE_GRPC", "localhost:8001"), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -44,77 +51,278 @@ func main() {
 	defer statConn.Close()
 	statClient := statpb.NewStatisticsServiceClient(statConn)
 
-	r.POST("/api/v1/orders", func(c *gin.Context) {
+	keys, err := auth.LoadKeySet(getEnv("JWT_HS256_KEYS", ""), getEnv("JWT_RS256_KEYS", ""))
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	if keys.Empty() {
+		log.Fatalf("No JWT keys configured, set JWT_HS256_KEYS or JWT_RS256_KEYS")
+	}
+	verifier := auth.NewVerifier(keys, getEnv("JWT_ISSUER", ""), getEnv("JWT_AUDIENCE", ""))
+
+	api := r.Group("/api/v1", middleware.Auth(verifier))
+
+	api.POST("/orders", func(c *gin.Context) {
 		var req orderpb.CreateOrderRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			response.BadRequest(c, err.Error())
 			return
 		}
-		res, err := orderClient.CreateOrder(context.Background(), &req)
+		res, err := orderClient.CreateOrder(middleware.OutgoingContext(c), &req)
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/orders/:id", func(c *gin.Context) {
-		res, err := orderClient.GetOrderByID(context.Background(), &orderpb.GetOrderRequest{
+	api.GET("/orders/:id", func(c *gin.Context) {
+		res, err := orderClient.GetOrderByID(middleware.OutgoingContext(c), &orderpb.GetOrderRequest{
 			Id: c.Param("id"),
 		})
 		handleResponse(c, res, err)
 	})
 
-	r.POST("/api/v1/orders/:id/status", func(c *gin.Context) {
+	api.POST("/orders/:id/status", func(c *gin.Context) {
 		var req orderpb.UpdateOrderStatusRequest
 		req.Id = c.Param("id")
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			response.BadRequest(c, err.Error())
+			return
+		}
+		res, err := orderClient.UpdateOrderStatus(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/orders/:id/cancel", func(c *gin.Context) {
+		var req orderpb.CancelOrderRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				response.BadRequest(c, err.Error())
+				return
+			}
+		}
+		req.Id = c.Param("id")
+		res, err := orderClient.CancelOrder(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/orders/:id/pay", func(c *gin.Context) {
+		res, err := orderClient.PayOrder(middleware.OutgoingContext(c), &orderpb.PayOrderRequest{
+			OrderId: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/orders/:id/payment", func(c *gin.Context) {
+		res, err := orderClient.GetOrderPayment(middleware.OutgoingContext(c), &orderpb.GetOrderPaymentRequest{
+			OrderId: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/orders/:id/returns", func(c *gin.Context) {
+		var req orderpb.OpenReturnRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		req.OrderId = c.Param("id")
+		res, err := orderClient.OpenReturn(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/orders/:id/returns", func(c *gin.Context) {
+		res, err := orderClient.ListOrderReturns(middleware.OutgoingContext(c), &orderpb.ListOrderReturnsRequest{
+			OrderId: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/returns/:id", func(c *gin.Context) {
+		res, err := orderClient.GetReturn(middleware.OutgoingContext(c), &orderpb.ReturnRequest{
+			Id: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/returns/:id/approve", func(c *gin.Context) {
+		res, err := orderClient.ApproveReturn(middleware.OutgoingContext(c), &orderpb.ReturnRequest{
+			Id: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/returns/:id/reject", func(c *gin.Context) {
+		var req orderpb.RejectReturnRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				response.BadRequest(c, err.Error())
+				return
+			}
+		}
+		req.Id = c.Param("id")
+		res, err := orderClient.RejectReturn(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/returns/:id/receive", func(c *gin.Context) {
+		var req orderpb.ReceiveReturnRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				response.BadRequest(c, err.Error())
+				return
+			}
+		}
+		req.Id = c.Param("id")
+		res, err := orderClient.ReceiveReturn(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/returns/:id/refund", func(c *gin.Context) {
+		res, err := orderClient.RefundReturn(middleware.OutgoingContext(c), &orderpb.ReturnRequest{
+			Id: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/orders/:id/shipments", func(c *gin.Context) {
+		var req orderpb.CreateShipmentRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				response.BadRequest(c, err.Error())
+				return
+			}
+		}
+		req.OrderId = c.Param("id")
+		res, err := orderClient.CreateShipment(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/orders/:id/shipments", func(c *gin.Context) {
+		res, err := orderClient.ListOrderShipments(middleware.OutgoingContext(c), &orderpb.ListOrderShipmentsRequest{
+			OrderId: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/shipments/:id", func(c *gin.Context) {
+		res, err := orderClient.GetShipment(middleware.OutgoingContext(c), &orderpb.ShipmentRequest{
+			Id: c.Param("id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.PUT("/shipments/:id/tracking", func(c *gin.Context) {
+		var req orderpb.SetShipmentTrackingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		req.Id = c.Param("id")
+		res, err := orderClient.SetShipmentTracking(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/shipments/:id/status", func(c *gin.Context) {
+		var req orderpb.UpdateShipmentStatusRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, err.Error())
 			return
 		}
-		res, err := orderClient.UpdateOrderStatus(context.Background(), &req)
+		req.Id = c.Param("id")
+		res, err := orderClient.UpdateShipmentStatus(middleware.OutgoingContext(c), &req)
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/orders", func(c *gin.Context) {
-		userID := c.Query("user_id")
+	api.GET("/orders", func(c *gin.Context) {
 		page := queryInt(c, "page", 1)
 		limit := queryInt(c, "limit", 10)
-		res, err := orderClient.ListUserOrders(context.Background(), &orderpb.ListOrdersRequest{
-			UserId: userID, Page: int32(page), Limit: int32(limit),
+		res, err := orderClient.ListUserOrders(middleware.OutgoingContext(c), &orderpb.ListOrdersRequest{
+			Page: int32(page), Limit: int32(limit),
 		})
 		handleResponse(c, res, err)
 	})
 
-	r.POST("/api/v1/products", func(c *gin.Context) {
+	api.GET("/cart", func(c *gin.Context) {
+		res, err := cartClient.GetCart(middleware.OutgoingContext(c), &orderpb.GetCartRequest{})
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/cart/items", func(c *gin.Context) {
+		var req orderpb.CartItemRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		res, err := cartClient.AddCartItem(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.PUT("/cart/items/:productId", func(c *gin.Context) {
+		var req orderpb.CartItemRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		req.ProductId = c.Param("productId")
+		res, err := cartClient.UpdateCartItem(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.DELETE("/cart/items/:productId", func(c *gin.Context) {
+		res, err := cartClient.RemoveCartItem(middleware.OutgoingContext(c), &orderpb.RemoveCartItemRequest{
+			ProductId: c.Param("productId"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.DELETE("/cart", func(c *gin.Context) {
+		_, err := cartClient.ClearCart(middleware.OutgoingContext(c), &orderpb.ClearCartRequest{})
+		handleResponse(c, gin.H{"message": "cleared"}, err)
+	})
+
+	api.POST("/cart/checkout", func(c *gin.Context) {
+		var req orderpb.CheckoutCartRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				response.BadRequest(c, err.Error())
+				return
+			}
+		}
+		res, err := cartClient.CheckoutCart(middleware.OutgoingContext(c), &req)
+		handleResponse(c, res, err)
+	})
+
+	api.POST("/products", func(c *gin.Context) {
 		var req inventorypb.CreateProductRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			response.BadRequest(c, err.Error())
 			return
 		}
-		res, err := inventoryClient.CreateProduct(context.Background(), &req)
+		res, err := inventoryClient.CreateProduct(middleware.OutgoingContext(c), &req)
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/products/:id", func(c *gin.Context) {
-		res, err := inventoryClient.GetProductByID(context.Background(), &inventorypb.GetProductRequest{
+	api.GET("/products/:id", func(c *gin.Context) {
+		res, err := inventoryClient.GetProductByID(middleware.OutgoingContext(c), &inventorypb.GetProductRequest{
 			Id: c.Param("id"),
 		})
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/products/cache/:id", func(c *gin.Context) {
-		res, err := inventoryClient.GetProductByIDFromCache(context.Background(), &inventorypb.GetProductByIDFromCacheRequest{
+	api.GET("/products/cache/:id", func(c *gin.Context) {
+		res, err := inventoryClient.GetProductByIDFromCache(middleware.OutgoingContext(c), &inventorypb.GetProductByIDFromCacheRequest{
 			Id: c.Param("id"),
 		})
 		handleResponse(c, res, err)
 	})
 
-	r.DELETE("/api/v1/products/:id", func(c *gin.Context) {
-		_, err := inventoryClient.DeleteProduct(context.Background(), &inventorypb.DeleteProductRequest{
+	api.DELETE("/products/:id", func(c *gin.Context) {
+		_, err := inventoryClient.DeleteProduct(middleware.OutgoingContext(c), &inventorypb.DeleteProductRequest{
 			Id: c.Param("id"),
 		})
 		handleResponse(c, gin.H{"message": "deleted"}, err)
 	})
 
-	r.GET("/api/v1/products", func(c *gin.Context) {
-		res, err := inventoryClient.ListProducts(context.Background(), &inventorypb.ListProductsRequest{
+	api.GET("/products", func(c *gin.Context) {
+		res, err := inventoryClient.ListProducts(middleware.OutgoingContext(c), &inventorypb.ListProductsRequest{
 			Name:       optional(c.Query("name")),
 			CategoryId: optional(c.Query("category_id")),
 			Limit:      int32(queryInt(c, "limit", 10)),
@@ -123,52 +331,188 @@ func main() {
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/products/cache", func(c *gin.Context) {
-		res, err := inventoryClient.GetAllProductsFromCache(context.Background(), &inventorypb.GetAllProductsFromCacheRequest{})
+	api.GET("/products/cache", func(c *gin.Context) {
+		res, err := inventoryClient.GetAllProductsFromCache(middleware.OutgoingContext(c), &inventorypb.GetAllProductsFromCacheRequest{})
 		handleResponse(c, res, err)
 	})
 
-	r.POST("/api/v1/categories", func(c *gin.Context) {
+	api.POST("/categories", func(c *gin.Context) {
 		var req inventorypb.CreateCategoryRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			response.BadRequest(c, err.Error())
 			return
 		}
-		res, err := inventoryClient.CreateCategory(context.Background(), &req)
+		res, err := inventoryClient.CreateCategory(middleware.OutgoingContext(c), &req)
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/categories/:id", func(c *gin.Context) {
-		res, err := inventoryClient.GetCategoryByID(context.Background(), &inventorypb.GetCategoryRequest{
+	api.GET("/categories/:id", func(c *gin.Context) {
+		res, err := inventoryClient.GetCategoryByID(middleware.OutgoingContext(c), &inventorypb.GetCategoryRequest{
 			Id: c.Param("id"),
 		})
 		handleResponse(c, res, err)
 	})
 
-	r.DELETE("/api/v1/categories/:id", func(c *gin.Context) {
-		_, err := inventoryClient.DeleteCategory(context.Background(), &inventorypb.DeleteCategoryRequest{
+	api.DELETE("/categories/:id", func(c *gin.Context) {
+		_, err := inventoryClient.DeleteCategory(middleware.OutgoingContext(c), &inventorypb.DeleteCategoryRequest{
 			Id: c.Param("id"),
 		})
 		handleResponse(c, gin.H{"message": "deleted"}, err)
 	})
 
-	r.GET("/api/v1/categories", func(c *gin.Context) {
-		res, err := inventoryClient.ListCategories(context.Background(), &inventorypb.ListCategoriesRequest{
+	api.GET("/categories", func(c *gin.Context) {
+		res, err := inventoryClient.ListCategories(middleware.OutgoingContext(c), &inventorypb.ListCategoriesRequest{
 			Name: optional(c.Query("name")),
 		})
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/statistics/user-orders/:user_id", func(c *gin.Context) {
-		res, err := statClient.GetUserOrdersStatistics(context.Background(), &statpb.UserOrderStatisticsRequest{
-			UserId: c.Param("user_id"),
+	api.GET("/statistics/user-orders/:user_id", func(c *gin.Context) {
+		res, err := statClient.GetUserOrdersStatistics(middleware.OutgoingContext(c), &statpb.UserOrderStatisticsRequest{
+			UserId:   c.Param("user_id"),
+			Timezone: c.Query("timezone"),
 		})
 		handleResponse(c, res, err)
 	})
 
-	r.GET("/api/v1/statistics/user/:user_id", func(c *gin.Context) {
-		res, err := statClient.GetUserStatistics(context.Background(), &statpb.UserStatisticsRequest{
-			UserId: c.Param("user_id"),
+	api.GET("/statistics/user/:user_id", func(c *gin.Context) {
+		res, err := statClient.GetUserStatistics(middleware.OutgoingContext(c), &statpb.UserStatisticsRequest{
+			UserId:   c.Param("user_id"),
+			Timezone: c.Query("timezone"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/users/active", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetActiveUsers(middleware.OutgoingContext(c), &statpb.ActiveUsersRequest{
+			From:        from,
+			To:          to,
+			Granularity: c.Query("granularity"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/users/cohorts", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetCohortRetention(middleware.OutgoingContext(c), &statpb.CohortRetentionRequest{
+			From: from,
+			To:   to,
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/revenue", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetRevenue(middleware.OutgoingContext(c), &statpb.RevenueRequest{
+			From:        from,
+			To:          to,
+			Granularity: c.Query("granularity"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/revenue/summary", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetRevenueSummary(middleware.OutgoingContext(c), &statpb.RevenueSummaryRequest{
+			From: from,
+			To:   to,
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/products/sales", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetProductSales(middleware.OutgoingContext(c), &statpb.ProductSalesRequest{
+			From:  from,
+			To:    to,
+			Limit: int32(queryInt(c, "limit", 0)),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/products/refunds", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetProductRefunds(middleware.OutgoingContext(c), &statpb.ProductRefundsRequest{
+			From:  from,
+			To:    to,
+			Limit: int32(queryInt(c, "limit", 0)),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/categories/sales", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetCategorySales(middleware.OutgoingContext(c), &statpb.CategorySalesRequest{
+			From: from,
+			To:   to,
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/products/top", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetTopProducts(middleware.OutgoingContext(c), &statpb.TopProductsRequest{
+			From:       from,
+			To:         to,
+			RankBy:     c.Query("rank_by"),
+			Limit:      int32(queryInt(c, "limit", 0)),
+			CategoryId: c.Query("category_id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/products/trending", func(c *gin.Context) {
+		to, err := queryTime(c, "to")
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		res, err := statClient.GetTrendingProducts(middleware.OutgoingContext(c), &statpb.TrendingProductsRequest{
+			To:         to,
+			WindowDays: int32(queryInt(c, "window_days", 0)),
+			RankBy:     c.Query("rank_by"),
+			Limit:      int32(queryInt(c, "limit", 0)),
+			CategoryId: c.Query("category_id"),
+		})
+		handleResponse(c, res, err)
+	})
+
+	api.GET("/statistics/timeseries", func(c *gin.Context) {
+		from, to, ok := queryRange(c)
+		if !ok {
+			return
+		}
+		res, err := statClient.GetTimeSeries(middleware.OutgoingContext(c), &statpb.TimeSeriesRequest{
+			Metric:      c.Query("metric"),
+			From:        from,
+			To:          to,
+			Granularity: c.Query("granularity"),
+			Timezone:    c.Query("timezone"),
 		})
 		handleResponse(c, res, err)
 	})
@@ -189,11 +533,7 @@ func main() {
 }
 
 func handleResponse(c *gin.Context, res any, err error) {
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	c.JSON(http.StatusOK, res)
+	response.JSON(c, res, err)
 }
 
 func waitForShutdown(server *http.Server) {
@@ -226,6 +566,34 @@ func queryInt(c *gin.Context, key string, defaultVal int) int {
 	return val
 }
 
+// queryRange parses the optional RFC 3339 "from" and "to" query parameters.
+// It responds with 400 and returns false when one of them is malformed.
+func queryRange(c *gin.Context) (*timestamppb.Timestamp, *timestamppb.Timestamp, bool) {
+	from, err := queryTime(c, "from")
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return nil, nil, false
+	}
+	to, err := queryTime(c, "to")
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return nil, nil, false
+	}
+	return from, to, true
+}
+
+func queryTime(c *gin.Context, key string) (*timestamppb.Timestamp, error) {
+	val := c.Query(key)
+	if val == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: expected an RFC 3339 time", key)
+	}
+	return timestamppb.New(t), nil
+}
+
 func optional(val string) *string {
 	if val == "" {
 		return nil
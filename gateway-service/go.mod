@@ -4,6 +4,7 @@ go 1.24.0
 
 require (
 	github.com/gin-gonic/gin v1.10.0
+	github.com/golang-jwt/jwt/v5 v5.2.2
 	google.golang.org/grpc v1.71.1
 	google.golang.org/protobuf v1.36.4
 )
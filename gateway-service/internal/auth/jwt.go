@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	MetadataUserID = "x-user-id"
+	MetadataRoles  = "x-user-roles"
+)
+
+type Claims struct {
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// KeySet holds the keys accepted for token verification, indexed by key ID.
+// A key registered without an ID is used for tokens that carry no "kid" header.
+type KeySet struct {
+	hmac map[string][]byte
+	rsa  map[string]*rsa.PublicKey
+}
+
+func NewKeySet() *KeySet {
+	return &KeySet{
+		hmac: make(map[string][]byte),
+		rsa:  make(map[string]*rsa.PublicKey),
+	}
+}
+
+// LoadKeySet parses comma separated "kid=value" lists. HS256 values are shared
+// secrets, RS256 values are paths to PEM encoded public keys.
+func LoadKeySet(hs256, rs256 string) (*KeySet, error) {
+	keys := NewKeySet()
+
+	for kid, secret := range parseKeyList(hs256) {
+		keys.AddHMAC(kid, []byte(secret))
+	}
+
+	for kid, path := range parseKeyList(rs256) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RS256 key %q: %w", kid, err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RS256 key %q: %w", kid, err)
+		}
+		keys.AddRSA(kid, key)
+	}
+
+	return keys, nil
+}
+
+func (k *KeySet) AddHMAC(kid string, secret []byte) {
+	k.hmac[kid] = secret
+}
+
+func (k *KeySet) AddRSA(kid string, key *rsa.PublicKey) {
+	k.rsa[kid] = key
+}
+
+func (k *KeySet) Empty() bool {
+	return len(k.hmac) == 0 && len(k.rsa) == 0
+}
+
+func (k *KeySet) lookup(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		if kid != "" {
+			if key, ok := k.hmac[kid]; ok {
+				return key, nil
+			}
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		var set jwt.VerificationKeySet
+		for _, key := range k.hmac {
+			set.Keys = append(set.Keys, key)
+		}
+		return set, nil
+	case jwt.SigningMethodRS256.Alg():
+		if kid != "" {
+			if key, ok := k.rsa[kid]; ok {
+				return key, nil
+			}
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		var set jwt.VerificationKeySet
+		for _, key := range k.rsa {
+			set.Keys = append(set.Keys, key)
+		}
+		return set, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing method %q", token.Method.Alg())
+	}
+}
+
+type Verifier struct {
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+func NewVerifier(keys *KeySet, issuer, audience string) *Verifier {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+
+	return &Verifier{
+		keys:   keys,
+		parser: jwt.NewParser(opts...),
+	}
+}
+
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	var claims Claims
+	if _, err := v.parser.ParseWithClaims(tokenString, &claims, v.keys.lookup); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+	return &claims, nil
+}
+
+func parseKeyList(list string) map[string]string {
+	keys := make(map[string]string)
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, value, found := strings.Cut(entry, "=")
+		if !found {
+			kid, value = "", entry
+		}
+		keys[strings.TrimSpace(kid)] = strings.TrimSpace(value)
+	}
+	return keys
+}
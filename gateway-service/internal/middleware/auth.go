@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mephirious/advanced-programming-2/gateway-service/internal/auth"
+	"github.com/mephirious/advanced-programming-2/gateway-service/internal/response"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	userIDKey = "userID"
+	rolesKey  = "roles"
+)
+
+func Auth(verifier *auth.Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || token == "" {
+			response.Unauthorized(c, "missing bearer token")
+			return
+		}
+
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			response.Unauthorized(c, "invalid token")
+			return
+		}
+
+		c.Set(userIDKey, claims.Subject)
+		c.Set(rolesKey, claims.Roles)
+		c.Next()
+	}
+}
+
+// OutgoingContext returns the request context with the request id and the
+// authenticated identity attached as gRPC metadata for the downstream services.
+func OutgoingContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+
+	if requestID := c.GetString(response.RequestIDKey); requestID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, metadataRequestID, requestID)
+	}
+	if userID := c.GetString(userIDKey); userID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, auth.MetadataUserID, userID)
+	}
+	if roles := c.GetStringSlice(rolesKey); len(roles) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, auth.MetadataRoles, strings.Join(roles, ","))
+	}
+
+	return ctx
+}
@@ -0,0 +1,158 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RequestIDKey is the gin context key holding the id of the current request.
+const RequestIDKey = "requestID"
+
+type ErrorBody struct {
+	Code      string            `json:"code"`
+	Message   string            `json:"message"`
+	Details   []json.RawMessage `json:"details,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	Retryable bool              `json:"retryable"`
+}
+
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+func JSON(c *gin.Context, res any, err error) {
+	if err != nil {
+		Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// Error writes an upstream gRPC error as a JSON error envelope with the HTTP
+// status matching its code.
+func Error(c *gin.Context, err error) {
+	st := status.Convert(err)
+
+	var details []json.RawMessage
+	for _, detail := range st.Details() {
+		msg, ok := detail.(proto.Message)
+		if !ok {
+			continue
+		}
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			continue
+		}
+		details = append(details, data)
+	}
+
+	abort(c, st.Code(), st.Message(), details)
+}
+
+func BadRequest(c *gin.Context, message string) {
+	abort(c, codes.InvalidArgument, message, nil)
+}
+
+func Unauthorized(c *gin.Context, message string) {
+	abort(c, codes.Unauthenticated, message, nil)
+}
+
+func abort(c *gin.Context, code codes.Code, message string, details []json.RawMessage) {
+	c.AbortWithStatusJSON(HTTPStatus(code), ErrorEnvelope{
+		Error: ErrorBody{
+			Code:      codeName(code),
+			Message:   message,
+			Details:   details,
+			RequestID: c.GetString(RequestIDKey),
+			Retryable: Retryable(code),
+		},
+	})
+}
+
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Retryable reports whether repeating the same request may succeed.
+func Retryable(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// codeName returns the canonical upper snake case name of a gRPC code,
+// e.g. NOT_FOUND.
+func codeName(code codes.Code) string {
+	switch code {
+	case codes.OK:
+		return "OK"
+	case codes.Canceled:
+		return "CANCELLED"
+	case codes.Unknown:
+		return "UNKNOWN"
+	case codes.InvalidArgument:
+		return "INVALID_ARGUMENT"
+	case codes.DeadlineExceeded:
+		return "DEADLINE_EXCEEDED"
+	case codes.NotFound:
+		return "NOT_FOUND"
+	case codes.AlreadyExists:
+		return "ALREADY_EXISTS"
+	case codes.PermissionDenied:
+		return "PERMISSION_DENIED"
+	case codes.ResourceExhausted:
+		return "RESOURCE_EXHAUSTED"
+	case codes.FailedPrecondition:
+		return "FAILED_PRECONDITION"
+	case codes.Aborted:
+		return "ABORTED"
+	case codes.OutOfRange:
+		return "OUT_OF_RANGE"
+	case codes.Unimplemented:
+		return "UNIMPLEMENTED"
+	case codes.Unavailable:
+		return "UNAVAILABLE"
+	case codes.DataLoss:
+		return "DATA_LOSS"
+	case codes.Unauthenticated:
+		return "UNAUTHENTICATED"
+	default:
+		return "INTERNAL"
+	}
+}
@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	InventoryService_CreateProduct_FullMethodName           = "/inventory.InventoryService/CreateProduct"
 	InventoryService_GetProductByID_FullMethodName          = "/inventory.InventoryService/GetProductByID"
+	InventoryService_GetProductsByIDs_FullMethodName        = "/inventory.InventoryService/GetProductsByIDs"
 	InventoryService_UpdateProduct_FullMethodName           = "/inventory.InventoryService/UpdateProduct"
 	InventoryService_DeleteProduct_FullMethodName           = "/inventory.InventoryService/DeleteProduct"
 	InventoryService_ListProducts_FullMethodName            = "/inventory.InventoryService/ListProducts"
@@ -32,6 +33,11 @@ const (
 	InventoryService_ListCategories_FullMethodName          = "/inventory.InventoryService/ListCategories"
 	InventoryService_GetProductByIDFromCache_FullMethodName = "/inventory.InventoryService/GetProductByIDFromCache"
 	InventoryService_GetAllProductsFromCache_FullMethodName = "/inventory.InventoryService/GetAllProductsFromCache"
+	InventoryService_ReserveStock_FullMethodName            = "/inventory.InventoryService/ReserveStock"
+	InventoryService_ReleaseStock_FullMethodName            = "/inventory.InventoryService/ReleaseStock"
+	InventoryService_CommitReservation_FullMethodName       = "/inventory.InventoryService/CommitReservation"
+	InventoryService_ReturnStock_FullMethodName             = "/inventory.InventoryService/ReturnStock"
+	InventoryService_RestockItems_FullMethodName            = "/inventory.InventoryService/RestockItems"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -43,6 +49,7 @@ type InventoryServiceClient interface {
 	// Product RPCs
 	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*Product, error)
 	GetProductByID(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error)
+	GetProductsByIDs(ctx context.Context, in *GetProductsByIDsRequest, opts ...grpc.CallOption) (*GetProductsByIDsResponse, error)
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
@@ -55,6 +62,12 @@ type InventoryServiceClient interface {
 	// Cache RPC
 	GetProductByIDFromCache(ctx context.Context, in *GetProductByIDFromCacheRequest, opts ...grpc.CallOption) (*Product, error)
 	GetAllProductsFromCache(ctx context.Context, in *GetAllProductsFromCacheRequest, opts ...grpc.CallOption) (*GetAllProductsFromCacheResponse, error)
+	// Stock reservation RPCs
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error)
+	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*Reservation, error)
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	ReturnStock(ctx context.Context, in *ReturnStockRequest, opts ...grpc.CallOption) (*Reservation, error)
+	RestockItems(ctx context.Context, in *RestockItemsRequest, opts ...grpc.CallOption) (*Restock, error)
 }
 
 type inventoryServiceClient struct {
@@ -85,6 +98,16 @@ func (c *inventoryServiceClient) GetProductByID(ctx context.Context, in *GetProd
 	return out, nil
 }
 
+func (c *inventoryServiceClient) GetProductsByIDs(ctx context.Context, in *GetProductsByIDsRequest, opts ...grpc.CallOption) (*GetProductsByIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductsByIDsResponse)
+	err := c.cc.Invoke(ctx, InventoryService_GetProductsByIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inventoryServiceClient) UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*Product, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Product)
@@ -185,6 +208,56 @@ func (c *inventoryServiceClient) GetAllProductsFromCache(ctx context.Context, in
 	return out, nil
 }
 
+func (c *inventoryServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, InventoryService_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, InventoryService_ReleaseStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, InventoryService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) ReturnStock(ctx context.Context, in *ReturnStockRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, InventoryService_ReturnStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) RestockItems(ctx context.Context, in *RestockItemsRequest, opts ...grpc.CallOption) (*Restock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Restock)
+	err := c.cc.Invoke(ctx, InventoryService_RestockItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
@@ -194,6 +267,7 @@ type InventoryServiceServer interface {
 	// Product RPCs
 	CreateProduct(context.Context, *CreateProductRequest) (*Product, error)
 	GetProductByID(context.Context, *GetProductRequest) (*Product, error)
+	GetProductsByIDs(context.Context, *GetProductsByIDsRequest) (*GetProductsByIDsResponse, error)
 	UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error)
 	DeleteProduct(context.Context, *DeleteProductRequest) (*emptypb.Empty, error)
 	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
@@ -206,6 +280,12 @@ type InventoryServiceServer interface {
 	// Cache RPC
 	GetProductByIDFromCache(context.Context, *GetProductByIDFromCacheRequest) (*Product, error)
 	GetAllProductsFromCache(context.Context, *GetAllProductsFromCacheRequest) (*GetAllProductsFromCacheResponse, error)
+	// Stock reservation RPCs
+	ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error)
+	ReleaseStock(context.Context, *ReleaseStockRequest) (*Reservation, error)
+	CommitReservation(context.Context, *CommitReservationRequest) (*Reservation, error)
+	ReturnStock(context.Context, *ReturnStockRequest) (*Reservation, error)
+	RestockItems(context.Context, *RestockItemsRequest) (*Restock, error)
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
@@ -222,6 +302,9 @@ func (UnimplementedInventoryServiceServer) CreateProduct(context.Context, *Creat
 func (UnimplementedInventoryServiceServer) GetProductByID(context.Context, *GetProductRequest) (*Product, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProductByID not implemented")
 }
+func (UnimplementedInventoryServiceServer) GetProductsByIDs(context.Context, *GetProductsByIDsRequest) (*GetProductsByIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductsByIDs not implemented")
+}
 func (UnimplementedInventoryServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*Product, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
 }
@@ -252,6 +335,21 @@ func (UnimplementedInventoryServiceServer) GetProductByIDFromCache(context.Conte
 func (UnimplementedInventoryServiceServer) GetAllProductsFromCache(context.Context, *GetAllProductsFromCacheRequest) (*GetAllProductsFromCacheResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllProductsFromCache not implemented")
 }
+func (UnimplementedInventoryServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReleaseStock(context.Context, *ReleaseStockRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) CommitReservation(context.Context, *CommitReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedInventoryServiceServer) ReturnStock(context.Context, *ReturnStockRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnStock not implemented")
+}
+func (UnimplementedInventoryServiceServer) RestockItems(context.Context, *RestockItemsRequest) (*Restock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestockItems not implemented")
+}
 func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
@@ -309,6 +407,24 @@ func _InventoryService_GetProductByID_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_GetProductsByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductsByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).GetProductsByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_GetProductsByIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).GetProductsByIDs(ctx, req.(*GetProductsByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InventoryService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateProductRequest)
 	if err := dec(in); err != nil {
@@ -489,6 +605,96 @@ func _InventoryService_GetAllProductsFromCache_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReleaseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReleaseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReleaseStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReleaseStock(ctx, req.(*ReleaseStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_ReturnStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ReturnStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ReturnStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ReturnStock(ctx, req.(*ReturnStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_RestockItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestockItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).RestockItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_RestockItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).RestockItems(ctx, req.(*RestockItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -504,6 +710,10 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProductByID",
 			Handler:    _InventoryService_GetProductByID_Handler,
 		},
+		{
+			MethodName: "GetProductsByIDs",
+			Handler:    _InventoryService_GetProductsByIDs_Handler,
+		},
 		{
 			MethodName: "UpdateProduct",
 			Handler:    _InventoryService_UpdateProduct_Handler,
@@ -544,6 +754,26 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllProductsFromCache",
 			Handler:    _InventoryService_GetAllProductsFromCache_Handler,
 		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _InventoryService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "ReleaseStock",
+			Handler:    _InventoryService_ReleaseStock_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _InventoryService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "ReturnStock",
+			Handler:    _InventoryService_ReturnStock_Handler,
+		},
+		{
+			MethodName: "RestockItems",
+			Handler:    _InventoryService_RestockItems_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/inventory.proto",
@@ -95,7 +95,7 @@ grpcurl -plaintext -d '{
 echo -e "\nUpdating Order Status..."
 grpcurl -plaintext -d '{
   "id": "681c5a19cb5964c723ac8006",
-  "status": "COMPLETED"
+  "status": "PAID"
 }' $GRPC_SERVER $SERVICE/UpdateOrderStatus
 
 echo -e "\nListing Orders for User..."
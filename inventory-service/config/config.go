@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/mephirious/advanced-programming-2/inventory-service/pkg/mongo"
@@ -12,9 +13,11 @@ import (
 
 type (
 	Config struct {
-		Mongo  mongo.Config
-		NATS   NATSConfig
-		Server Server
+		Mongo       mongo.Config
+		NATS        NATSConfig
+		Outbox      OutboxConfig
+		Server      Server
+		Reservation ReservationConfig
 	}
 
 	Server struct {
@@ -29,6 +32,15 @@ type (
 	NATSConfig struct {
 		URL string `env:"NATS_URL,required"`
 	}
+
+	OutboxConfig struct {
+		RelayInterval time.Duration `env:"OUTBOX_RELAY_INTERVAL" envDefault:"1s"`
+	}
+
+	ReservationConfig struct {
+		TTL            time.Duration `env:"RESERVATION_TTL" envDefault:"15m"`
+		ExpiryInterval time.Duration `env:"RESERVATION_EXPIRY_INTERVAL" envDefault:"30s"`
+	}
 )
 
 func New() (*Config, error) {
@@ -60,6 +72,32 @@ func New() (*Config, error) {
 	cfg.Mongo.Password = os.Getenv("MONGO_PASSWORD")
 
 	cfg.NATS.URL = os.Getenv("NATS_URL")
+	cfg.Outbox.RelayInterval, err = durationEnv("OUTBOX_RELAY_INTERVAL", time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Reservation.TTL, err = durationEnv("RESERVATION_TTL", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Reservation.ExpiryInterval, err = durationEnv("RESERVATION_EXPIRY_INTERVAL", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
+
+func durationEnv(key string, defaultVal time.Duration) (time.Duration, error) {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return d, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/adapter/grpc/service/handler"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/usecase"
 	pb "github.com/mephirious/advanced-programming-2/inventory-service/proto"
+	"github.com/mephirious/advanced-programming-2/inventory-service/pkg/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -20,15 +21,15 @@ type GRPCServer struct {
 	listener net.Listener
 }
 
-func NewGRPCServer(cfg config.Config, productUC usecase.ProductUseCase, categoryUC usecase.CategoryUseCase) (*GRPCServer, error) {
+func NewGRPCServer(cfg config.Config, productUC usecase.ProductUseCase, categoryUC usecase.CategoryUseCase, reservationUC usecase.ReservationUseCase) (*GRPCServer, error) {
 	addr := fmt.Sprintf("0.0.0.0:%d", cfg.Server.GRPCServer.Port)
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
 
-	s := grpc.NewServer()
-	handler := handler.NewInventoryHandler(productUC, categoryUC)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(), errorInterceptor))
+	handler := handler.NewInventoryHandler(productUC, categoryUC, reservationUC)
 
 	pb.RegisterInventoryServiceServer(s, handler)
 
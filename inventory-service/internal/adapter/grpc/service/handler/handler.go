@@ -2,6 +2,9 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	empty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain"
@@ -13,15 +16,17 @@ import (
 )
 
 type InventoryHandler struct {
-	productUC  usecase.ProductUseCase
-	categoryUC usecase.CategoryUseCase
+	productUC     usecase.ProductUseCase
+	categoryUC    usecase.CategoryUseCase
+	reservationUC usecase.ReservationUseCase
 	inventory.UnimplementedInventoryServiceServer
 }
 
-func NewInventoryHandler(productUC usecase.ProductUseCase, categoryUC usecase.CategoryUseCase) *InventoryHandler {
+func NewInventoryHandler(productUC usecase.ProductUseCase, categoryUC usecase.CategoryUseCase, reservationUC usecase.ReservationUseCase) *InventoryHandler {
 	return &InventoryHandler{
-		productUC:  productUC,
-		categoryUC: categoryUC,
+		productUC:     productUC,
+		categoryUC:    categoryUC,
+		reservationUC: reservationUC,
 	}
 }
 
@@ -43,7 +48,7 @@ func (h *InventoryHandler) CreateProduct(ctx context.Context, req *inventory.Cre
 }
 
 func (h *InventoryHandler) GetProductByID(ctx context.Context, req *inventory.GetProductRequest) (*inventory.Product, error) {
-	id, err := primitive.ObjectIDFromHex(req.GetId())
+	id, err := parseID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +61,41 @@ func (h *InventoryHandler) GetProductByID(ctx context.Context, req *inventory.Ge
 	return mapProductToProto(product), nil
 }
 
+// GetProductsByIDs returns the requested products and lists the IDs that have
+// no product, so callers can tell missing products from failed lookups.
+func (h *InventoryHandler) GetProductsByIDs(ctx context.Context, req *inventory.GetProductsByIDsRequest) (*inventory.GetProductsByIDsResponse, error) {
+	ids := make([]primitive.ObjectID, 0, len(req.GetIds()))
+	for _, rawID := range req.GetIds() {
+		id, err := parseID(rawID)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	products, err := h.productUC.GetProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[primitive.ObjectID]bool, len(products))
+	res := &inventory.GetProductsByIDsResponse{}
+	for _, product := range products {
+		found[product.ID] = true
+		res.Products = append(res.Products, mapProductToProto(&product))
+	}
+	for _, id := range ids {
+		if !found[id] {
+			res.MissingIds = append(res.MissingIds, id.Hex())
+		}
+	}
+	return res, nil
+}
+
 func (h *InventoryHandler) GetProductByIDFromCache(ctx context.Context, req *inventory.GetProductByIDFromCacheRequest) (*inventory.Product, error) {
-	id, err := primitive.ObjectIDFromHex(req.GetId())
+	id, err := parseID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +109,7 @@ func (h *InventoryHandler) GetProductByIDFromCache(ctx context.Context, req *inv
 }
 
 func (h *InventoryHandler) UpdateProduct(ctx context.Context, req *inventory.UpdateProductRequest) (*inventory.Product, error) {
-	id, err := primitive.ObjectIDFromHex(req.GetId())
+	id, err := parseID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +131,7 @@ func (h *InventoryHandler) UpdateProduct(ctx context.Context, req *inventory.Upd
 }
 
 func (h *InventoryHandler) DeleteProduct(ctx context.Context, req *inventory.DeleteProductRequest) (*empty.Empty, error) {
-	id, err := primitive.ObjectIDFromHex(req.GetId())
+	id, err := parseID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +183,14 @@ func (h *InventoryHandler) GetAllProductsFromCache(ctx context.Context, req *inv
 	}, nil
 }
 
+func parseID(id string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, fmt.Errorf("%w: invalid id %q", domain.ErrInvalidArgument, id)
+	}
+	return objectID, nil
+}
+
 func optionalString(s string) *string {
 	if s == "" {
 		return nil
@@ -194,7 +240,7 @@ func (h *InventoryHandler) CreateCategory(ctx context.Context, req *inventory.Cr
 }
 
 func (h *InventoryHandler) GetCategoryByID(ctx context.Context, req *inventory.GetCategoryRequest) (*inventory.Category, error) {
-	id, err := primitive.ObjectIDFromHex(req.GetId())
+	id, err := parseID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +254,7 @@ func (h *InventoryHandler) GetCategoryByID(ctx context.Context, req *inventory.G
 }
 
 func (h *InventoryHandler) UpdateCategory(ctx context.Context, req *inventory.UpdateCategoryRequest) (*inventory.Category, error) {
-	id, err := primitive.ObjectIDFromHex(req.GetId())
+	id, err := parseID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -227,7 +273,7 @@ func (h *InventoryHandler) UpdateCategory(ctx context.Context, req *inventory.Up
 }
 
 func (h *InventoryHandler) DeleteCategory(ctx context.Context, req *inventory.DeleteCategoryRequest) (*empty.Empty, error) {
-	id, err := primitive.ObjectIDFromHex(req.GetId())
+	id, err := parseID(req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -264,3 +310,131 @@ func mapCategoryToProto(c *domain.Category) *inventory.Category {
 		UpdatedAt:   timestamppb.New(c.UpdatedAt),
 	}
 }
+
+func (h *InventoryHandler) ReserveStock(ctx context.Context, req *inventory.ReserveStockRequest) (*inventory.Reservation, error) {
+	items := make([]dto.ReservationItemDTO, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = dto.ReservationItemDTO{
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
+		}
+	}
+
+	reservation, err := h.reservationUC.ReserveStock(ctx, dto.ReserveStockDTO{
+		OrderID: req.GetOrderId(),
+		Items:   items,
+		TTL:     time.Duration(req.GetTtlSeconds()) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReservationToProto(reservation), nil
+}
+
+func (h *InventoryHandler) ReleaseStock(ctx context.Context, req *inventory.ReleaseStockRequest) (*inventory.Reservation, error) {
+	id, err := parseID(req.GetReservationId())
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := h.reservationUC.ReleaseStock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReservationToProto(reservation), nil
+}
+
+func (h *InventoryHandler) CommitReservation(ctx context.Context, req *inventory.CommitReservationRequest) (*inventory.Reservation, error) {
+	id, err := parseID(req.GetReservationId())
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := h.reservationUC.CommitReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReservationToProto(reservation), nil
+}
+
+func (h *InventoryHandler) ReturnStock(ctx context.Context, req *inventory.ReturnStockRequest) (*inventory.Reservation, error) {
+	id, err := parseID(req.GetReservationId())
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := h.reservationUC.ReturnStock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReservationToProto(reservation), nil
+}
+
+func (h *InventoryHandler) RestockItems(ctx context.Context, req *inventory.RestockItemsRequest) (*inventory.Restock, error) {
+	items := make([]dto.ReservationItemDTO, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = dto.ReservationItemDTO{
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
+		}
+	}
+
+	restock, err := h.reservationUC.RestockItems(ctx, dto.RestockItemsDTO{
+		Reference: req.GetReference(),
+		OrderID:   req.GetOrderId(),
+		Items:     items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory.Restock{
+		Id:        restock.ID.Hex(),
+		Reference: restock.Reference,
+		OrderId:   restock.OrderID,
+		Items:     mapReservationItemsToProto(restock.Items),
+		CreatedAt: timestamppb.New(restock.CreatedAt),
+	}, nil
+}
+
+func mapReservationToProto(r *domain.Reservation) *inventory.Reservation {
+	return &inventory.Reservation{
+		Id:        r.ID.Hex(),
+		OrderId:   r.OrderID,
+		Items:     mapReservationItemsToProto(r.Items),
+		Status:    mapReservationStatusToProto(r.Status),
+		ExpiresAt: timestamppb.New(r.ExpiresAt),
+		CreatedAt: timestamppb.New(r.CreatedAt),
+		UpdatedAt: timestamppb.New(r.UpdatedAt),
+	}
+}
+
+func mapReservationStatusToProto(status domain.ReservationStatus) inventory.ReservationStatus {
+	switch status {
+	case domain.ReservationStatusCommitted:
+		return inventory.ReservationStatus_COMMITTED
+	case domain.ReservationStatusReleased:
+		return inventory.ReservationStatus_RELEASED
+	case domain.ReservationStatusExpired:
+		return inventory.ReservationStatus_EXPIRED
+	case domain.ReservationStatusReturned:
+		return inventory.ReservationStatus_RETURNED
+	default:
+		return inventory.ReservationStatus_HELD
+	}
+}
+
+func mapReservationItemsToProto(items []domain.ReservationItem) []*inventory.ReservationItem {
+	res := make([]*inventory.ReservationItem, len(items))
+	for i, item := range items {
+		res[i] = &inventory.ReservationItem{
+			ProductId: item.ProductID.Hex(),
+			Quantity:  item.Quantity,
+		}
+	}
+	return res
+}
@@ -1,8 +1,8 @@
 package dto
 
 import (
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain"
-	pb "github.com/mephirious/advanced-programming-2/inventory-service/proto/events"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
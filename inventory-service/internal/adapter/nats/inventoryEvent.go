@@ -2,30 +2,40 @@ package producer
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/mephirious/advanced-programming-2/contracts/events"
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain"
-	"github.com/mephirious/advanced-programming-2/inventory-service/pkg/nats"
-	pb "github.com/mephirious/advanced-programming-2/inventory-service/proto/events"
+	"github.com/mephirious/advanced-programming-2/inventory-service/pkg/auth"
 )
 
 const PushTimeout = time.Second * 30
 
+// Outbox stores events that are published later by an outbox relay and
+// numbers the events of every aggregate.
+type Outbox interface {
+	AddMessage(ctx context.Context, id primitive.ObjectID, subject string, headers map[string]string, payload []byte) error
+	NextSequence(ctx context.Context, aggregateID string) (int64, error)
+}
+
+// InventoryEventProducer writes inventory events to the outbox. Push must be
+// called with the context of the transaction that changes the product.
 type InventoryEventProducer struct {
-	natsClient *nats.Client
-	subject    string
+	outbox  Outbox
+	subject string
 }
 
-func NewInventoryEventProducer(natsClient *nats.Client, subject string) *InventoryEventProducer {
+func NewInventoryEventProducer(outbox Outbox, subject string) *InventoryEventProducer {
 	return &InventoryEventProducer{
-		natsClient: natsClient,
-		subject:    subject,
+		outbox:  outbox,
+		subject: subject,
 	}
 }
 
@@ -33,12 +43,11 @@ func (p *InventoryEventProducer) Push(ctx context.Context, event *domain.Product
 	ctx, cancel := context.WithTimeout(ctx, PushTimeout)
 	defer cancel()
 
-	if eventType == pb.InventoryEventType_CREATED && event.Price <= 0 {
-		return fmt.Errorf("invalid price for CREATED inventory event: %f", event.Price)
-	}
-	if eventType == pb.InventoryEventType_CREATED && event.Stock <= 0 {
-		return fmt.Errorf("invalid stock for CREATED inventory event: %d", event.Stock)
+	sequence, err := p.outbox.NextSequence(ctx, event.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("p.outbox.NextSequence: %w", err)
 	}
+	eventID := primitive.NewObjectID()
 
 	pbEvent := &pb.InventoryEvent{
 		Id:          event.ID.Hex(),
@@ -50,6 +59,8 @@ func (p *InventoryEventProducer) Push(ctx context.Context, event *domain.Product
 		CreatedAt:   timestamppb.New(event.CreatedAt),
 		UpdatedAt:   timestamppb.New(event.UpdatedAt),
 		EventType:   eventType,
+		EventId:     eventID.Hex(),
+		Sequence:    sequence,
 	}
 
 	data, err := proto.Marshal(pbEvent)
@@ -57,12 +68,30 @@ func (p *InventoryEventProducer) Push(ctx context.Context, event *domain.Product
 		return fmt.Errorf("proto.Marshal: %w", err)
 	}
 
-	log.Printf("Publishing InventoryEvent to %s: %+v, data: %s", p.subject, pbEvent, hex.EncodeToString(data))
-	err = p.natsClient.Conn.Publish(p.subject, data)
-	if err != nil {
-		return fmt.Errorf("p.natsClient.Conn.Publish: %w", err)
+	envelope := events.Envelope{
+		ID:            eventID.Hex(),
+		Type:          events.InventoryTypes[eventType],
+		Source:        events.SourceInventoryService,
+		SchemaVersion: events.CurrentSchemaVersion,
+		Time:          time.Now(),
+		TraceID:       traceID(ctx, eventID),
+		ContentType:   events.ContentTypeProtobuf,
 	}
-	log.Printf("Inventory event pushed to %s: %+v [%s]", p.subject, event, eventType)
+
+	log.Printf("Queueing InventoryEvent for %s: %+v", p.subject, pbEvent)
+	if err := p.outbox.AddMessage(ctx, eventID, p.subject, envelope.Headers(), data); err != nil {
+		return fmt.Errorf("p.outbox.AddMessage: %w", err)
+	}
+	log.Printf("Inventory event queued for %s: %+v [%s]", p.subject, event, eventType)
 
 	return nil
 }
+
+// traceID returns the ID of the request that caused the event. Events raised
+// outside of a request are traced by their own ID.
+func traceID(ctx context.Context, eventID primitive.ObjectID) string {
+	if requestID, ok := auth.RequestIDFromContext(ctx); ok {
+		return requestID
+	}
+	return eventID.Hex()
+}
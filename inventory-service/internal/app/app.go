@@ -8,6 +8,8 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/mephirious/advanced-programming-2/contracts/outbox"
+	"github.com/mephirious/advanced-programming-2/contracts/streams"
 	"github.com/mephirious/advanced-programming-2/inventory-service/config"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/adapter/cache"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/adapter/grpc/service"
@@ -39,17 +41,40 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("nats.NewClient: %w", err)
 	}
-	inventoryProducer := producer.NewInventoryEventProducer(natsClient, "inventory.events")
+	js, err := natsClient.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	if err := streams.Ensure(ctx, js, streams.Inventory); err != nil {
+		return nil, err
+	}
+	outboxRepository := outbox.NewRepository(mongoDB.Connection)
+	if err := outboxRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("outbox indexes: %w", err)
+	}
+	inventoryProducer := producer.NewInventoryEventProducer(outboxRepository, "inventory.events")
+	outbox.NewRelay(js, outboxRepository, cfg.Outbox.RelayInterval).Start(ctx)
 	productCache := cache.NewProductCache()
 
 	productRepository := repository.NewProductRepository(mongoDB.Connection)
-	productUseCase := usecase.NewProductUseCase(productRepository, inventoryProducer, productCache)
+	productUseCase := usecase.NewProductUseCase(productRepository, inventoryProducer, productCache, mongoDB)
 	cache.StartCacheRefresher(productCache)
 
 	categoryRepository := repository.NewCategoryRepository(mongoDB.Connection)
 	categoryUseCase := usecase.NewCategoryUseCase(categoryRepository)
 
-	grpcServer, err := service.NewGRPCServer(*cfg, productUseCase, categoryUseCase)
+	reservationRepository := repository.NewReservationRepository(mongoDB.Connection)
+	if err := reservationRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("reservation indexes: %w", err)
+	}
+	restockRepository := repository.NewRestockRepository(mongoDB.Connection)
+	if err := restockRepository.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("restock indexes: %w", err)
+	}
+	reservationUseCase := usecase.NewReservationUseCase(reservationRepository, restockRepository, productRepository, inventoryProducer, productCache, mongoDB, cfg.Reservation.TTL)
+	usecase.StartReservationExpirer(ctx, reservationUseCase, cfg.Reservation.ExpiryInterval)
+
+	grpcServer, err := service.NewGRPCServer(*cfg, productUseCase, categoryUseCase, reservationUseCase)
 	if err != nil {
 		return nil, err
 	}
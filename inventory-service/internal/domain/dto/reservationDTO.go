@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type ReserveStockDTO struct {
+	OrderID string
+	Items   []ReservationItemDTO
+	TTL     time.Duration
+}
+
+type RestockItemsDTO struct {
+	Reference string
+	OrderID   string
+	Items     []ReservationItemDTO
+}
+
+type ReservationItemDTO struct {
+	ProductID string
+	Quantity  int32
+}
@@ -1,11 +1,17 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrProductNotFound  = fmt.Errorf("product %w", ErrNotFound)
+	ErrCategoryNotFound = fmt.Errorf("category %w", ErrNotFound)
+)
+
 type Product struct {
 	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Name        string             `json:"name" bson:"name"`
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationStatusHeld      ReservationStatus = "held"
+	ReservationStatusCommitted ReservationStatus = "committed"
+	ReservationStatusReleased  ReservationStatus = "released"
+	ReservationStatusExpired   ReservationStatus = "expired"
+	ReservationStatusReturned  ReservationStatus = "returned"
+)
+
+var ErrReservationNotFound = fmt.Errorf("reservation %w", ErrNotFound)
+
+type ReservationItem struct {
+	ProductID primitive.ObjectID `json:"product_id" bson:"product_id"`
+	Quantity  int32              `json:"quantity" bson:"quantity"`
+}
+
+// Reservation holds stock that has already been taken out of Product.Stock for
+// an order. Held reservations are either committed by the order, released, or
+// expired, in which case the stock is returned. Committed reservations are
+// returned when the order is cancelled after all.
+type Reservation struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrderID   string             `json:"order_id" bson:"order_id"`
+	Items     []ReservationItem  `json:"items" bson:"items"`
+	Status    ReservationStatus  `json:"status" bson:"status"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type StockShortage struct {
+	ProductID primitive.ObjectID
+	Requested int32
+	Available int32
+}
+
+// InsufficientStockError lists every product of a reservation request that
+// does not have enough stock.
+type InsufficientStockError struct {
+	Shortages []StockShortage
+}
+
+func (e *InsufficientStockError) Error() string {
+	parts := make([]string, len(e.Shortages))
+	for i, s := range e.Shortages {
+		parts[i] = fmt.Sprintf("%s (requested %d, available %d)", s.ProductID.Hex(), s.Requested, s.Available)
+	}
+	return "insufficient stock for products: " + strings.Join(parts, ", ")
+}
+
+func (e *InsufficientStockError) Unwrap() error {
+	return ErrFailedPrecondition
+}
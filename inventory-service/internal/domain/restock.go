@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Restock records goods that were put back into stock outside of a
+// reservation, such as returned items. Reference is unique, so the same goods
+// are never restocked twice.
+type Restock struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Reference string             `json:"reference" bson:"reference"`
+	OrderID   string             `json:"order_id,omitempty" bson:"order_id,omitempty"`
+	Items     []ReservationItem  `json:"items" bson:"items"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
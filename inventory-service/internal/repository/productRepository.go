@@ -15,9 +15,12 @@ import (
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *domain.Product) error
 	GetProductByID(ctx context.Context, id primitive.ObjectID) (*domain.Product, error)
+	GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Product, error)
 	UpdateProduct(ctx context.Context, product *domain.Product) error
 	DeleteProduct(ctx context.Context, id primitive.ObjectID) error
 	GetAllProducts(ctx context.Context, filter dto.ProductFilterDTO) ([]domain.Product, error)
+	DecrementStock(ctx context.Context, id primitive.ObjectID, quantity int32) (bool, error)
+	IncrementStock(ctx context.Context, id primitive.ObjectID, quantity int32) error
 }
 
 type productRepository struct {
@@ -50,6 +53,20 @@ func (r *productRepository) GetProductByID(ctx context.Context, id primitive.Obj
 	return &product, nil
 }
 
+func (r *productRepository) GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Product, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []domain.Product
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
 func (r *productRepository) UpdateProduct(ctx context.Context, product *domain.Product) error {
 	product.UpdatedAt = time.Now()
 
@@ -110,3 +127,32 @@ func (r *productRepository) GetAllProducts(ctx context.Context, filter dto.Produ
 
 	return products, nil
 }
+
+// DecrementStock atomically takes quantity out of the product stock. It reports
+// false without changing anything when the product has less stock than requested.
+func (r *productRepository) DecrementStock(ctx context.Context, id primitive.ObjectID, quantity int32) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "stock": bson.M{"$gte": quantity}},
+		bson.M{
+			"$inc": bson.M{"stock": -quantity},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *productRepository) IncrementStock(ctx context.Context, id primitive.ObjectID, quantity int32) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$inc": bson.M{"stock": quantity},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReservationRepository interface {
+	CreateReservation(ctx context.Context, reservation *domain.Reservation) error
+	GetReservationByID(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error)
+	GetActiveReservationByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error)
+	TransitionStatus(ctx context.Context, id primitive.ObjectID, from, to domain.ReservationStatus) (bool, error)
+	GetExpiredReservations(ctx context.Context, now time.Time, limit int64) ([]domain.Reservation, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type reservationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReservationRepository(db *mongo.Database) *reservationRepository {
+	return &reservationRepository{
+		collection: db.Collection("reservations"),
+	}
+}
+
+func (r *reservationRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": domain.ReservationStatusHeld}),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}},
+		},
+	})
+	return err
+}
+
+func (r *reservationRepository) CreateReservation(ctx context.Context, reservation *domain.Reservation) error {
+	reservation.CreatedAt = time.Now()
+	reservation.UpdatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, reservation)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: order %s already has a held reservation", domain.ErrConflict, reservation.OrderID)
+	}
+	return err
+}
+
+func (r *reservationRepository) GetReservationByID(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&reservation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// GetActiveReservationByOrderID returns the held or committed reservation of an
+// order, if any.
+func (r *reservationRepository) GetActiveReservationByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := r.collection.FindOne(ctx, bson.M{
+		"order_id": orderID,
+		"status": bson.M{"$in": []domain.ReservationStatus{
+			domain.ReservationStatusHeld,
+			domain.ReservationStatusCommitted,
+		}},
+	}).Decode(&reservation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// TransitionStatus moves a reservation from one status to another. It reports
+// false when the reservation is no longer in the expected status, which lets
+// concurrent release, commit and expiry calls race safely.
+func (r *reservationRepository) TransitionStatus(ctx context.Context, id primitive.ObjectID, from, to domain.ReservationStatus) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": from},
+		bson.M{"$set": bson.M{
+			"status":     to,
+			"updated_at": time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *reservationRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int64) ([]domain.Reservation, error) {
+	opts := options.Find().SetLimit(limit).SetSort(bson.D{{Key: "expires_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":     domain.ReservationStatusHeld,
+		"expires_at": bson.M{"$lte": now},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reservations []domain.Reservation
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
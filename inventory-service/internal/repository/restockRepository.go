@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RestockRepository interface {
+	CreateRestock(ctx context.Context, restock *domain.Restock) error
+	GetRestockByReference(ctx context.Context, reference string) (*domain.Restock, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type restockRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRestockRepository(db *mongo.Database) *restockRepository {
+	return &restockRepository{
+		collection: db.Collection("restocks"),
+	}
+}
+
+func (r *restockRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "reference", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (r *restockRepository) CreateRestock(ctx context.Context, restock *domain.Restock) error {
+	restock.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, restock)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: reference %s was already restocked", domain.ErrConflict, restock.Reference)
+	}
+	return err
+}
+
+func (r *restockRepository) GetRestockByReference(ctx context.Context, reference string) (*domain.Restock, error) {
+	var restock domain.Restock
+	err := r.collection.FindOne(ctx, bson.M{"reference": reference}).Decode(&restock)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &restock, nil
+}
@@ -3,20 +3,25 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"log"
 
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/adapter/cache"
 	producer "github.com/mephirious/advanced-programming-2/inventory-service/internal/adapter/nats"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain/dto"
 	"github.com/mephirious/advanced-programming-2/inventory-service/internal/repository"
-	pb "github.com/mephirious/advanced-programming-2/inventory-service/proto/events"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// maxProductBatch is the largest number of products GetProductsByIDs returns.
+const maxProductBatch = 100
+
 type ProductUseCase interface {
 	CreateProduct(ctx context.Context, dto dto.ProductCreateDTO) (*domain.Product, error)
 	GetProductByID(ctx context.Context, id primitive.ObjectID) (*domain.Product, error)
+	// GetProductsByIDs returns the existing products among ids, in no
+	// particular order.
+	GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Product, error)
 	UpdateProduct(ctx context.Context, id primitive.ObjectID, dto dto.ProductUpdateDTO) (*domain.Product, error)
 	DeleteProduct(ctx context.Context, id primitive.ObjectID) error
 	GetAllProducts(ctx context.Context, filter dto.ProductFilterDTO) ([]domain.Product, error)
@@ -29,20 +34,22 @@ type productUseCase struct {
 	productRepo   repository.ProductRepository
 	eventProducer *producer.InventoryEventProducer
 	productCache  *cache.ProductCache
+	tx            Transactor
 }
 
-func NewProductUseCase(repo repository.ProductRepository, eventProducer *producer.InventoryEventProducer, productCache *cache.ProductCache) *productUseCase {
+func NewProductUseCase(repo repository.ProductRepository, eventProducer *producer.InventoryEventProducer, productCache *cache.ProductCache, tx Transactor) *productUseCase {
 	return &productUseCase{
 		productRepo:   repo,
 		eventProducer: eventProducer,
 		productCache:  productCache,
+		tx:            tx,
 	}
 }
 
 func (uc *productUseCase) CreateProduct(ctx context.Context, dto dto.ProductCreateDTO) (*domain.Product, error) {
 	categoryObjectID, err := primitive.ObjectIDFromHex(dto.CategoryID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid category_id: %w", err)
+		return nil, fmt.Errorf("%w: invalid category_id %q", domain.ErrInvalidArgument, dto.CategoryID)
 	}
 
 	product := &domain.Product{
@@ -54,14 +61,16 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, dto dto.ProductCrea
 		Stock:       dto.Stock,
 	}
 
-	if err := uc.productRepo.CreateProduct(ctx, product); err != nil {
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.CreateProduct(ctx, product); err != nil {
+			return err
+		}
+		return uc.eventProducer.Push(ctx, product, pb.InventoryEventType_CREATED)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if err := uc.eventProducer.Push(ctx, product, pb.InventoryEventType_CREATED); err != nil {
-		log.Printf("Failed to push create event to NATS: %v", err)
-	}
-
 	uc.productCache.Set(*product)
 
 	return product, nil
@@ -73,16 +82,29 @@ func (uc *productUseCase) GetProductByID(ctx context.Context, id primitive.Objec
 		return nil, err
 	}
 	if product == nil {
-		return nil, fmt.Errorf("product not found")
+		return nil, domain.ErrProductNotFound
 	}
 	return product, nil
 }
 
+func (uc *productUseCase) GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Product, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: no product IDs", domain.ErrInvalidArgument)
+	}
+	if len(ids) > maxProductBatch {
+		return nil, fmt.Errorf("%w: at most %d product IDs per request", domain.ErrInvalidArgument, maxProductBatch)
+	}
+	return uc.productRepo.GetProductsByIDs(ctx, ids)
+}
+
 func (uc *productUseCase) UpdateProduct(ctx context.Context, id primitive.ObjectID, dto dto.ProductUpdateDTO) (*domain.Product, error) {
 	product, err := uc.productRepo.GetProductByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if product == nil {
+		return nil, domain.ErrProductNotFound
+	}
 
 	if dto.Name != nil {
 		product.Name = *dto.Name
@@ -93,7 +115,7 @@ func (uc *productUseCase) UpdateProduct(ctx context.Context, id primitive.Object
 	if dto.CategoryID != nil {
 		categoryID, err := primitive.ObjectIDFromHex(*dto.CategoryID)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: invalid category_id %q", domain.ErrInvalidArgument, *dto.CategoryID)
 		}
 		product.CategoryID = categoryID
 	}
@@ -104,23 +126,30 @@ func (uc *productUseCase) UpdateProduct(ctx context.Context, id primitive.Object
 		product.Stock = *dto.Stock
 	}
 
-	err = uc.productRepo.UpdateProduct(ctx, product)
-	if err != nil {
-		return nil, err
-	}
+	var updated *domain.Product
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.UpdateProduct(ctx, product); err != nil {
+			return err
+		}
 
-	p1roduct, err := uc.productRepo.GetProductByID(ctx, id)
+		var err error
+		updated, err = uc.productRepo.GetProductByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return domain.ErrProductNotFound
+		}
+
+		return uc.eventProducer.Push(ctx, updated, pb.InventoryEventType_UPDATED)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := uc.eventProducer.Push(ctx, product, pb.InventoryEventType_UPDATED); err != nil {
-		log.Printf("Failed to push create event to NATS: %v", err)
-	}
-
-	uc.productCache.Set(*product)
+	uc.productCache.Set(*updated)
 
-	return p1roduct, nil
+	return updated, nil
 }
 
 func (uc *productUseCase) DeleteProduct(ctx context.Context, id primitive.ObjectID) error {
@@ -128,17 +157,16 @@ func (uc *productUseCase) DeleteProduct(ctx context.Context, id primitive.Object
 	if err != nil {
 		return err
 	}
-
-	err = uc.productRepo.DeleteProduct(ctx, id)
-	if err != nil {
-		return err
-	}
-
-	if err := uc.eventProducer.Push(ctx, product, pb.InventoryEventType_DELETED); err != nil {
-		log.Printf("Failed to push delete event to NATS: %v", err)
+	if product == nil {
+		return domain.ErrProductNotFound
 	}
 
-	return nil
+	return uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.DeleteProduct(ctx, id); err != nil {
+			return err
+		}
+		return uc.eventProducer.Push(ctx, product, pb.InventoryEventType_DELETED)
+	})
 }
 
 func (uc *productUseCase) GetAllProducts(ctx context.Context, filter dto.ProductFilterDTO) ([]domain.Product, error) {
@@ -148,7 +176,7 @@ func (uc *productUseCase) GetAllProducts(ctx context.Context, filter dto.Product
 func (uc *productUseCase) GetProductByIDFromCache(ctx context.Context, id primitive.ObjectID) (*domain.Product, error) {
 	product, ok := uc.productCache.Get(id.Hex())
 	if ok == false {
-		return nil, fmt.Errorf("product not found")
+		return nil, domain.ErrProductNotFound
 	}
 	return &product, nil
 }
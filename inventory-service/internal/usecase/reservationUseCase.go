@@ -0,0 +1,389 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
+	"github.com/mephirious/advanced-programming-2/inventory-service/internal/adapter/cache"
+	producer "github.com/mephirious/advanced-programming-2/inventory-service/internal/adapter/nats"
+	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/inventory-service/internal/domain/dto"
+	"github.com/mephirious/advanced-programming-2/inventory-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const expiredReservationsBatch = 100
+
+type ReservationUseCase interface {
+	ReserveStock(ctx context.Context, dto dto.ReserveStockDTO) (*domain.Reservation, error)
+	ReleaseStock(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error)
+	CommitReservation(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error)
+	ReturnStock(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error)
+	RestockItems(ctx context.Context, dto dto.RestockItemsDTO) (*domain.Restock, error)
+	ReleaseExpired(ctx context.Context) (int, error)
+}
+
+// reservationUseCase changes stock in transactions that also queue a
+// product-updated event for every product whose stock changed, so that
+// consumers of the inventory events see the current stock.
+type reservationUseCase struct {
+	reservationRepo repository.ReservationRepository
+	restockRepo     repository.RestockRepository
+	productRepo     repository.ProductRepository
+	eventProducer   *producer.InventoryEventProducer
+	productCache    *cache.ProductCache
+	tx              Transactor
+	defaultTTL      time.Duration
+}
+
+func NewReservationUseCase(reservationRepo repository.ReservationRepository, restockRepo repository.RestockRepository, productRepo repository.ProductRepository, eventProducer *producer.InventoryEventProducer, productCache *cache.ProductCache, tx Transactor, defaultTTL time.Duration) *reservationUseCase {
+	return &reservationUseCase{
+		reservationRepo: reservationRepo,
+		restockRepo:     restockRepo,
+		productRepo:     productRepo,
+		eventProducer:   eventProducer,
+		productCache:    productCache,
+		tx:              tx,
+		defaultTTL:      defaultTTL,
+	}
+}
+
+// ReserveStock takes the requested quantities out of stock for an order. Either
+// every item is reserved or none is; the returned error lists all products
+// without enough stock. Reserving again for the same order returns the active
+// reservation.
+func (uc *reservationUseCase) ReserveStock(ctx context.Context, dto dto.ReserveStockDTO) (*domain.Reservation, error) {
+	if dto.OrderID == "" {
+		return nil, fmt.Errorf("%w: order_id is required", domain.ErrInvalidArgument)
+	}
+	items, err := mergeReservationItems(dto.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.reservationRepo.GetActiveReservationByOrderID(ctx, dto.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	ttl := dto.TTL
+	if ttl <= 0 {
+		ttl = uc.defaultTTL
+	}
+
+	reservation := &domain.Reservation{
+		ID:        primitive.NewObjectID(),
+		OrderID:   dto.OrderID,
+		Items:     items,
+		Status:    domain.ReservationStatusHeld,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	// The stock is taken in one transaction, so a shortage of any item
+	// rolls back the items reserved before it.
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var shortages []domain.StockShortage
+		for _, item := range items {
+			ok, err := uc.productRepo.DecrementStock(ctx, item.ProductID, item.Quantity)
+			if err != nil {
+				return fmt.Errorf("failed to reserve product %s: %w", item.ProductID.Hex(), err)
+			}
+			if ok {
+				continue
+			}
+
+			product, err := uc.productRepo.GetProductByID(ctx, item.ProductID)
+			if err != nil {
+				return err
+			}
+			if product == nil {
+				return fmt.Errorf("%w: product %s", domain.ErrNotFound, item.ProductID.Hex())
+			}
+			shortages = append(shortages, domain.StockShortage{
+				ProductID: item.ProductID,
+				Requested: item.Quantity,
+				Available: product.Stock,
+			})
+		}
+		if len(shortages) > 0 {
+			return &domain.InsufficientStockError{Shortages: shortages}
+		}
+
+		if err := uc.reservationRepo.CreateReservation(ctx, reservation); err != nil {
+			return err
+		}
+		return uc.pushStock(ctx, items)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return uc.reservationRepo.GetActiveReservationByOrderID(ctx, dto.OrderID)
+		}
+		return nil, err
+	}
+
+	uc.refreshCache(ctx, items)
+
+	return reservation, nil
+}
+
+// ReleaseStock returns the stock of a held reservation. Releasing a reservation
+// that was already released or has expired is a no-op.
+func (uc *reservationUseCase) ReleaseStock(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error) {
+	reservation, err := uc.getReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reservation.Status {
+	case domain.ReservationStatusReleased, domain.ReservationStatusExpired:
+		return reservation, nil
+	case domain.ReservationStatusCommitted:
+		return nil, fmt.Errorf("%w: reservation %s is already committed", domain.ErrFailedPrecondition, id.Hex())
+	}
+
+	if err := uc.returnStock(ctx, reservation, domain.ReservationStatusHeld, domain.ReservationStatusReleased); err != nil {
+		return nil, err
+	}
+
+	return uc.getReservation(ctx, id)
+}
+
+// CommitReservation makes a held reservation permanent, the stock stays taken.
+func (uc *reservationUseCase) CommitReservation(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error) {
+	reservation, err := uc.getReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reservation.Status {
+	case domain.ReservationStatusCommitted:
+		return reservation, nil
+	case domain.ReservationStatusReleased, domain.ReservationStatusExpired:
+		return nil, fmt.Errorf("%w: reservation %s is %s", domain.ErrFailedPrecondition, id.Hex(), reservation.Status)
+	}
+
+	ok, err := uc.reservationRepo.TransitionStatus(ctx, id, domain.ReservationStatusHeld, domain.ReservationStatusCommitted)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Released or expired concurrently, report the state it ended up in.
+		return uc.CommitReservation(ctx, id)
+	}
+
+	return uc.getReservation(ctx, id)
+}
+
+// ReturnStock puts the stock of a committed reservation back, for orders that
+// are cancelled after they were paid. Returning a reservation again is a no-op.
+func (uc *reservationUseCase) ReturnStock(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error) {
+	reservation, err := uc.getReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reservation.Status {
+	case domain.ReservationStatusReturned:
+		return reservation, nil
+	case domain.ReservationStatusHeld:
+		return nil, fmt.Errorf("%w: reservation %s is not committed, release it instead", domain.ErrFailedPrecondition, id.Hex())
+	case domain.ReservationStatusReleased, domain.ReservationStatusExpired:
+		return nil, fmt.Errorf("%w: reservation %s is %s", domain.ErrFailedPrecondition, id.Hex(), reservation.Status)
+	}
+
+	if err := uc.returnStock(ctx, reservation, domain.ReservationStatusCommitted, domain.ReservationStatusReturned); err != nil {
+		return nil, err
+	}
+
+	return uc.getReservation(ctx, id)
+}
+
+// RestockItems puts returned goods back into stock. The restock is recorded
+// under its reference before the stock changes, so restocking a reference
+// again returns the first restock and leaves the stock alone.
+func (uc *reservationUseCase) RestockItems(ctx context.Context, dto dto.RestockItemsDTO) (*domain.Restock, error) {
+	if dto.Reference == "" {
+		return nil, fmt.Errorf("%w: reference is required", domain.ErrInvalidArgument)
+	}
+	items, err := mergeReservationItems(dto.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	restock := &domain.Restock{
+		ID:        primitive.NewObjectID(),
+		Reference: dto.Reference,
+		OrderID:   dto.OrderID,
+		Items:     items,
+	}
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.restockRepo.CreateRestock(ctx, restock); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := uc.productRepo.IncrementStock(ctx, item.ProductID, item.Quantity); err != nil {
+				return fmt.Errorf("failed to restock product %s: %w", item.ProductID.Hex(), err)
+			}
+		}
+		return uc.pushStock(ctx, items)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return uc.restockRepo.GetRestockByReference(ctx, dto.Reference)
+		}
+		return nil, err
+	}
+
+	uc.refreshCache(ctx, items)
+
+	return restock, nil
+}
+
+// ReleaseExpired returns the stock of held reservations past their expiry and
+// reports how many were released.
+func (uc *reservationUseCase) ReleaseExpired(ctx context.Context) (int, error) {
+	reservations, err := uc.reservationRepo.GetExpiredReservations(ctx, time.Now(), expiredReservationsBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for i := range reservations {
+		if err := uc.returnStock(ctx, &reservations[i], domain.ReservationStatusHeld, domain.ReservationStatusExpired); err != nil {
+			return released, err
+		}
+		released++
+	}
+
+	return released, nil
+}
+
+// StartReservationExpirer periodically releases expired reservations.
+func StartReservationExpirer(ctx context.Context, uc ReservationUseCase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				released, err := uc.ReleaseExpired(ctx)
+				if err != nil {
+					log.Printf("Failed to release expired reservations: %v", err)
+				}
+				if released > 0 {
+					log.Printf("Released %d expired reservations", released)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (uc *reservationUseCase) getReservation(ctx context.Context, id primitive.ObjectID) (*domain.Reservation, error) {
+	reservation, err := uc.reservationRepo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, domain.ErrReservationNotFound
+	}
+	return reservation, nil
+}
+
+// returnStock moves a reservation from status from to the given final status
+// and puts its items back into stock in one transaction. Only the caller that
+// wins the status transition restocks, so stock is never returned twice.
+func (uc *reservationUseCase) returnStock(ctx context.Context, reservation *domain.Reservation, from, to domain.ReservationStatus) error {
+	returned := false
+	err := uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := uc.reservationRepo.TransitionStatus(ctx, reservation.ID, from, to)
+		if err != nil {
+			return err
+		}
+		returned = ok
+		if !ok {
+			return nil
+		}
+
+		for _, item := range reservation.Items {
+			if err := uc.productRepo.IncrementStock(ctx, item.ProductID, item.Quantity); err != nil {
+				return fmt.Errorf("failed to restock product %s: %w", item.ProductID.Hex(), err)
+			}
+		}
+		return uc.pushStock(ctx, reservation.Items)
+	})
+	if err != nil {
+		return err
+	}
+
+	if returned {
+		uc.refreshCache(ctx, reservation.Items)
+	}
+	return nil
+}
+
+// pushStock queues a product-updated event with the current state of every
+// product of items. Products that no longer exist are skipped.
+func (uc *reservationUseCase) pushStock(ctx context.Context, items []domain.ReservationItem) error {
+	for _, item := range items {
+		product, err := uc.productRepo.GetProductByID(ctx, item.ProductID)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			continue
+		}
+		if err := uc.eventProducer.Push(ctx, product, pb.InventoryEventType_UPDATED); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *reservationUseCase) refreshCache(ctx context.Context, items []domain.ReservationItem) {
+	for _, item := range items {
+		product, err := uc.productRepo.GetProductByID(ctx, item.ProductID)
+		if err != nil || product == nil {
+			continue
+		}
+		uc.productCache.Set(*product)
+	}
+}
+
+// mergeReservationItems validates the requested items and sums quantities of
+// duplicate products.
+func mergeReservationItems(items []dto.ReservationItemDTO) ([]domain.ReservationItem, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", domain.ErrInvalidArgument)
+	}
+
+	var merged []domain.ReservationItem
+	index := make(map[primitive.ObjectID]int)
+	for _, item := range items {
+		productID, err := primitive.ObjectIDFromHex(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid product_id %q", domain.ErrInvalidArgument, item.ProductID)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity for product %s must be positive", domain.ErrInvalidArgument, item.ProductID)
+		}
+
+		if i, ok := index[productID]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[productID] = len(merged)
+		merged = append(merged, domain.ReservationItem{ProductID: productID, Quantity: item.Quantity})
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,10 @@
+package usecase
+
+import "context"
+
+// Transactor runs fn in a database transaction. Repository calls made with the
+// context passed to fn take part in the transaction, which is committed when
+// fn returns nil.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -1,259 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.6
-// 	protoc        v3.21.12
-// source: events.proto
-
-package events
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-type InventoryEventType int32
-
-const (
-	InventoryEventType_CREATED InventoryEventType = 0
-	InventoryEventType_UPDATED InventoryEventType = 1
-	InventoryEventType_DELETED InventoryEventType = 2
-)
-
-// Enum value maps for InventoryEventType.
-var (
-	InventoryEventType_name = map[int32]string{
-		0: "CREATED",
-		1: "UPDATED",
-		2: "DELETED",
-	}
-	InventoryEventType_value = map[string]int32{
-		"CREATED": 0,
-		"UPDATED": 1,
-		"DELETED": 2,
-	}
-)
-
-func (x InventoryEventType) Enum() *InventoryEventType {
-	p := new(InventoryEventType)
-	*p = x
-	return p
-}
-
-func (x InventoryEventType) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (InventoryEventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_events_proto_enumTypes[0].Descriptor()
-}
-
-func (InventoryEventType) Type() protoreflect.EnumType {
-	return &file_events_proto_enumTypes[0]
-}
-
-func (x InventoryEventType) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use InventoryEventType.Descriptor instead.
-func (InventoryEventType) EnumDescriptor() ([]byte, []int) {
-	return file_events_proto_rawDescGZIP(), []int{0}
-}
-
-type InventoryEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	CategoryId    string                 `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	EventType     InventoryEventType     `protobuf:"varint,9,opt,name=event_type,json=eventType,proto3,enum=events.InventoryEventType" json:"event_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *InventoryEvent) Reset() {
-	*x = InventoryEvent{}
-	mi := &file_events_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *InventoryEvent) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*InventoryEvent) ProtoMessage() {}
-
-func (x *InventoryEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use InventoryEvent.ProtoReflect.Descriptor instead.
-func (*InventoryEvent) Descriptor() ([]byte, []int) {
-	return file_events_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *InventoryEvent) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *InventoryEvent) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *InventoryEvent) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *InventoryEvent) GetCategoryId() string {
-	if x != nil {
-		return x.CategoryId
-	}
-	return ""
-}
-
-func (x *InventoryEvent) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-func (x *InventoryEvent) GetStock() int32 {
-	if x != nil {
-		return x.Stock
-	}
-	return 0
-}
-
-func (x *InventoryEvent) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *InventoryEvent) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-func (x *InventoryEvent) GetEventType() InventoryEventType {
-	if x != nil {
-		return x.EventType
-	}
-	return InventoryEventType_CREATED
-}
-
-var File_events_proto protoreflect.FileDescriptor
-
-const file_events_proto_rawDesc = "" +
-	"\n" +
-	"\fevents.proto\x12\x06events\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd4\x02\n" +
-	"\x0eInventoryEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
-	"\vcategory_id\x18\x04 \x01(\tR\n" +
-	"categoryId\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x14\n" +
-	"\x05stock\x18\x06 \x01(\x05R\x05stock\x129\n" +
-	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
-	"\n" +
-	"event_type\x18\t \x01(\x0e2\x1a.events.InventoryEventTypeR\teventType*;\n" +
-	"\x12InventoryEventType\x12\v\n" +
-	"\aCREATED\x10\x00\x12\v\n" +
-	"\aUPDATED\x10\x01\x12\v\n" +
-	"\aDELETED\x10\x02BVZTgithub.com/mephirious/advanced-programming-2/inventory-service/pkg/api/events;eventsb\x06proto3"
-
-var (
-	file_events_proto_rawDescOnce sync.Once
-	file_events_proto_rawDescData []byte
-)
-
-func file_events_proto_rawDescGZIP() []byte {
-	file_events_proto_rawDescOnce.Do(func() {
-		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)))
-	})
-	return file_events_proto_rawDescData
-}
-
-var file_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_events_proto_goTypes = []any{
-	(InventoryEventType)(0),       // 0: events.InventoryEventType
-	(*InventoryEvent)(nil),        // 1: events.InventoryEvent
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
-}
-var file_events_proto_depIdxs = []int32{
-	2, // 0: events.InventoryEvent.created_at:type_name -> google.protobuf.Timestamp
-	2, // 1: events.InventoryEvent.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 2: events.InventoryEvent.event_type:type_name -> events.InventoryEventType
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
-}
-
-func init() { file_events_proto_init() }
-func file_events_proto_init() {
-	if File_events_proto != nil {
-		return
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   1,
-			NumExtensions: 0,
-			NumServices:   0,
-		},
-		GoTypes:           file_events_proto_goTypes,
-		DependencyIndexes: file_events_proto_depIdxs,
-		EnumInfos:         file_events_proto_enumTypes,
-		MessageInfos:      file_events_proto_msgTypes,
-	}.Build()
-	File_events_proto = out.File
-	file_events_proto_goTypes = nil
-	file_events_proto_depIdxs = nil
-}
@@ -23,6 +23,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Reservation Messages
+type ReservationStatus int32
+
+const (
+	ReservationStatus_HELD      ReservationStatus = 0
+	ReservationStatus_COMMITTED ReservationStatus = 1
+	ReservationStatus_RELEASED  ReservationStatus = 2
+	ReservationStatus_EXPIRED   ReservationStatus = 3
+	ReservationStatus_RETURNED  ReservationStatus = 4 // committed stock that was put back, e.g. for a cancelled order
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "HELD",
+		1: "COMMITTED",
+		2: "RELEASED",
+		3: "EXPIRED",
+		4: "RETURNED",
+	}
+	ReservationStatus_value = map[string]int32{
+		"HELD":      0,
+		"COMMITTED": 1,
+		"RELEASED":  2,
+		"EXPIRED":   3,
+		"RETURNED":  4,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_inventory_proto_enumTypes[0].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_proto_inventory_proto_enumTypes[0]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{0}
+}
+
 // Product Messages
 type Product struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -244,6 +300,102 @@ func (x *GetProductRequest) GetId() string {
 	return ""
 }
 
+type GetProductsByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIDsRequest) Reset() {
+	*x = GetProductsByIDsRequest{}
+	mi := &file_proto_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIDsRequest) ProtoMessage() {}
+
+func (x *GetProductsByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetProductsByIDsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type GetProductsByIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	MissingIds    []string               `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"` // requested IDs without a product
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIDsResponse) Reset() {
+	*x = GetProductsByIDsResponse{}
+	mi := &file_proto_inventory_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIDsResponse) ProtoMessage() {}
+
+func (x *GetProductsByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProductsByIDsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetProductsByIDsResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
 type UpdateProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -258,7 +410,7 @@ type UpdateProductRequest struct {
 
 func (x *UpdateProductRequest) Reset() {
 	*x = UpdateProductRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[3]
+	mi := &file_proto_inventory_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -270,7 +422,7 @@ func (x *UpdateProductRequest) String() string {
 func (*UpdateProductRequest) ProtoMessage() {}
 
 func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[3]
+	mi := &file_proto_inventory_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -283,7 +435,7 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{3}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateProductRequest) GetId() string {
@@ -337,7 +489,7 @@ type DeleteProductRequest struct {
 
 func (x *DeleteProductRequest) Reset() {
 	*x = DeleteProductRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[4]
+	mi := &file_proto_inventory_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -349,7 +501,7 @@ func (x *DeleteProductRequest) String() string {
 func (*DeleteProductRequest) ProtoMessage() {}
 
 func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[4]
+	mi := &file_proto_inventory_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,7 +514,7 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
 func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{4}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteProductRequest) GetId() string {
@@ -381,7 +533,7 @@ type GetByIDRequest struct {
 
 func (x *GetByIDRequest) Reset() {
 	*x = GetByIDRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[5]
+	mi := &file_proto_inventory_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -393,7 +545,7 @@ func (x *GetByIDRequest) String() string {
 func (*GetByIDRequest) ProtoMessage() {}
 
 func (x *GetByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[5]
+	mi := &file_proto_inventory_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +558,7 @@ func (x *GetByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetByIDRequest.ProtoReflect.Descriptor instead.
 func (*GetByIDRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{5}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetByIDRequest) GetId() string {
@@ -436,7 +588,7 @@ type ListProductsRequest struct {
 
 func (x *ListProductsRequest) Reset() {
 	*x = ListProductsRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[6]
+	mi := &file_proto_inventory_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -448,7 +600,7 @@ func (x *ListProductsRequest) String() string {
 func (*ListProductsRequest) ProtoMessage() {}
 
 func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[6]
+	mi := &file_proto_inventory_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,7 +613,7 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
 func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{6}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListProductsRequest) GetName() string {
@@ -543,7 +695,7 @@ type ListProductsResponse struct {
 
 func (x *ListProductsResponse) Reset() {
 	*x = ListProductsResponse{}
-	mi := &file_proto_inventory_proto_msgTypes[7]
+	mi := &file_proto_inventory_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -555,7 +707,7 @@ func (x *ListProductsResponse) String() string {
 func (*ListProductsResponse) ProtoMessage() {}
 
 func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[7]
+	mi := &file_proto_inventory_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,7 +720,7 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{7}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListProductsResponse) GetProducts() []*Product {
@@ -592,7 +744,7 @@ type Category struct {
 
 func (x *Category) Reset() {
 	*x = Category{}
-	mi := &file_proto_inventory_proto_msgTypes[8]
+	mi := &file_proto_inventory_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -604,7 +756,7 @@ func (x *Category) String() string {
 func (*Category) ProtoMessage() {}
 
 func (x *Category) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[8]
+	mi := &file_proto_inventory_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +769,7 @@ func (x *Category) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Category.ProtoReflect.Descriptor instead.
 func (*Category) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{8}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Category) GetId() string {
@@ -665,7 +817,7 @@ type CreateCategoryRequest struct {
 
 func (x *CreateCategoryRequest) Reset() {
 	*x = CreateCategoryRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[9]
+	mi := &file_proto_inventory_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -677,7 +829,7 @@ func (x *CreateCategoryRequest) String() string {
 func (*CreateCategoryRequest) ProtoMessage() {}
 
 func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[9]
+	mi := &file_proto_inventory_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -690,7 +842,7 @@ func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
 func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{9}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateCategoryRequest) GetName() string {
@@ -716,7 +868,7 @@ type GetCategoryRequest struct {
 
 func (x *GetCategoryRequest) Reset() {
 	*x = GetCategoryRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[10]
+	mi := &file_proto_inventory_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -728,7 +880,7 @@ func (x *GetCategoryRequest) String() string {
 func (*GetCategoryRequest) ProtoMessage() {}
 
 func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[10]
+	mi := &file_proto_inventory_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -741,7 +893,7 @@ func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
 func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{10}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetCategoryRequest) GetId() string {
@@ -762,7 +914,7 @@ type UpdateCategoryRequest struct {
 
 func (x *UpdateCategoryRequest) Reset() {
 	*x = UpdateCategoryRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[11]
+	mi := &file_proto_inventory_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -774,7 +926,7 @@ func (x *UpdateCategoryRequest) String() string {
 func (*UpdateCategoryRequest) ProtoMessage() {}
 
 func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[11]
+	mi := &file_proto_inventory_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -787,7 +939,7 @@ func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{11}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UpdateCategoryRequest) GetId() string {
@@ -820,7 +972,7 @@ type DeleteCategoryRequest struct {
 
 func (x *DeleteCategoryRequest) Reset() {
 	*x = DeleteCategoryRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[12]
+	mi := &file_proto_inventory_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -832,7 +984,7 @@ func (x *DeleteCategoryRequest) String() string {
 func (*DeleteCategoryRequest) ProtoMessage() {}
 
 func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[12]
+	mi := &file_proto_inventory_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -845,7 +997,7 @@ func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{12}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteCategoryRequest) GetId() string {
@@ -864,7 +1016,7 @@ type ListCategoriesRequest struct {
 
 func (x *ListCategoriesRequest) Reset() {
 	*x = ListCategoriesRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[13]
+	mi := &file_proto_inventory_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -876,7 +1028,7 @@ func (x *ListCategoriesRequest) String() string {
 func (*ListCategoriesRequest) ProtoMessage() {}
 
 func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[13]
+	mi := &file_proto_inventory_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,7 +1041,7 @@ func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
 func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{13}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListCategoriesRequest) GetName() string {
@@ -908,7 +1060,7 @@ type ListCategoriesResponse struct {
 
 func (x *ListCategoriesResponse) Reset() {
 	*x = ListCategoriesResponse{}
-	mi := &file_proto_inventory_proto_msgTypes[14]
+	mi := &file_proto_inventory_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -920,7 +1072,7 @@ func (x *ListCategoriesResponse) String() string {
 func (*ListCategoriesResponse) ProtoMessage() {}
 
 func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[14]
+	mi := &file_proto_inventory_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -933,7 +1085,7 @@ func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
 func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{14}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ListCategoriesResponse) GetCategories() []*Category {
@@ -952,7 +1104,7 @@ type GetProductByIDFromCacheRequest struct {
 
 func (x *GetProductByIDFromCacheRequest) Reset() {
 	*x = GetProductByIDFromCacheRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[15]
+	mi := &file_proto_inventory_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -964,7 +1116,7 @@ func (x *GetProductByIDFromCacheRequest) String() string {
 func (*GetProductByIDFromCacheRequest) ProtoMessage() {}
 
 func (x *GetProductByIDFromCacheRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[15]
+	mi := &file_proto_inventory_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -977,7 +1129,7 @@ func (x *GetProductByIDFromCacheRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductByIDFromCacheRequest.ProtoReflect.Descriptor instead.
 func (*GetProductByIDFromCacheRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{15}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetProductByIDFromCacheRequest) GetId() string {
@@ -1001,7 +1153,7 @@ type GetAllProductsFromCacheRequest struct {
 
 func (x *GetAllProductsFromCacheRequest) Reset() {
 	*x = GetAllProductsFromCacheRequest{}
-	mi := &file_proto_inventory_proto_msgTypes[16]
+	mi := &file_proto_inventory_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1013,7 +1165,7 @@ func (x *GetAllProductsFromCacheRequest) String() string {
 func (*GetAllProductsFromCacheRequest) ProtoMessage() {}
 
 func (x *GetAllProductsFromCacheRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[16]
+	mi := &file_proto_inventory_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,7 +1178,7 @@ func (x *GetAllProductsFromCacheRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllProductsFromCacheRequest.ProtoReflect.Descriptor instead.
 func (*GetAllProductsFromCacheRequest) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{16}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetAllProductsFromCacheRequest) GetName() string {
@@ -1080,7 +1232,7 @@ type GetAllProductsFromCacheResponse struct {
 
 func (x *GetAllProductsFromCacheResponse) Reset() {
 	*x = GetAllProductsFromCacheResponse{}
-	mi := &file_proto_inventory_proto_msgTypes[17]
+	mi := &file_proto_inventory_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1092,7 +1244,7 @@ func (x *GetAllProductsFromCacheResponse) String() string {
 func (*GetAllProductsFromCacheResponse) ProtoMessage() {}
 
 func (x *GetAllProductsFromCacheResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_inventory_proto_msgTypes[17]
+	mi := &file_proto_inventory_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1105,7 +1257,7 @@ func (x *GetAllProductsFromCacheResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllProductsFromCacheResponse.ProtoReflect.Descriptor instead.
 func (*GetAllProductsFromCacheResponse) Descriptor() ([]byte, []int) {
-	return file_proto_inventory_proto_rawDescGZIP(), []int{17}
+	return file_proto_inventory_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetAllProductsFromCacheResponse) GetProducts() []*Product {
@@ -1115,6 +1267,480 @@ func (x *GetAllProductsFromCacheResponse) GetProducts() []*Product {
 	return nil
 }
 
+type ReservationItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationItem) Reset() {
+	*x = ReservationItem{}
+	mi := &file_proto_inventory_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationItem) ProtoMessage() {}
+
+func (x *ReservationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationItem.ProtoReflect.Descriptor instead.
+func (*ReservationItem) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReservationItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReservationItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Status        ReservationStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=inventory.ReservationStatus" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_proto_inventory_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Reservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reservation) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Reservation) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Reservation) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_HELD
+}
+
+func (x *Reservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	TtlSeconds    int32                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // defaults to the service setting when 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_inventory_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReserveStockRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type ReleaseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_proto_inventory_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReleaseStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_proto_inventory_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReturnStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnStockRequest) Reset() {
+	*x = ReturnStockRequest{}
+	mi := &file_proto_inventory_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnStockRequest) ProtoMessage() {}
+
+func (x *ReturnStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnStockRequest.ProtoReflect.Descriptor instead.
+func (*ReturnStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReturnStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+// Puts returned goods back into stock. Restocking the same reference again
+// returns the first restock without changing the stock.
+type RestockItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reference     string                 `protobuf:"bytes,1,opt,name=reference,proto3" json:"reference,omitempty"` // e.g. the ID of the order return
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockItemsRequest) Reset() {
+	*x = RestockItemsRequest{}
+	mi := &file_proto_inventory_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockItemsRequest) ProtoMessage() {}
+
+func (x *RestockItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockItemsRequest.ProtoReflect.Descriptor instead.
+func (*RestockItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RestockItemsRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *RestockItemsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RestockItemsRequest) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Restock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reference     string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*ReservationItem     `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Restock) Reset() {
+	*x = Restock{}
+	mi := &file_proto_inventory_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Restock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Restock) ProtoMessage() {}
+
+func (x *Restock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_inventory_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Restock.ProtoReflect.Descriptor instead.
+func (*Restock) Descriptor() ([]byte, []int) {
+	return file_proto_inventory_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Restock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Restock) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Restock) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Restock) GetItems() []*ReservationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Restock) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 var File_proto_inventory_proto protoreflect.FileDescriptor
 
 const file_proto_inventory_proto_rawDesc = "" +
@@ -1140,7 +1766,13 @@ const file_proto_inventory_proto_rawDesc = "" +
 	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x14\n" +
 	"\x05stock\x18\x05 \x01(\x05R\x05stock\"#\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xff\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x17GetProductsByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"k\n" +
+	"\x18GetProductsByIDsResponse\x12.\n" +
+	"\bproducts\x18\x01 \x03(\v2\x12.inventory.ProductR\bproducts\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"\xff\x01\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
@@ -1233,10 +1865,54 @@ const file_proto_inventory_proto_rawDesc = "" +
 	"\n" +
 	"_max_stock\"Q\n" +
 	"\x1fGetAllProductsFromCacheResponse\x12.\n" +
-	"\bproducts\x18\x01 \x03(\v2\x12.inventory.ProductR\bproducts2\xc5\a\n" +
+	"\bproducts\x18\x01 \x03(\v2\x12.inventory.ProductR\bproducts\"L\n" +
+	"\x0fReservationItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xd1\x02\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x120\n" +
+	"\x05items\x18\x03 \x03(\v2\x1a.inventory.ReservationItemR\x05items\x124\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1c.inventory.ReservationStatusR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x83\x01\n" +
+	"\x13ReserveStockRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x120\n" +
+	"\x05items\x18\x02 \x03(\v2\x1a.inventory.ReservationItemR\x05items\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x05R\n" +
+	"ttlSeconds\"<\n" +
+	"\x13ReleaseStockRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"A\n" +
+	"\x18CommitReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\";\n" +
+	"\x12ReturnStockRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"\x80\x01\n" +
+	"\x13RestockItemsRequest\x12\x1c\n" +
+	"\treference\x18\x01 \x01(\tR\treference\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x120\n" +
+	"\x05items\x18\x03 \x03(\v2\x1a.inventory.ReservationItemR\x05items\"\xbf\x01\n" +
+	"\aRestock\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x120\n" +
+	"\x05items\x18\x04 \x03(\v2\x1a.inventory.ReservationItemR\x05items\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*U\n" +
+	"\x11ReservationStatus\x12\b\n" +
+	"\x04HELD\x10\x00\x12\r\n" +
+	"\tCOMMITTED\x10\x01\x12\f\n" +
+	"\bRELEASED\x10\x02\x12\v\n" +
+	"\aEXPIRED\x10\x03\x12\f\n" +
+	"\bRETURNED\x10\x042\x8e\v\n" +
 	"\x10InventoryService\x12D\n" +
 	"\rCreateProduct\x12\x1f.inventory.CreateProductRequest\x1a\x12.inventory.Product\x12B\n" +
-	"\x0eGetProductByID\x12\x1c.inventory.GetProductRequest\x1a\x12.inventory.Product\x12D\n" +
+	"\x0eGetProductByID\x12\x1c.inventory.GetProductRequest\x1a\x12.inventory.Product\x12[\n" +
+	"\x10GetProductsByIDs\x12\".inventory.GetProductsByIDsRequest\x1a#.inventory.GetProductsByIDsResponse\x12D\n" +
 	"\rUpdateProduct\x12\x1f.inventory.UpdateProductRequest\x1a\x12.inventory.Product\x12H\n" +
 	"\rDeleteProduct\x12\x1f.inventory.DeleteProductRequest\x1a\x16.google.protobuf.Empty\x12O\n" +
 	"\fListProducts\x12\x1e.inventory.ListProductsRequest\x1a\x1f.inventory.ListProductsResponse\x12G\n" +
@@ -1246,7 +1922,12 @@ const file_proto_inventory_proto_rawDesc = "" +
 	"\x0eDeleteCategory\x12 .inventory.DeleteCategoryRequest\x1a\x16.google.protobuf.Empty\x12U\n" +
 	"\x0eListCategories\x12 .inventory.ListCategoriesRequest\x1a!.inventory.ListCategoriesResponse\x12X\n" +
 	"\x17GetProductByIDFromCache\x12).inventory.GetProductByIDFromCacheRequest\x1a\x12.inventory.Product\x12p\n" +
-	"\x17GetAllProductsFromCache\x12).inventory.GetAllProductsFromCacheRequest\x1a*.inventory.GetAllProductsFromCacheResponseB\\ZZgithub.com/mephirious/advanced-programming-2/inventory-service/pkg/api/inventory;inventoryb\x06proto3"
+	"\x17GetAllProductsFromCache\x12).inventory.GetAllProductsFromCacheRequest\x1a*.inventory.GetAllProductsFromCacheResponse\x12F\n" +
+	"\fReserveStock\x12\x1e.inventory.ReserveStockRequest\x1a\x16.inventory.Reservation\x12F\n" +
+	"\fReleaseStock\x12\x1e.inventory.ReleaseStockRequest\x1a\x16.inventory.Reservation\x12P\n" +
+	"\x11CommitReservation\x12#.inventory.CommitReservationRequest\x1a\x16.inventory.Reservation\x12D\n" +
+	"\vReturnStock\x12\x1d.inventory.ReturnStockRequest\x1a\x16.inventory.Reservation\x12B\n" +
+	"\fRestockItems\x12\x1e.inventory.RestockItemsRequest\x1a\x12.inventory.RestockB\\ZZgithub.com/mephirious/advanced-programming-2/inventory-service/pkg/api/inventory;inventoryb\x06proto3"
 
 var (
 	file_proto_inventory_proto_rawDescOnce sync.Once
@@ -1260,66 +1941,100 @@ func file_proto_inventory_proto_rawDescGZIP() []byte {
 	return file_proto_inventory_proto_rawDescData
 }
 
-var file_proto_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_proto_inventory_proto_goTypes = []any{
-	(*Product)(nil),                         // 0: inventory.Product
-	(*CreateProductRequest)(nil),            // 1: inventory.CreateProductRequest
-	(*GetProductRequest)(nil),               // 2: inventory.GetProductRequest
-	(*UpdateProductRequest)(nil),            // 3: inventory.UpdateProductRequest
-	(*DeleteProductRequest)(nil),            // 4: inventory.DeleteProductRequest
-	(*GetByIDRequest)(nil),                  // 5: inventory.GetByIDRequest
-	(*ListProductsRequest)(nil),             // 6: inventory.ListProductsRequest
-	(*ListProductsResponse)(nil),            // 7: inventory.ListProductsResponse
-	(*Category)(nil),                        // 8: inventory.Category
-	(*CreateCategoryRequest)(nil),           // 9: inventory.CreateCategoryRequest
-	(*GetCategoryRequest)(nil),              // 10: inventory.GetCategoryRequest
-	(*UpdateCategoryRequest)(nil),           // 11: inventory.UpdateCategoryRequest
-	(*DeleteCategoryRequest)(nil),           // 12: inventory.DeleteCategoryRequest
-	(*ListCategoriesRequest)(nil),           // 13: inventory.ListCategoriesRequest
-	(*ListCategoriesResponse)(nil),          // 14: inventory.ListCategoriesResponse
-	(*GetProductByIDFromCacheRequest)(nil),  // 15: inventory.GetProductByIDFromCacheRequest
-	(*GetAllProductsFromCacheRequest)(nil),  // 16: inventory.GetAllProductsFromCacheRequest
-	(*GetAllProductsFromCacheResponse)(nil), // 17: inventory.GetAllProductsFromCacheResponse
-	(*timestamppb.Timestamp)(nil),           // 18: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                   // 19: google.protobuf.Empty
+	(ReservationStatus)(0),                  // 0: inventory.ReservationStatus
+	(*Product)(nil),                         // 1: inventory.Product
+	(*CreateProductRequest)(nil),            // 2: inventory.CreateProductRequest
+	(*GetProductRequest)(nil),               // 3: inventory.GetProductRequest
+	(*GetProductsByIDsRequest)(nil),         // 4: inventory.GetProductsByIDsRequest
+	(*GetProductsByIDsResponse)(nil),        // 5: inventory.GetProductsByIDsResponse
+	(*UpdateProductRequest)(nil),            // 6: inventory.UpdateProductRequest
+	(*DeleteProductRequest)(nil),            // 7: inventory.DeleteProductRequest
+	(*GetByIDRequest)(nil),                  // 8: inventory.GetByIDRequest
+	(*ListProductsRequest)(nil),             // 9: inventory.ListProductsRequest
+	(*ListProductsResponse)(nil),            // 10: inventory.ListProductsResponse
+	(*Category)(nil),                        // 11: inventory.Category
+	(*CreateCategoryRequest)(nil),           // 12: inventory.CreateCategoryRequest
+	(*GetCategoryRequest)(nil),              // 13: inventory.GetCategoryRequest
+	(*UpdateCategoryRequest)(nil),           // 14: inventory.UpdateCategoryRequest
+	(*DeleteCategoryRequest)(nil),           // 15: inventory.DeleteCategoryRequest
+	(*ListCategoriesRequest)(nil),           // 16: inventory.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),          // 17: inventory.ListCategoriesResponse
+	(*GetProductByIDFromCacheRequest)(nil),  // 18: inventory.GetProductByIDFromCacheRequest
+	(*GetAllProductsFromCacheRequest)(nil),  // 19: inventory.GetAllProductsFromCacheRequest
+	(*GetAllProductsFromCacheResponse)(nil), // 20: inventory.GetAllProductsFromCacheResponse
+	(*ReservationItem)(nil),                 // 21: inventory.ReservationItem
+	(*Reservation)(nil),                     // 22: inventory.Reservation
+	(*ReserveStockRequest)(nil),             // 23: inventory.ReserveStockRequest
+	(*ReleaseStockRequest)(nil),             // 24: inventory.ReleaseStockRequest
+	(*CommitReservationRequest)(nil),        // 25: inventory.CommitReservationRequest
+	(*ReturnStockRequest)(nil),              // 26: inventory.ReturnStockRequest
+	(*RestockItemsRequest)(nil),             // 27: inventory.RestockItemsRequest
+	(*Restock)(nil),                         // 28: inventory.Restock
+	(*timestamppb.Timestamp)(nil),           // 29: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                   // 30: google.protobuf.Empty
 }
 var file_proto_inventory_proto_depIdxs = []int32{
-	18, // 0: inventory.Product.created_at:type_name -> google.protobuf.Timestamp
-	18, // 1: inventory.Product.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: inventory.ListProductsResponse.products:type_name -> inventory.Product
-	18, // 3: inventory.Category.created_at:type_name -> google.protobuf.Timestamp
-	18, // 4: inventory.Category.updated_at:type_name -> google.protobuf.Timestamp
-	8,  // 5: inventory.ListCategoriesResponse.categories:type_name -> inventory.Category
-	0,  // 6: inventory.GetAllProductsFromCacheResponse.products:type_name -> inventory.Product
-	1,  // 7: inventory.InventoryService.CreateProduct:input_type -> inventory.CreateProductRequest
-	2,  // 8: inventory.InventoryService.GetProductByID:input_type -> inventory.GetProductRequest
-	3,  // 9: inventory.InventoryService.UpdateProduct:input_type -> inventory.UpdateProductRequest
-	4,  // 10: inventory.InventoryService.DeleteProduct:input_type -> inventory.DeleteProductRequest
-	6,  // 11: inventory.InventoryService.ListProducts:input_type -> inventory.ListProductsRequest
-	9,  // 12: inventory.InventoryService.CreateCategory:input_type -> inventory.CreateCategoryRequest
-	10, // 13: inventory.InventoryService.GetCategoryByID:input_type -> inventory.GetCategoryRequest
-	11, // 14: inventory.InventoryService.UpdateCategory:input_type -> inventory.UpdateCategoryRequest
-	12, // 15: inventory.InventoryService.DeleteCategory:input_type -> inventory.DeleteCategoryRequest
-	13, // 16: inventory.InventoryService.ListCategories:input_type -> inventory.ListCategoriesRequest
-	15, // 17: inventory.InventoryService.GetProductByIDFromCache:input_type -> inventory.GetProductByIDFromCacheRequest
-	16, // 18: inventory.InventoryService.GetAllProductsFromCache:input_type -> inventory.GetAllProductsFromCacheRequest
-	0,  // 19: inventory.InventoryService.CreateProduct:output_type -> inventory.Product
-	0,  // 20: inventory.InventoryService.GetProductByID:output_type -> inventory.Product
-	0,  // 21: inventory.InventoryService.UpdateProduct:output_type -> inventory.Product
-	19, // 22: inventory.InventoryService.DeleteProduct:output_type -> google.protobuf.Empty
-	7,  // 23: inventory.InventoryService.ListProducts:output_type -> inventory.ListProductsResponse
-	8,  // 24: inventory.InventoryService.CreateCategory:output_type -> inventory.Category
-	8,  // 25: inventory.InventoryService.GetCategoryByID:output_type -> inventory.Category
-	8,  // 26: inventory.InventoryService.UpdateCategory:output_type -> inventory.Category
-	19, // 27: inventory.InventoryService.DeleteCategory:output_type -> google.protobuf.Empty
-	14, // 28: inventory.InventoryService.ListCategories:output_type -> inventory.ListCategoriesResponse
-	0,  // 29: inventory.InventoryService.GetProductByIDFromCache:output_type -> inventory.Product
-	17, // 30: inventory.InventoryService.GetAllProductsFromCache:output_type -> inventory.GetAllProductsFromCacheResponse
-	19, // [19:31] is the sub-list for method output_type
-	7,  // [7:19] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	29, // 0: inventory.Product.created_at:type_name -> google.protobuf.Timestamp
+	29, // 1: inventory.Product.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: inventory.GetProductsByIDsResponse.products:type_name -> inventory.Product
+	1,  // 3: inventory.ListProductsResponse.products:type_name -> inventory.Product
+	29, // 4: inventory.Category.created_at:type_name -> google.protobuf.Timestamp
+	29, // 5: inventory.Category.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 6: inventory.ListCategoriesResponse.categories:type_name -> inventory.Category
+	1,  // 7: inventory.GetAllProductsFromCacheResponse.products:type_name -> inventory.Product
+	21, // 8: inventory.Reservation.items:type_name -> inventory.ReservationItem
+	0,  // 9: inventory.Reservation.status:type_name -> inventory.ReservationStatus
+	29, // 10: inventory.Reservation.expires_at:type_name -> google.protobuf.Timestamp
+	29, // 11: inventory.Reservation.created_at:type_name -> google.protobuf.Timestamp
+	29, // 12: inventory.Reservation.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 13: inventory.ReserveStockRequest.items:type_name -> inventory.ReservationItem
+	21, // 14: inventory.RestockItemsRequest.items:type_name -> inventory.ReservationItem
+	21, // 15: inventory.Restock.items:type_name -> inventory.ReservationItem
+	29, // 16: inventory.Restock.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 17: inventory.InventoryService.CreateProduct:input_type -> inventory.CreateProductRequest
+	3,  // 18: inventory.InventoryService.GetProductByID:input_type -> inventory.GetProductRequest
+	4,  // 19: inventory.InventoryService.GetProductsByIDs:input_type -> inventory.GetProductsByIDsRequest
+	6,  // 20: inventory.InventoryService.UpdateProduct:input_type -> inventory.UpdateProductRequest
+	7,  // 21: inventory.InventoryService.DeleteProduct:input_type -> inventory.DeleteProductRequest
+	9,  // 22: inventory.InventoryService.ListProducts:input_type -> inventory.ListProductsRequest
+	12, // 23: inventory.InventoryService.CreateCategory:input_type -> inventory.CreateCategoryRequest
+	13, // 24: inventory.InventoryService.GetCategoryByID:input_type -> inventory.GetCategoryRequest
+	14, // 25: inventory.InventoryService.UpdateCategory:input_type -> inventory.UpdateCategoryRequest
+	15, // 26: inventory.InventoryService.DeleteCategory:input_type -> inventory.DeleteCategoryRequest
+	16, // 27: inventory.InventoryService.ListCategories:input_type -> inventory.ListCategoriesRequest
+	18, // 28: inventory.InventoryService.GetProductByIDFromCache:input_type -> inventory.GetProductByIDFromCacheRequest
+	19, // 29: inventory.InventoryService.GetAllProductsFromCache:input_type -> inventory.GetAllProductsFromCacheRequest
+	23, // 30: inventory.InventoryService.ReserveStock:input_type -> inventory.ReserveStockRequest
+	24, // 31: inventory.InventoryService.ReleaseStock:input_type -> inventory.ReleaseStockRequest
+	25, // 32: inventory.InventoryService.CommitReservation:input_type -> inventory.CommitReservationRequest
+	26, // 33: inventory.InventoryService.ReturnStock:input_type -> inventory.ReturnStockRequest
+	27, // 34: inventory.InventoryService.RestockItems:input_type -> inventory.RestockItemsRequest
+	1,  // 35: inventory.InventoryService.CreateProduct:output_type -> inventory.Product
+	1,  // 36: inventory.InventoryService.GetProductByID:output_type -> inventory.Product
+	5,  // 37: inventory.InventoryService.GetProductsByIDs:output_type -> inventory.GetProductsByIDsResponse
+	1,  // 38: inventory.InventoryService.UpdateProduct:output_type -> inventory.Product
+	30, // 39: inventory.InventoryService.DeleteProduct:output_type -> google.protobuf.Empty
+	10, // 40: inventory.InventoryService.ListProducts:output_type -> inventory.ListProductsResponse
+	11, // 41: inventory.InventoryService.CreateCategory:output_type -> inventory.Category
+	11, // 42: inventory.InventoryService.GetCategoryByID:output_type -> inventory.Category
+	11, // 43: inventory.InventoryService.UpdateCategory:output_type -> inventory.Category
+	30, // 44: inventory.InventoryService.DeleteCategory:output_type -> google.protobuf.Empty
+	17, // 45: inventory.InventoryService.ListCategories:output_type -> inventory.ListCategoriesResponse
+	1,  // 46: inventory.InventoryService.GetProductByIDFromCache:output_type -> inventory.Product
+	20, // 47: inventory.InventoryService.GetAllProductsFromCache:output_type -> inventory.GetAllProductsFromCacheResponse
+	22, // 48: inventory.InventoryService.ReserveStock:output_type -> inventory.Reservation
+	22, // 49: inventory.InventoryService.ReleaseStock:output_type -> inventory.Reservation
+	22, // 50: inventory.InventoryService.CommitReservation:output_type -> inventory.Reservation
+	22, // 51: inventory.InventoryService.ReturnStock:output_type -> inventory.Reservation
+	28, // 52: inventory.InventoryService.RestockItems:output_type -> inventory.Restock
+	35, // [35:53] is the sub-list for method output_type
+	17, // [17:35] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_proto_inventory_proto_init() }
@@ -1327,23 +2042,24 @@ func file_proto_inventory_proto_init() {
 	if File_proto_inventory_proto != nil {
 		return
 	}
-	file_proto_inventory_proto_msgTypes[3].OneofWrappers = []any{}
-	file_proto_inventory_proto_msgTypes[6].OneofWrappers = []any{}
-	file_proto_inventory_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_inventory_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_inventory_proto_msgTypes[8].OneofWrappers = []any{}
 	file_proto_inventory_proto_msgTypes[13].OneofWrappers = []any{}
-	file_proto_inventory_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_inventory_proto_msgTypes[15].OneofWrappers = []any{}
+	file_proto_inventory_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_inventory_proto_rawDesc), len(file_proto_inventory_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   18,
+			NumEnums:      1,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_inventory_proto_goTypes,
 		DependencyIndexes: file_proto_inventory_proto_depIdxs,
+		EnumInfos:         file_proto_inventory_proto_enumTypes,
 		MessageInfos:      file_proto_inventory_proto_msgTypes,
 	}.Build()
 	File_proto_inventory_proto = out.File
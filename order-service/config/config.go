@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/mephirious/advanced-programming-2/order-service/pkg/mongo"
@@ -12,9 +13,15 @@ import (
 
 type (
 	Config struct {
-		Mongo  mongo.Config
-		NATS   NATSConfig
-		Server Server
+		Mongo     mongo.Config
+		NATS      NATSConfig
+		Outbox    OutboxConfig
+		Server    Server
+		Inventory InventoryConfig
+		Catalog   CatalogConfig
+		Payment   PaymentConfig
+		Cart      CartConfig
+		Order     OrderConfig
 	}
 
 	Server struct {
@@ -28,6 +35,42 @@ type (
 
 	NATSConfig struct {
 		URL string `env:"NATS_URL,required"`
+
+		// MaxDeliver bounds how often a failing message is delivered.
+		MaxDeliver int           `env:"NATS_MAX_DELIVER" envDefault:"5"`
+		AckWait    time.Duration `env:"NATS_ACK_WAIT" envDefault:"30s"`
+	}
+
+	OutboxConfig struct {
+		RelayInterval time.Duration `env:"OUTBOX_RELAY_INTERVAL" envDefault:"1s"`
+	}
+
+	InventoryConfig struct {
+		Addr    string        `env:"INVENTORY_SERVICE_GRPC" envDefault:"localhost:8001"`
+		Timeout time.Duration `env:"INVENTORY_TIMEOUT" envDefault:"5s"`
+	}
+
+	// CatalogConfig selects what orders and carts are priced with: the
+	// inventory service over gRPC or the local product projection.
+	CatalogConfig struct {
+		Source string `env:"PRODUCT_CATALOG" envDefault:"rpc"`
+	}
+
+	// OrderConfig sets how often pending orders whose stock reservation
+	// expired are cancelled.
+	OrderConfig struct {
+		ExpiryInterval time.Duration `env:"ORDER_EXPIRY_INTERVAL" envDefault:"30s"`
+	}
+
+	CartConfig struct {
+		TTL time.Duration `env:"CART_TTL" envDefault:"168h"`
+	}
+
+	PaymentConfig struct {
+		Timeout          time.Duration `env:"PAYMENT_TIMEOUT" envDefault:"10s"`
+		FakeOutcome      string        `env:"PAYMENT_FAKE_OUTCOME" envDefault:"success"`
+		FakeDeclineAbove float64       `env:"PAYMENT_FAKE_DECLINE_ABOVE"`
+		FakeLatency      time.Duration `env:"PAYMENT_FAKE_LATENCY"`
 	}
 )
 
@@ -60,6 +103,79 @@ func New() (*Config, error) {
 	cfg.Mongo.Password = os.Getenv("MONGO_PASSWORD")
 
 	cfg.NATS.URL = os.Getenv("NATS_URL")
+	cfg.NATS.MaxDeliver = 5
+	if v := os.Getenv("NATS_MAX_DELIVER"); v != "" {
+		cfg.NATS.MaxDeliver, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS_MAX_DELIVER value: %w", err)
+		}
+	}
+	cfg.NATS.AckWait, err = durationEnv("NATS_ACK_WAIT", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Outbox.RelayInterval, err = durationEnv("OUTBOX_RELAY_INTERVAL", time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Inventory.Addr = os.Getenv("INVENTORY_SERVICE_GRPC")
+	if cfg.Inventory.Addr == "" {
+		cfg.Inventory.Addr = "localhost:8001"
+	}
+	cfg.Inventory.Timeout, err = durationEnv("INVENTORY_TIMEOUT", 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Catalog.Source = os.Getenv("PRODUCT_CATALOG")
+	if cfg.Catalog.Source == "" {
+		cfg.Catalog.Source = "rpc"
+	}
+	if cfg.Catalog.Source != "rpc" && cfg.Catalog.Source != "projection" {
+		return nil, fmt.Errorf("invalid PRODUCT_CATALOG value %q: want rpc or projection", cfg.Catalog.Source)
+	}
+
+	cfg.Cart.TTL, err = durationEnv("CART_TTL", 7*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Order.ExpiryInterval, err = durationEnv("ORDER_EXPIRY_INTERVAL", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Payment.Timeout, err = durationEnv("PAYMENT_TIMEOUT", 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Payment.FakeOutcome = os.Getenv("PAYMENT_FAKE_OUTCOME")
+	if cfg.Payment.FakeOutcome == "" {
+		cfg.Payment.FakeOutcome = "success"
+	}
+	if v := os.Getenv("PAYMENT_FAKE_DECLINE_ABOVE"); v != "" {
+		cfg.Payment.FakeDeclineAbove, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PAYMENT_FAKE_DECLINE_ABOVE value: %w", err)
+		}
+	}
+	cfg.Payment.FakeLatency, err = durationEnv("PAYMENT_FAKE_LATENCY", 0)
+	if err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
+
+func durationEnv(key string, defaultVal time.Duration) (time.Duration, error) {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return d, nil
+}
@@ -28,7 +28,7 @@ echo "Fetched Order!"
 echo "Updating Order Status..."
 grpcurl -plaintext -d '{
   "id": "67f2c352315ca4f05670da8b",
-  "status": "completed"
+  "status": "paid"
 }' localhost:8002 order.OrderService/UpdateOrderStatus
 
 echo "Order Status Updated!"
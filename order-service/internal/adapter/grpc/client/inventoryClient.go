@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	inventorypb "github.com/mephirious/advanced-programming-2/order-service/proto/inventory"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// productBatchSize is the largest number of products the inventory service
+// returns per GetProductsByIDs call.
+const productBatchSize = 100
+
+type InventoryClient struct {
+	conn    *grpc.ClientConn
+	client  inventorypb.InventoryServiceClient
+	timeout time.Duration
+}
+
+func NewInventoryClient(addr string, timeout time.Duration) (*InventoryClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to inventory service: %w", err)
+	}
+
+	return &InventoryClient{
+		conn:    conn,
+		client:  inventorypb.NewInventoryServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// ReserveStock reserves the order items in the inventory service and returns
+// the reservation id and expiry. Reserving twice for the same order is
+// idempotent.
+func (c *InventoryClient) ReserveStock(ctx context.Context, orderID string, items []domain.OrderItem) (string, time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	reqItems := make([]*inventorypb.ReservationItem, len(items))
+	for i, item := range items {
+		reqItems[i] = &inventorypb.ReservationItem{
+			ProductId: item.ProductID.Hex(),
+			Quantity:  int32(item.Quantity),
+		}
+	}
+
+	reservation, err := c.client.ReserveStock(ctx, &inventorypb.ReserveStockRequest{
+		OrderId: orderID,
+		Items:   reqItems,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return reservation.GetId(), reservation.GetExpiresAt().AsTime(), nil
+}
+
+// ReleaseStock gives back the stock of a held reservation. Releasing a
+// committed reservation fails with domain.ErrFailedPrecondition.
+func (c *InventoryClient) ReleaseStock(ctx context.Context, reservationID string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.client.ReleaseStock(ctx, &inventorypb.ReleaseStockRequest{ReservationId: reservationID})
+	return reservationError(err)
+}
+
+func (c *InventoryClient) CommitReservation(ctx context.Context, reservationID string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.client.CommitReservation(ctx, &inventorypb.CommitReservationRequest{ReservationId: reservationID})
+	return reservationError(err)
+}
+
+// ReturnStock puts the stock of a committed reservation back on the shelf.
+func (c *InventoryClient) ReturnStock(ctx context.Context, reservationID string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.client.ReturnStock(ctx, &inventorypb.ReturnStockRequest{ReservationId: reservationID})
+	return reservationError(err)
+}
+
+// RestockItems puts returned items back into stock. Restocking the same
+// reference twice changes the stock only once.
+func (c *InventoryClient) RestockItems(ctx context.Context, reference, orderID string, items []domain.ReturnItem) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	reqItems := make([]*inventorypb.ReservationItem, len(items))
+	for i, item := range items {
+		reqItems[i] = &inventorypb.ReservationItem{
+			ProductId: item.ProductID.Hex(),
+			Quantity:  int32(item.Quantity),
+		}
+	}
+
+	_, err := c.client.RestockItems(ctx, &inventorypb.RestockItemsRequest{
+		Reference: reference,
+		OrderId:   orderID,
+		Items:     reqItems,
+	})
+	return err
+}
+
+// GetProductsByIDs returns the current data of the products that exist among
+// ids from the inventory service, keyed by product ID. Large lookups are split
+// into batches, each with its own timeout.
+func (c *InventoryClient) GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]domain.CatalogProduct, error) {
+	products := make(map[primitive.ObjectID]domain.CatalogProduct, len(ids))
+	for batch := range slices.Chunk(ids, productBatchSize) {
+		if err := c.getProducts(ctx, batch, products); err != nil {
+			return nil, err
+		}
+	}
+	return products, nil
+}
+
+func (c *InventoryClient) getProducts(ctx context.Context, ids []primitive.ObjectID, products map[primitive.ObjectID]domain.CatalogProduct) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req := &inventorypb.GetProductsByIDsRequest{Ids: make([]string, len(ids))}
+	for i, id := range ids {
+		req.Ids[i] = id.Hex()
+	}
+
+	res, err := c.client.GetProductsByIDs(ctx, req)
+	if err != nil {
+		return err
+	}
+	for _, p := range res.GetProducts() {
+		product, err := mapProductFromProto(p)
+		if err != nil {
+			return err
+		}
+		products[product.ID] = product
+	}
+	return nil
+}
+
+// ListProducts returns a page of products ordered by ID. Pages start at 1.
+func (c *InventoryClient) ListProducts(ctx context.Context, page, limit int) ([]domain.Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.client.ListProducts(ctx, &inventorypb.ListProductsRequest{
+		Page:      int32(page),
+		Limit:     int32(limit),
+		SortBy:    "_id",
+		SortOrder: "asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]domain.Product, 0, len(res.GetProducts()))
+	for _, p := range res.GetProducts() {
+		product, err := mapProductFromProto(p)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, domain.Product{
+			ID:        product.ID,
+			Name:      product.Name,
+			Price:     product.Price,
+			Stock:     product.Stock,
+			UpdatedAt: p.GetUpdatedAt().AsTime(),
+		})
+	}
+	return products, nil
+}
+
+// reservationError turns the status errors of the reservation RPCs that a
+// retry cannot fix into domain errors, so callers can tell them from outages.
+func reservationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", domain.ErrFailedPrecondition, st.Message())
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", domain.ErrNotFound, st.Message())
+	default:
+		return err
+	}
+}
+
+func (c *InventoryClient) Close() error {
+	return c.conn.Close()
+}
+
+func mapProductFromProto(p *inventorypb.Product) (domain.CatalogProduct, error) {
+	id, err := primitive.ObjectIDFromHex(p.GetId())
+	if err != nil {
+		return domain.CatalogProduct{}, fmt.Errorf("invalid product ID %q from inventory: %w", p.GetId(), err)
+	}
+
+	return domain.CatalogProduct{
+		ID:    id,
+		Name:  p.GetName(),
+		Price: p.GetPrice(),
+		Stock: p.GetStock(),
+	}, nil
+}
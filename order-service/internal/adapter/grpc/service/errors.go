@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorInterceptor translates domain errors returned by the handlers into gRPC
+// status errors. Unexpected errors are logged and reported as Internal without
+// leaking their details to the caller.
+func errorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	return resp, toStatusError(info.FullMethod, err)
+}
+
+func toStatusError(method string, err error) error {
+	// Errors from downstream services keep their original status.
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.GRPCStatus().Err()
+	}
+
+	var code codes.Code
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.Is(err, domain.ErrConflict):
+		code = codes.AlreadyExists
+	case errors.Is(err, domain.ErrFailedPrecondition):
+		code = codes.FailedPrecondition
+	case errors.Is(err, domain.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	default:
+		log.Printf("%s: %v", method, err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	return status.Error(code, err.Error())
+}
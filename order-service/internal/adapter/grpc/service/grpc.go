@@ -9,6 +9,7 @@ import (
 	"github.com/mephirious/advanced-programming-2/order-service/internal/adapter/grpc/service/handler"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/usecase"
 	orderpb "github.com/mephirious/advanced-programming-2/order-service/proto"
+	"github.com/mephirious/advanced-programming-2/order-service/pkg/auth"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -20,17 +21,18 @@ type GRPCServer struct {
 	listener net.Listener
 }
 
-func NewGRPCServer(cfg config.Config, orderUC usecase.OrderUseCase) (*GRPCServer, error) {
+func NewGRPCServer(cfg config.Config, orderUC usecase.OrderUseCase, paymentUC usecase.PaymentUseCase, cartUC usecase.CartUseCase, productUC usecase.ProductUseCase, returnUC usecase.ReturnUseCase, shipmentUC usecase.ShipmentUseCase) (*GRPCServer, error) {
 	addr := fmt.Sprintf("0.0.0.0:%d", cfg.Server.GRPCServer.Port)
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
 
-	s := grpc.NewServer()
-	orderHandler := handler.NewOrderHandler(orderUC)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(), errorInterceptor))
+	orderHandler := handler.NewOrderHandler(orderUC, paymentUC, productUC, returnUC, shipmentUC)
 
 	orderpb.RegisterOrderServiceServer(s, orderHandler)
+	orderpb.RegisterCartServiceServer(s, handler.NewCartHandler(cartUC))
 
 	reflection.Register(s)
 
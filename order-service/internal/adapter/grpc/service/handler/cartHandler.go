@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/usecase"
+	orderpb "github.com/mephirious/advanced-programming-2/order-service/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CartHandler struct {
+	cartUC usecase.CartUseCase
+	orderpb.UnimplementedCartServiceServer
+}
+
+func NewCartHandler(cartUC usecase.CartUseCase) *CartHandler {
+	return &CartHandler{
+		cartUC: cartUC,
+	}
+}
+
+func (h *CartHandler) GetCart(ctx context.Context, req *orderpb.GetCartRequest) (*orderpb.CartResponse, error) {
+	cart, err := h.cartUC.GetCart(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.CartResponse{
+		Cart: mapCartToProto(cart),
+	}, nil
+}
+
+func (h *CartHandler) AddCartItem(ctx context.Context, req *orderpb.CartItemRequest) (*orderpb.CartResponse, error) {
+	cart, err := h.cartUC.AddItem(ctx, req.GetUserId(), req.GetProductId(), int(req.GetQuantity()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.CartResponse{
+		Cart: mapCartToProto(cart),
+	}, nil
+}
+
+func (h *CartHandler) UpdateCartItem(ctx context.Context, req *orderpb.CartItemRequest) (*orderpb.CartResponse, error) {
+	cart, err := h.cartUC.UpdateItem(ctx, req.GetUserId(), req.GetProductId(), int(req.GetQuantity()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.CartResponse{
+		Cart: mapCartToProto(cart),
+	}, nil
+}
+
+func (h *CartHandler) RemoveCartItem(ctx context.Context, req *orderpb.RemoveCartItemRequest) (*orderpb.CartResponse, error) {
+	cart, err := h.cartUC.RemoveItem(ctx, req.GetUserId(), req.GetProductId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.CartResponse{
+		Cart: mapCartToProto(cart),
+	}, nil
+}
+
+func (h *CartHandler) ClearCart(ctx context.Context, req *orderpb.ClearCartRequest) (*emptypb.Empty, error) {
+	if err := h.cartUC.ClearCart(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CartHandler) CheckoutCart(ctx context.Context, req *orderpb.CheckoutCartRequest) (*orderpb.OrderResponse, error) {
+	order, err := h.cartUC.Checkout(ctx, req.GetUserId(), mapProtoToAddress(req.GetShippingAddress()), mapProtoToAddress(req.GetBillingAddress()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.OrderResponse{
+		Order: mapOrderToProto(order),
+	}, nil
+}
+
+func mapCartToProto(c *domain.PricedCart) *orderpb.Cart {
+	lines := make([]*orderpb.CartLine, len(c.Lines))
+	for i, line := range c.Lines {
+		lines[i] = &orderpb.CartLine{
+			ProductId: line.ProductID.Hex(),
+			Name:      line.Name,
+			Quantity:  int32(line.Quantity),
+			UnitPrice: line.UnitPrice,
+			LineTotal: line.LineTotal,
+			Available: line.Available,
+			Warning:   line.Warning,
+		}
+	}
+
+	cart := &orderpb.Cart{
+		UserId: c.UserID.Hex(),
+		Lines:  lines,
+		Total:  c.Total,
+	}
+	if !c.UpdatedAt.IsZero() {
+		cart.UpdatedAt = timestamppb.New(c.UpdatedAt)
+		cart.ExpiresAt = timestamppb.New(c.ExpiresAt)
+	}
+	return cart
+}
@@ -12,13 +12,27 @@ import (
 )
 
 type OrderHandler struct {
-	orderUC usecase.OrderUseCase
+	orderUC    usecase.OrderUseCase
+	paymentUC  usecase.PaymentUseCase
+	productUC  usecase.ProductUseCase
+	returnUC   usecase.ReturnUseCase
+	shipmentUC usecase.ShipmentUseCase
 	orderpb.UnimplementedOrderServiceServer
 }
 
-func NewOrderHandler(orderUC usecase.OrderUseCase) *OrderHandler {
+func NewOrderHandler(
+	orderUC usecase.OrderUseCase,
+	paymentUC usecase.PaymentUseCase,
+	productUC usecase.ProductUseCase,
+	returnUC usecase.ReturnUseCase,
+	shipmentUC usecase.ShipmentUseCase,
+) *OrderHandler {
 	return &OrderHandler{
-		orderUC: orderUC,
+		orderUC:    orderUC,
+		paymentUC:  paymentUC,
+		productUC:  productUC,
+		returnUC:   returnUC,
+		shipmentUC: shipmentUC,
 	}
 }
 
@@ -32,8 +46,10 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *orderpb.CreateOrder
 	}
 
 	dto := dto.OrderCreateDTO{
-		UserID: req.GetUserId(),
-		Items:  items,
+		UserID:          req.GetUserId(),
+		Items:           items,
+		ShippingAddress: mapProtoToAddress(req.GetShippingAddress()),
+		BillingAddress:  mapProtoToAddress(req.GetBillingAddress()),
 	}
 
 	order, err := h.orderUC.CreateOrder(ctx, dto)
@@ -68,6 +84,17 @@ func (h *OrderHandler) UpdateOrderStatus(ctx context.Context, req *orderpb.Updat
 	}, nil
 }
 
+func (h *OrderHandler) CancelOrder(ctx context.Context, req *orderpb.CancelOrderRequest) (*orderpb.OrderResponse, error) {
+	order, err := h.orderUC.CancelOrder(ctx, req.GetId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.OrderResponse{
+		Order: mapOrderToProto(order),
+	}, nil
+}
+
 func (h *OrderHandler) ListUserOrders(ctx context.Context, req *orderpb.ListOrdersRequest) (*orderpb.ListOrdersResponse, error) {
 	filter := dto.OrderFilterDTO{
 		UserID: req.GetUserId(),
@@ -94,22 +121,94 @@ func mapOrderToProto(o *domain.Order) *orderpb.Order {
 	items := make([]*orderpb.OrderItem, len(o.Items))
 	for i, item := range o.Items {
 		items[i] = &orderpb.OrderItem{
-			ProductId: item.ProductID.Hex(),
-			Quantity:  int32(item.Quantity),
-			Price:     item.Price,
+			ProductId:        item.ProductID.Hex(),
+			Quantity:         int32(item.Quantity),
+			Price:            item.Price,
+			RefundedQuantity: int32(item.RefundedQuantity),
+		}
+	}
+
+	history := make([]*orderpb.StatusTransition, len(o.StatusHistory))
+	for i, t := range o.StatusHistory {
+		history[i] = &orderpb.StatusTransition{
+			From:   mapOrderStatusToProto(t.From),
+			To:     mapOrderStatusToProto(t.To),
+			Actor:  t.Actor,
+			At:     timestamppb.New(t.At),
+			Reason: t.Reason,
 		}
 	}
 
 	return &orderpb.Order{
-		Id:        o.ID.Hex(),
-		UserId:    o.UserID.Hex(),
-		Items:     items,
-		Status:    mapOrderStatusToProto(o.Status),
-		CreatedAt: timestamppb.New(o.CreatedAt),
-		UpdatedAt: timestamppb.New(o.UpdatedAt),
+		Id:            o.ID.Hex(),
+		UserId:        o.UserID.Hex(),
+		Items:         items,
+		Total:         o.Total,
+		Status:        mapOrderStatusToProto(o.Status),
+		CreatedAt:     timestamppb.New(o.CreatedAt),
+		UpdatedAt:     timestamppb.New(o.UpdatedAt),
+		StatusHistory: history,
+		CancelReason:  o.CancelReason,
+		RefundedTotal: o.RefundedTotal,
+		NetTotal:      o.NetTotal(),
+
+		ShippingAddress: mapAddressToProto(o.ShippingAddress),
+		BillingAddress:  mapAddressToProto(o.BillingAddress),
+	}
+}
+
+func mapAddressToProto(a *domain.Address) *orderpb.Address {
+	if a == nil {
+		return nil
+	}
+	return &orderpb.Address{
+		Name:       a.Name,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func mapProtoToAddress(a *orderpb.Address) *domain.Address {
+	if a == nil {
+		return nil
+	}
+	return &domain.Address{
+		Name:       a.GetName(),
+		Line1:      a.GetLine1(),
+		Line2:      a.GetLine2(),
+		City:       a.GetCity(),
+		Region:     a.GetRegion(),
+		PostalCode: a.GetPostalCode(),
+		Country:    a.GetCountry(),
 	}
 }
 
+func (h *OrderHandler) PayOrder(ctx context.Context, req *orderpb.PayOrderRequest) (*orderpb.PaymentResponse, error) {
+	payment, err := h.paymentUC.PayOrder(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.PaymentResponse{
+		Payment: mapPaymentToProto(payment),
+	}, nil
+}
+
+func (h *OrderHandler) GetOrderPayment(ctx context.Context, req *orderpb.GetOrderPaymentRequest) (*orderpb.PaymentResponse, error) {
+	payment, err := h.paymentUC.GetOrderPayment(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.PaymentResponse{
+		Payment: mapPaymentToProto(payment),
+	}, nil
+}
+
 func mapOrderStatusToProto(status domain.OrderStatus) orderpb.OrderStatus {
 	switch status {
 	case domain.OrderStatusPending:
@@ -118,7 +217,54 @@ func mapOrderStatusToProto(status domain.OrderStatus) orderpb.OrderStatus {
 		return orderpb.OrderStatus_COMPLETED
 	case domain.OrderStatusCancelled:
 		return orderpb.OrderStatus_CANCELLED
+	case domain.OrderStatusPaid:
+		return orderpb.OrderStatus_PAID
+	case domain.OrderStatusProcessing:
+		return orderpb.OrderStatus_PROCESSING
+	case domain.OrderStatusShipped:
+		return orderpb.OrderStatus_SHIPPED
+	case domain.OrderStatusDelivered:
+		return orderpb.OrderStatus_DELIVERED
+	case domain.OrderStatusRefunded:
+		return orderpb.OrderStatus_REFUNDED
 	default:
 		return orderpb.OrderStatus_PENDING
 	}
 }
+
+func mapPaymentToProto(p *domain.Payment) *orderpb.Payment {
+	return &orderpb.Payment{
+		Id:                p.ID.Hex(),
+		OrderId:           p.OrderID.Hex(),
+		Amount:            p.Amount,
+		CapturedAmount:    p.CapturedAmount,
+		RefundedAmount:    p.RefundedAmount,
+		Status:            mapPaymentStatusToProto(p.Status),
+		Provider:          p.Provider,
+		ProviderReference: p.ProviderReference,
+		FailureReason:     p.FailureReason,
+		CreatedAt:         timestamppb.New(p.CreatedAt),
+		UpdatedAt:         timestamppb.New(p.UpdatedAt),
+	}
+}
+
+func mapPaymentStatusToProto(status domain.PaymentStatus) orderpb.PaymentStatus {
+	switch status {
+	case domain.PaymentStatusAuthorized:
+		return orderpb.PaymentStatus_PAYMENT_AUTHORIZED
+	case domain.PaymentStatusCaptured:
+		return orderpb.PaymentStatus_PAYMENT_CAPTURED
+	case domain.PaymentStatusVoided:
+		return orderpb.PaymentStatus_PAYMENT_VOIDED
+	case domain.PaymentStatusPartiallyRefunded:
+		return orderpb.PaymentStatus_PAYMENT_PARTIALLY_REFUNDED
+	case domain.PaymentStatusRefunded:
+		return orderpb.PaymentStatus_PAYMENT_REFUNDED
+	case domain.PaymentStatusDeclined:
+		return orderpb.PaymentStatus_PAYMENT_DECLINED
+	case domain.PaymentStatusFailed:
+		return orderpb.PaymentStatus_PAYMENT_FAILED
+	default:
+		return orderpb.PaymentStatus_PAYMENT_PENDING
+	}
+}
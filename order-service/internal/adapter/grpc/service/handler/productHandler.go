@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"context"
+
+	orderpb "github.com/mephirious/advanced-programming-2/order-service/proto"
+)
+
+func (h *OrderHandler) ResyncProducts(ctx context.Context, req *orderpb.ResyncProductsRequest) (*orderpb.ResyncProductsResponse, error) {
+	resync, err := h.productUC.ResyncProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderpb.ResyncProductsResponse{
+		Synced:  int32(resync.Synced),
+		Deleted: int32(resync.Deleted),
+	}, nil
+}
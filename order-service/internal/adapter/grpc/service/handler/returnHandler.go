@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain/dto"
+	orderpb "github.com/mephirious/advanced-programming-2/order-service/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *OrderHandler) OpenReturn(ctx context.Context, req *orderpb.OpenReturnRequest) (*orderpb.ReturnResponse, error) {
+	items := make([]dto.OrderItemDTO, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = dto.OrderItemDTO{
+			ProductID: item.GetProductId(),
+			Quantity:  int(item.GetQuantity()),
+		}
+	}
+
+	ret, err := h.returnUC.OpenReturn(ctx, dto.ReturnCreateDTO{
+		OrderID: req.GetOrderId(),
+		Items:   items,
+		Reason:  req.GetReason(),
+	})
+	return returnResponse(ret, err)
+}
+
+func (h *OrderHandler) GetReturn(ctx context.Context, req *orderpb.ReturnRequest) (*orderpb.ReturnResponse, error) {
+	return returnResponse(h.returnUC.GetReturn(ctx, req.GetId()))
+}
+
+func (h *OrderHandler) ListOrderReturns(ctx context.Context, req *orderpb.ListOrderReturnsRequest) (*orderpb.ListReturnsResponse, error) {
+	returns, err := h.returnUC.ListOrderReturns(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &orderpb.ListReturnsResponse{}
+	for i := range returns {
+		res.Returns = append(res.Returns, mapReturnToProto(&returns[i]))
+	}
+	return res, nil
+}
+
+func (h *OrderHandler) ApproveReturn(ctx context.Context, req *orderpb.ReturnRequest) (*orderpb.ReturnResponse, error) {
+	return returnResponse(h.returnUC.ApproveReturn(ctx, req.GetId()))
+}
+
+func (h *OrderHandler) RejectReturn(ctx context.Context, req *orderpb.RejectReturnRequest) (*orderpb.ReturnResponse, error) {
+	return returnResponse(h.returnUC.RejectReturn(ctx, req.GetId(), req.GetReason()))
+}
+
+func (h *OrderHandler) ReceiveReturn(ctx context.Context, req *orderpb.ReceiveReturnRequest) (*orderpb.ReturnResponse, error) {
+	return returnResponse(h.returnUC.ReceiveReturn(ctx, req.GetId(), req.GetRestock()))
+}
+
+func (h *OrderHandler) RefundReturn(ctx context.Context, req *orderpb.ReturnRequest) (*orderpb.ReturnResponse, error) {
+	return returnResponse(h.returnUC.RefundReturn(ctx, req.GetId()))
+}
+
+func returnResponse(ret *domain.Return, err error) (*orderpb.ReturnResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &orderpb.ReturnResponse{OrderReturn: mapReturnToProto(ret)}, nil
+}
+
+func mapReturnToProto(r *domain.Return) *orderpb.OrderReturn {
+	items := make([]*orderpb.OrderItem, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = &orderpb.OrderItem{
+			ProductId: item.ProductID.Hex(),
+			Quantity:  int32(item.Quantity),
+			Price:     item.Price,
+		}
+	}
+
+	history := make([]*orderpb.ReturnTransition, len(r.StatusHistory))
+	for i, t := range r.StatusHistory {
+		history[i] = &orderpb.ReturnTransition{
+			From:  mapReturnStatusToProto(t.From),
+			To:    mapReturnStatusToProto(t.To),
+			Actor: t.Actor,
+			At:    timestamppb.New(t.At),
+		}
+	}
+
+	return &orderpb.OrderReturn{
+		Id:            r.ID.Hex(),
+		OrderId:       r.OrderID.Hex(),
+		UserId:        r.UserID.Hex(),
+		Items:         items,
+		Amount:        r.Amount,
+		Reason:        r.Reason,
+		RejectReason:  r.RejectReason,
+		Status:        mapReturnStatusToProto(r.Status),
+		Restocked:     r.Restocked,
+		CreatedAt:     timestamppb.New(r.CreatedAt),
+		UpdatedAt:     timestamppb.New(r.UpdatedAt),
+		StatusHistory: history,
+	}
+}
+
+func mapReturnStatusToProto(status domain.ReturnStatus) orderpb.ReturnStatus {
+	switch status {
+	case domain.ReturnStatusApproved:
+		return orderpb.ReturnStatus_RETURN_APPROVED
+	case domain.ReturnStatusRejected:
+		return orderpb.ReturnStatus_RETURN_REJECTED
+	case domain.ReturnStatusReceived:
+		return orderpb.ReturnStatus_RETURN_RECEIVED
+	case domain.ReturnStatusRefunded:
+		return orderpb.ReturnStatus_RETURN_REFUNDED
+	default:
+		return orderpb.ReturnStatus_RETURN_REQUESTED
+	}
+}
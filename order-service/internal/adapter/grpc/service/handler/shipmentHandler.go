@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain/dto"
+	orderpb "github.com/mephirious/advanced-programming-2/order-service/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *OrderHandler) CreateShipment(ctx context.Context, req *orderpb.CreateShipmentRequest) (*orderpb.ShipmentResponse, error) {
+	items := make([]dto.OrderItemDTO, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = dto.OrderItemDTO{
+			ProductID: item.GetProductId(),
+			Quantity:  int(item.GetQuantity()),
+		}
+	}
+
+	shipment, err := h.shipmentUC.CreateShipment(ctx, dto.ShipmentCreateDTO{
+		OrderID:        req.GetOrderId(),
+		Items:          items,
+		Address:        mapProtoToAddress(req.GetAddress()),
+		Carrier:        req.GetCarrier(),
+		TrackingNumber: req.GetTrackingNumber(),
+	})
+	return shipmentResponse(shipment, err)
+}
+
+func (h *OrderHandler) GetShipment(ctx context.Context, req *orderpb.ShipmentRequest) (*orderpb.ShipmentResponse, error) {
+	return shipmentResponse(h.shipmentUC.GetShipment(ctx, req.GetId()))
+}
+
+func (h *OrderHandler) ListOrderShipments(ctx context.Context, req *orderpb.ListOrderShipmentsRequest) (*orderpb.ListShipmentsResponse, error) {
+	shipments, err := h.shipmentUC.ListOrderShipments(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &orderpb.ListShipmentsResponse{}
+	for i := range shipments {
+		res.Shipments = append(res.Shipments, mapShipmentToProto(&shipments[i]))
+	}
+	return res, nil
+}
+
+func (h *OrderHandler) SetShipmentTracking(ctx context.Context, req *orderpb.SetShipmentTrackingRequest) (*orderpb.ShipmentResponse, error) {
+	return shipmentResponse(h.shipmentUC.SetShipmentTracking(ctx, req.GetId(), req.GetCarrier(), req.GetTrackingNumber()))
+}
+
+func (h *OrderHandler) UpdateShipmentStatus(ctx context.Context, req *orderpb.UpdateShipmentStatusRequest) (*orderpb.ShipmentResponse, error) {
+	return shipmentResponse(h.shipmentUC.UpdateShipmentStatus(ctx, req.GetId(), req.GetStatus()))
+}
+
+func shipmentResponse(shipment *domain.Shipment, err error) (*orderpb.ShipmentResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &orderpb.ShipmentResponse{Shipment: mapShipmentToProto(shipment)}, nil
+}
+
+func mapShipmentToProto(s *domain.Shipment) *orderpb.Shipment {
+	items := make([]*orderpb.CreateOrderItem, len(s.Items))
+	for i, item := range s.Items {
+		items[i] = &orderpb.CreateOrderItem{
+			ProductId: item.ProductID.Hex(),
+			Quantity:  int32(item.Quantity),
+		}
+	}
+
+	history := make([]*orderpb.ShipmentTransition, len(s.StatusHistory))
+	for i, t := range s.StatusHistory {
+		history[i] = &orderpb.ShipmentTransition{
+			From:  mapShipmentStatusToProto(t.From),
+			To:    mapShipmentStatusToProto(t.To),
+			Actor: t.Actor,
+			At:    timestamppb.New(t.At),
+		}
+	}
+
+	return &orderpb.Shipment{
+		Id:             s.ID.Hex(),
+		OrderId:        s.OrderID.Hex(),
+		UserId:         s.UserID.Hex(),
+		Items:          items,
+		Address:        mapAddressToProto(s.Address),
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Status:         mapShipmentStatusToProto(s.Status),
+		CreatedAt:      timestamppb.New(s.CreatedAt),
+		UpdatedAt:      timestamppb.New(s.UpdatedAt),
+		StatusHistory:  history,
+	}
+}
+
+func mapShipmentStatusToProto(status domain.ShipmentStatus) orderpb.ShipmentStatus {
+	switch status {
+	case domain.ShipmentStatusShipped:
+		return orderpb.ShipmentStatus_SHIPMENT_SHIPPED
+	case domain.ShipmentStatusDelivered:
+		return orderpb.ShipmentStatus_SHIPMENT_DELIVERED
+	case domain.ShipmentStatusCancelled:
+		return orderpb.ShipmentStatus_SHIPMENT_CANCELLED
+	default:
+		return orderpb.ShipmentStatus_SHIPMENT_PENDING
+	}
+}
@@ -0,0 +1,160 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/contracts/events"
+	inventoryv1 "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
+	"github.com/mephirious/advanced-programming-2/contracts/streams"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/usecase"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const inventoryConsumer = "order-products"
+
+type Config struct {
+	MaxDeliver int
+	AckWait    time.Duration
+}
+
+// InventoryConsumer keeps the product projection up to date from the
+// inventory stream with a durable pull consumer. Messages are acknowledged
+// after they were applied and redelivered with backoff when that fails.
+// Messages that cannot be applied are written to the dead letters and
+// terminated; a resync repairs what they would have changed.
+type InventoryConsumer struct {
+	products    usecase.ProductUseCase
+	deadLetters repository.DeadLetterRepository
+	js          jetstream.JetStream
+	cfg         Config
+	cc          jetstream.ConsumeContext
+}
+
+func NewInventoryConsumer(products usecase.ProductUseCase, deadLetters repository.DeadLetterRepository, js jetstream.JetStream, cfg Config) *InventoryConsumer {
+	return &InventoryConsumer{
+		products:    products,
+		deadLetters: deadLetters,
+		js:          js,
+		cfg:         cfg,
+	}
+}
+
+func (c *InventoryConsumer) Start(ctx context.Context) error {
+	consumer, err := c.js.CreateOrUpdateConsumer(ctx, streams.Inventory.Name, jetstream.ConsumerConfig{
+		Durable:       inventoryConsumer,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       c.cfg.AckWait,
+		MaxDeliver:    c.cfg.MaxDeliver,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consumer %s: %w", inventoryConsumer, err)
+	}
+
+	c.cc, err = consumer.Consume(c.handleMessage)
+	if err != nil {
+		return fmt.Errorf("failed to consume %s: %w", inventoryConsumer, err)
+	}
+	log.Printf("Consuming stream %s with durable consumer %s", streams.Inventory.Name, inventoryConsumer)
+	return nil
+}
+
+func (c *InventoryConsumer) Stop() {
+	if c.cc != nil {
+		c.cc.Stop()
+	}
+}
+
+func (c *InventoryConsumer) handleMessage(msg jetstream.Msg) {
+	err := c.process(context.Background(), streams.HeaderMap(msg.Headers()), msg.Data())
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			log.Printf("Failed to ack message on %s: %v", msg.Subject(), err)
+		}
+		return
+	}
+
+	var delivered uint64 = 1
+	meta, metaErr := msg.Metadata()
+	if metaErr == nil {
+		delivered = meta.NumDelivered
+	}
+
+	if errors.Is(err, streams.ErrInvalidEvent) || (c.cfg.MaxDeliver > 0 && delivered >= uint64(c.cfg.MaxDeliver)) {
+		log.Printf("Dead-lettering message on %s after %d deliveries: %v", msg.Subject(), delivered, err)
+		if dlqErr := c.deadLetter(msg, meta, delivered, err); dlqErr != nil {
+			// Without a dead letter the message must not be dropped, so it is
+			// left to JetStream to redeliver.
+			log.Printf("Failed to dead-letter message on %s: %v", msg.Subject(), dlqErr)
+			if err := msg.NakWithDelay(streams.RedeliveryDelay(delivered)); err != nil {
+				log.Printf("Failed to nak message on %s: %v", msg.Subject(), err)
+			}
+			return
+		}
+		if err := msg.Term(); err != nil {
+			log.Printf("Failed to terminate message on %s: %v", msg.Subject(), err)
+		}
+		return
+	}
+
+	log.Printf("Failed to process message on %s (delivery %d of %d): %v", msg.Subject(), delivered, c.cfg.MaxDeliver, err)
+	if err := msg.NakWithDelay(streams.RedeliveryDelay(delivered)); err != nil {
+		log.Printf("Failed to nak message on %s: %v", msg.Subject(), err)
+	}
+}
+
+func (c *InventoryConsumer) deadLetter(msg jetstream.Msg, meta *jetstream.MsgMetadata, delivered uint64, cause error) error {
+	deadLetter := &domain.DeadLetter{
+		Consumer: inventoryConsumer,
+		Subject:  msg.Subject(),
+		Headers:  streams.HeaderMap(msg.Headers()),
+		Payload:  msg.Data(),
+		Error:    cause.Error(),
+		Attempts: int(delivered),
+		FailedAt: time.Now(),
+	}
+	if meta != nil {
+		deadLetter.Stream = meta.Stream
+		deadLetter.Sequence = meta.Sequence.Stream
+	}
+	return c.deadLetters.SaveDeadLetter(context.Background(), deadLetter)
+}
+
+// process applies an inventory event. Messages published before the envelope
+// was introduced have no headers and are decoded as the first schema version.
+func (c *InventoryConsumer) process(ctx context.Context, headers map[string]string, data []byte) error {
+	envelope, err := events.ParseEnvelope(headers)
+	if err != nil && !errors.Is(err, events.ErrMissingEnvelope) {
+		return fmt.Errorf("%w: %v", streams.ErrInvalidEvent, err)
+	}
+	if err == nil && envelope.ContentType != events.ContentTypeProtobuf {
+		return fmt.Errorf("%w: unsupported content type %q of %s event %s", streams.ErrInvalidEvent, envelope.ContentType, envelope.Type, envelope.ID)
+	}
+
+	event, err := events.DecodeInventoryEvent(envelope.SchemaVersion, data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", streams.ErrInvalidEvent, err)
+	}
+	id, err := primitive.ObjectIDFromHex(event.Id)
+	if err != nil {
+		return fmt.Errorf("%w: invalid product ID %q", streams.ErrInvalidEvent, event.Id)
+	}
+
+	return c.products.ApplyEvent(ctx, &domain.Product{
+		ID:        id,
+		Name:      event.Name,
+		Price:     event.Price,
+		Stock:     event.Stock,
+		Deleted:   event.EventType == inventoryv1.InventoryEventType_DELETED,
+		Sequence:  event.Sequence,
+		UpdatedAt: event.UpdatedAt.AsTime(),
+		SyncedAt:  time.Now(),
+	})
+}
@@ -1,8 +1,8 @@
 package dto
 
 import (
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
-	pb "github.com/mephirious/advanced-programming-2/order-service/proto/events"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -10,21 +10,59 @@ func ToOrderEvent(order *domain.Order, eventType pb.OrderEventType) *pb.OrderEve
 	orderItems := make([]*pb.OrderItem, len(order.Items))
 	for i, item := range order.Items {
 		orderItems[i] = &pb.OrderItem{
+			ProductId:        item.ProductID.Hex(),
+			Quantity:         int32(item.Quantity),
+			Price:            item.Price,
+			RefundedQuantity: int32(item.RefundedQuantity),
+		}
+	}
+
+	return &pb.OrderEvent{
+		Id:            order.ID.Hex(),
+		UserId:        order.UserID.Hex(),
+		Items:         orderItems,
+		Total:         order.Total,
+		Status:        domainStatusToProtoStatus(order.Status),
+		CreatedAt:     timestamppb.New(order.CreatedAt),
+		UpdatedAt:     timestamppb.New(order.UpdatedAt),
+		EventType:     eventType,
+		CancelReason:  order.CancelReason,
+		RefundedTotal: order.RefundedTotal,
+	}
+}
+
+func ToOrderReturn(ret *domain.Return) *pb.OrderReturn {
+	items := make([]*pb.OrderItem, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = &pb.OrderItem{
 			ProductId: item.ProductID.Hex(),
 			Quantity:  int32(item.Quantity),
 			Price:     item.Price,
 		}
 	}
 
-	return &pb.OrderEvent{
-		Id:        order.ID.Hex(),
-		UserId:    order.UserID.Hex(),
-		Items:     orderItems,
-		Total:     order.Total,
-		Status:    domainStatusToProtoStatus(order.Status),
-		CreatedAt: timestamppb.New(order.CreatedAt),
-		UpdatedAt: timestamppb.New(order.UpdatedAt),
-		EventType: eventType,
+	return &pb.OrderReturn{
+		Id:        ret.ID.Hex(),
+		Items:     items,
+		Amount:    ret.Amount,
+		Status:    domainReturnStatusToProto(ret.Status),
+		Reason:    ret.Reason,
+		Restocked: ret.Restocked,
+	}
+}
+
+func domainReturnStatusToProto(status domain.ReturnStatus) pb.ReturnStatus {
+	switch status {
+	case domain.ReturnStatusApproved:
+		return pb.ReturnStatus_R_APPROVED
+	case domain.ReturnStatusRejected:
+		return pb.ReturnStatus_R_REJECTED
+	case domain.ReturnStatusReceived:
+		return pb.ReturnStatus_R_RECEIVED
+	case domain.ReturnStatusRefunded:
+		return pb.ReturnStatus_R_REFUNDED
+	default:
+		return pb.ReturnStatus_R_REQUESTED
 	}
 }
 
@@ -36,6 +74,16 @@ func domainStatusToProtoStatus(status domain.OrderStatus) pb.OrderStatus {
 		return pb.OrderStatus_S_COMPLETED
 	case domain.OrderStatusCancelled:
 		return pb.OrderStatus_S_CANCELLED
+	case domain.OrderStatusPaid:
+		return pb.OrderStatus_S_PAID
+	case domain.OrderStatusProcessing:
+		return pb.OrderStatus_S_PROCESSING
+	case domain.OrderStatusShipped:
+		return pb.OrderStatus_S_SHIPPED
+	case domain.OrderStatusDelivered:
+		return pb.OrderStatus_S_DELIVERED
+	case domain.OrderStatusRefunded:
+		return pb.OrderStatus_S_REFUNDED
 	default:
 		return pb.OrderStatus_S_PENDING
 	}
@@ -6,45 +6,94 @@ import (
 	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/mephirious/advanced-programming-2/order-service/internal/adapter/nats/dto"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
-	"github.com/mephirious/advanced-programming-2/order-service/pkg/nats"
+	"github.com/mephirious/advanced-programming-2/order-service/pkg/auth"
 
-	pb "github.com/mephirious/advanced-programming-2/order-service/proto/events"
+	"github.com/mephirious/advanced-programming-2/contracts/events"
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
 )
 
 const PushTimeout = time.Second * 30
 
+// Outbox stores events that are published later by an outbox relay and
+// numbers the events of every aggregate.
+type Outbox interface {
+	AddMessage(ctx context.Context, id primitive.ObjectID, subject string, headers map[string]string, payload []byte) error
+	NextSequence(ctx context.Context, aggregateID string) (int64, error)
+}
+
+// OrderEventProducer writes order events to the outbox. Push must be called
+// with the context of the transaction that changes the order.
 type OrderEventProducer struct {
-	natsClient *nats.Client
-	subject    string
+	outbox  Outbox
+	subject string
 }
 
-func NewOrderEventProducer(natsClient *nats.Client, subject string) *OrderEventProducer {
+func NewOrderEventProducer(outbox Outbox, subject string) *OrderEventProducer {
 	return &OrderEventProducer{
-		natsClient: natsClient,
-		subject:    subject,
+		outbox:  outbox,
+		subject: subject,
 	}
 }
 
 func (p *OrderEventProducer) Push(ctx context.Context, event *domain.Order, eventType pb.OrderEventType) error {
+	return p.push(ctx, event, nil, eventType)
+}
+
+// PushReturn queues a RETURN_* event about ret, carrying the state of its order.
+func (p *OrderEventProducer) PushReturn(ctx context.Context, order *domain.Order, ret *domain.Return, eventType pb.OrderEventType) error {
+	return p.push(ctx, order, ret, eventType)
+}
+
+func (p *OrderEventProducer) push(ctx context.Context, event *domain.Order, ret *domain.Return, eventType pb.OrderEventType) error {
 	ctx, cancel := context.WithTimeout(ctx, PushTimeout)
 	defer cancel()
 
+	sequence, err := p.outbox.NextSequence(ctx, event.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("p.outbox.NextSequence: %w", err)
+	}
+	eventID := primitive.NewObjectID()
+
 	pbEvent := dto.ToOrderEvent(event, eventType)
+	pbEvent.EventId = eventID.Hex()
+	pbEvent.Sequence = sequence
+	if ret != nil {
+		pbEvent.OrderReturn = dto.ToOrderReturn(ret)
+	}
 	data, err := proto.Marshal(pbEvent)
 	if err != nil {
 		return fmt.Errorf("proto.Marshal: %w", err)
 	}
 
-	log.Printf("Publishing to subject: %s, event: %+v", p.subject, pbEvent)
-	err = p.natsClient.Conn.Publish(p.subject, data)
-	if err != nil {
-		return fmt.Errorf("p.natsClient.Conn.Publish: %w", err)
+	envelope := events.Envelope{
+		ID:            eventID.Hex(),
+		Type:          events.OrderTypes[eventType],
+		Source:        events.SourceOrderService,
+		SchemaVersion: events.CurrentSchemaVersion,
+		Time:          time.Now(),
+		TraceID:       traceID(ctx, eventID),
+		ContentType:   events.ContentTypeProtobuf,
+	}
+
+	log.Printf("Queueing for subject: %s, event: %+v", p.subject, pbEvent)
+	if err := p.outbox.AddMessage(ctx, eventID, p.subject, envelope.Headers(), data); err != nil {
+		return fmt.Errorf("p.outbox.AddMessage: %w", err)
 	}
-	log.Printf("Order event pushed to %s: %+v [%s]", p.subject, event, eventType)
+	log.Printf("Order event queued for %s: %+v [%s]", p.subject, event, eventType)
 
 	return nil
 }
+
+// traceID returns the ID of the request that caused the event. Events raised
+// outside of a request are traced by their own ID.
+func traceID(ctx context.Context, eventID primitive.ObjectID) string {
+	if requestID, ok := auth.RequestIDFromContext(ctx); ok {
+		return requestID
+	}
+	return eventID.Hex()
+}
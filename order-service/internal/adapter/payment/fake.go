@@ -0,0 +1,214 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+)
+
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeDecline Outcome = "decline"
+	OutcomeTimeout Outcome = "timeout"
+)
+
+func ParseOutcome(s string) (Outcome, error) {
+	switch o := Outcome(s); o {
+	case OutcomeSuccess, OutcomeDecline, OutcomeTimeout:
+		return o, nil
+	default:
+		return "", fmt.Errorf("unknown payment outcome %q", s)
+	}
+}
+
+// FakeConfig controls the results of the fake provider. Authorizations above
+// DeclineAbove are declined regardless of Outcome; zero disables the limit.
+type FakeConfig struct {
+	Outcome      Outcome
+	DeclineAbove float64
+	Latency      time.Duration
+}
+
+type authorization struct {
+	amount   float64
+	captured float64
+	refunded float64
+	voided   bool
+	refunds  map[string]float64
+}
+
+// PaymentStore finds the payment record of an authorization.
+type PaymentStore interface {
+	GetPaymentByProviderReference(ctx context.Context, reference string) (*domain.Payment, error)
+}
+
+// FakeProvider is an in-process payment provider for local development and
+// tests. Its results depend only on the configuration and the requests, so
+// payment flows can be reproduced without a real payment service. It keeps
+// its authorizations in memory and rebuilds the ones it does not know, after
+// a restart, from the payment records.
+type FakeProvider struct {
+	cfg            FakeConfig
+	payments       PaymentStore
+	mu             sync.Mutex
+	authorizations map[string]*authorization
+}
+
+func NewFakeProvider(cfg FakeConfig, payments PaymentStore) *FakeProvider {
+	if cfg.Outcome == "" {
+		cfg.Outcome = OutcomeSuccess
+	}
+	return &FakeProvider{
+		cfg:            cfg,
+		payments:       payments,
+		authorizations: make(map[string]*authorization),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return "fake"
+}
+
+// Authorize authorizes amount under key. A key that was already authorized
+// returns the same reference.
+func (p *FakeProvider) Authorize(ctx context.Context, key string, amount float64) (string, error) {
+	if err := p.respond(ctx); err != nil {
+		return "", err
+	}
+	if p.cfg.DeclineAbove > 0 && amount > p.cfg.DeclineAbove {
+		return "", fmt.Errorf("%w: amount %.2f exceeds limit", domain.ErrPaymentDeclined, amount)
+	}
+
+	reference := "fake_" + key
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.authorizations[reference]; !ok {
+		p.authorizations[reference] = &authorization{amount: amount}
+	}
+	return reference, nil
+}
+
+func (p *FakeProvider) Capture(ctx context.Context, reference string, amount float64) error {
+	if err := p.respond(ctx); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	auth, err := p.lookup(ctx, reference)
+	if err != nil {
+		return err
+	}
+	if auth.voided {
+		return fmt.Errorf("%w: authorization %s is voided", domain.ErrFailedPrecondition, reference)
+	}
+	if auth.captured+amount > auth.amount {
+		return fmt.Errorf("%w: capture exceeds authorized amount", domain.ErrFailedPrecondition)
+	}
+	auth.captured += amount
+	return nil
+}
+
+func (p *FakeProvider) Void(ctx context.Context, reference string) error {
+	if err := p.respond(ctx); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	auth, err := p.lookup(ctx, reference)
+	if err != nil {
+		return err
+	}
+	if auth.captured > 0 {
+		return fmt.Errorf("%w: authorization %s is already captured", domain.ErrFailedPrecondition, reference)
+	}
+	auth.voided = true
+	return nil
+}
+
+// Refund refunds amount of a captured authorization. A refund reference that
+// was already refunded is accepted without refunding again.
+func (p *FakeProvider) Refund(ctx context.Context, reference, refundReference string, amount float64) error {
+	if err := p.respond(ctx); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	auth, err := p.lookup(ctx, reference)
+	if err != nil {
+		return err
+	}
+	if _, ok := auth.refunds[refundReference]; ok {
+		return nil
+	}
+	if auth.refunded+amount > auth.captured+domain.CentTolerance {
+		return fmt.Errorf("%w: refund exceeds captured amount", domain.ErrFailedPrecondition)
+	}
+	if auth.refunds == nil {
+		auth.refunds = make(map[string]float64)
+	}
+	auth.refunds[refundReference] = amount
+	auth.refunded += amount
+	return nil
+}
+
+// respond waits for the configured latency and applies the configured outcome.
+// A timeout blocks until the context is done.
+func (p *FakeProvider) respond(ctx context.Context) error {
+	if p.cfg.Outcome == OutcomeTimeout {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	if p.cfg.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.cfg.Latency):
+		}
+	}
+
+	if p.cfg.Outcome == OutcomeDecline {
+		return domain.ErrPaymentDeclined
+	}
+	return nil
+}
+
+// lookup returns the authorization of reference, rebuilding it from its
+// payment record when it is not known. Only completed refunds are rebuilt, so
+// a refund that was pending when the provider restarted is made again.
+func (p *FakeProvider) lookup(ctx context.Context, reference string) (*authorization, error) {
+	if auth, ok := p.authorizations[reference]; ok {
+		return auth, nil
+	}
+
+	payment, err := p.payments.GetPaymentByProviderReference(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil {
+		return nil, fmt.Errorf("%w: unknown authorization %s", domain.ErrNotFound, reference)
+	}
+
+	auth := &authorization{
+		amount:   payment.Amount,
+		captured: payment.CapturedAmount,
+		voided:   payment.Status == domain.PaymentStatusVoided,
+		refunds:  make(map[string]float64),
+	}
+	for _, refund := range payment.Refunds {
+		if !refund.Pending {
+			auth.refunds[refund.Reference] = refund.Amount
+			auth.refunded += refund.Amount
+		}
+	}
+	p.authorizations[reference] = auth
+	return auth, nil
+}
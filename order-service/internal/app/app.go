@@ -8,9 +8,14 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/mephirious/advanced-programming-2/contracts/outbox"
+	"github.com/mephirious/advanced-programming-2/contracts/streams"
 	"github.com/mephirious/advanced-programming-2/order-service/config"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/adapter/grpc/client"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/adapter/grpc/service"
 	producer "github.com/mephirious/advanced-programming-2/order-service/internal/adapter/nats"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/adapter/nats/consumer"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/adapter/payment"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/usecase"
 	"github.com/mephirious/advanced-programming-2/order-service/pkg/mongo"
@@ -20,9 +25,12 @@ import (
 const serviceName = "order-service"
 
 type App struct {
-	grpcServer *service.GRPCServer
-	natsClient *nats.Client
-	orderProd  *producer.OrderEventProducer
+	grpcServer      *service.GRPCServer
+	checkout        usecase.CheckoutSaga
+	natsClient      *nats.Client
+	orderProd       *producer.OrderEventProducer
+	inventoryClient *client.InventoryClient
+	productConsumer *consumer.InventoryConsumer
 }
 
 func New(ctx context.Context, cfg *config.Config) (*App, error) {
@@ -37,25 +45,107 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("nats.NewClient: %w", err)
 	}
-	orderProducer := producer.NewOrderEventProducer(natsClient, "order.events")
+	js, err := natsClient.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	if err := streams.Ensure(ctx, js, streams.Orders, streams.Inventory); err != nil {
+		return nil, err
+	}
+	outboxRepo := outbox.NewRepository(mongoDB.Connection)
+	if err := outboxRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("outbox indexes: %w", err)
+	}
+	orderProducer := producer.NewOrderEventProducer(outboxRepo, "order.events")
+	outbox.NewRelay(js, outboxRepo, cfg.Outbox.RelayInterval).Start(ctx)
+
+	inventoryClient, err := client.NewInventoryClient(cfg.Inventory.Addr, cfg.Inventory.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("inventory client: %w", err)
+	}
+
+	productUC := usecase.NewProductUseCase(repository.NewProductRepository(mongoDB.Connection), inventoryClient)
+	productConsumer := consumer.NewInventoryConsumer(productUC, repository.NewDeadLetterRepository(mongoDB.Connection), js, consumer.Config{
+		MaxDeliver: cfg.NATS.MaxDeliver,
+		AckWait:    cfg.NATS.AckWait,
+	})
+	if err := productConsumer.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	var catalog usecase.ProductCatalog = inventoryClient
+	if cfg.Catalog.Source == "projection" {
+		catalog = productUC
+	}
+	log.Printf("pricing orders with the %s product catalog", cfg.Catalog.Source)
 
 	orderRepo := repository.NewOrderRepository(mongoDB.Connection)
-	orderUC := usecase.NewOrderUseCase(orderRepo, *orderProducer)
+	if err := orderRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("order indexes: %w", err)
+	}
+	sagaRepo := repository.NewSagaRepository(mongoDB.Connection)
+	if err := sagaRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("saga indexes: %w", err)
+	}
 
-	grpcServer, err := service.NewGRPCServer(*cfg, orderUC)
+	paymentRepo := repository.NewPaymentRepository(mongoDB.Connection)
+	if err := paymentRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("payment indexes: %w", err)
+	}
+
+	outcome, err := payment.ParseOutcome(cfg.Payment.FakeOutcome)
+	if err != nil {
+		return nil, err
+	}
+	paymentProvider := payment.NewFakeProvider(payment.FakeConfig{
+		Outcome:      outcome,
+		DeclineAbove: cfg.Payment.FakeDeclineAbove,
+		Latency:      cfg.Payment.FakeLatency,
+	}, paymentRepo)
+	paymentUC := usecase.NewPaymentUseCase(paymentRepo, orderRepo, paymentProvider, cfg.Payment.Timeout)
+
+	shipmentRepo := repository.NewShipmentRepository(mongoDB.Connection)
+	if err := shipmentRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("shipment indexes: %w", err)
+	}
+
+	checkout := usecase.NewCheckoutSaga(sagaRepo, orderRepo, *orderProducer, inventoryClient, paymentUC, mongoDB)
+	orderUC := usecase.NewOrderUseCase(orderRepo, sagaRepo, shipmentRepo, *orderProducer, inventoryClient, catalog, paymentUC, checkout, mongoDB)
+	usecase.StartOrderExpirer(ctx, orderUC, cfg.Order.ExpiryInterval)
+
+	cartRepo := repository.NewCartRepository(mongoDB.Connection)
+	if err := cartRepo.EnsureIndexes(ctx, cfg.Cart.TTL); err != nil {
+		return nil, fmt.Errorf("cart indexes: %w", err)
+	}
+	cartUC := usecase.NewCartUseCase(cartRepo, catalog, orderUC, cfg.Cart.TTL)
+
+	returnRepo := repository.NewReturnRepository(mongoDB.Connection)
+	if err := returnRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("return indexes: %w", err)
+	}
+	returnUC := usecase.NewReturnUseCase(returnRepo, orderRepo, *orderProducer, inventoryClient, paymentUC, mongoDB)
+
+	shipmentUC := usecase.NewShipmentUseCase(shipmentRepo, orderRepo, *orderProducer, mongoDB)
+
+	grpcServer, err := service.NewGRPCServer(*cfg, orderUC, paymentUC, cartUC, productUC, returnUC, shipmentUC)
 	if err != nil {
 		return nil, err
 	}
 
 	return &App{
-		grpcServer: grpcServer,
-		natsClient: natsClient,
-		orderProd:  orderProducer,
+		grpcServer:      grpcServer,
+		checkout:        checkout,
+		natsClient:      natsClient,
+		orderProd:       orderProducer,
+		inventoryClient: inventoryClient,
+		productConsumer: productConsumer,
 	}, nil
 }
 
 func (a *App) Close() {
 	a.grpcServer.Stop()
+	a.productConsumer.Stop()
+	a.inventoryClient.Close()
 }
 
 func (a *App) Run() error {
@@ -65,6 +155,12 @@ func (a *App) Run() error {
 		errCh <- a.grpcServer.Run()
 	}()
 
+	go func() {
+		if err := a.checkout.Resume(context.Background()); err != nil {
+			log.Printf("failed to resume checkouts: %v", err)
+		}
+	}()
+
 	log.Printf("%s started", serviceName)
 
 	shutdownCh := make(chan os.Signal, 1)
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Address struct {
+	Name       string `json:"name,omitempty" bson:"name,omitempty"`
+	Line1      string `json:"line1" bson:"line1"`
+	Line2      string `json:"line2,omitempty" bson:"line2,omitempty"`
+	City       string `json:"city" bson:"city"`
+	Region     string `json:"region,omitempty" bson:"region,omitempty"`
+	PostalCode string `json:"postal_code" bson:"postal_code"`
+	Country    string `json:"country" bson:"country"`
+}
+
+// Validate returns an invalid argument error naming the first required field
+// of a that is empty. kind names the address in the message.
+func (a *Address) Validate(kind string) error {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"line1", a.Line1},
+		{"city", a.City},
+		{"postal_code", a.PostalCode},
+		{"country", a.Country},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			return fmt.Errorf("%w: %s address %s is required", ErrInvalidArgument, kind, r.field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrCartItemNotFound = fmt.Errorf("cart item %w", ErrNotFound)
+
+type CartItem struct {
+	ProductID primitive.ObjectID `json:"product_id" bson:"product_id"`
+	Quantity  int                `json:"quantity" bson:"quantity"`
+	AddedAt   time.Time          `json:"added_at" bson:"added_at"`
+}
+
+// Cart is the server side shopping cart of a user. Carts are removed after a
+// period of inactivity measured from UpdatedAt.
+type Cart struct {
+	UserID    primitive.ObjectID `json:"user_id" bson:"_id"`
+	Items     []CartItem         `json:"items" bson:"items"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// CartLine is a cart item priced with the current inventory data. Warning is
+// set when the line cannot be ordered as it is.
+type CartLine struct {
+	ProductID primitive.ObjectID `json:"product_id"`
+	Name      string             `json:"name"`
+	Quantity  int                `json:"quantity"`
+	UnitPrice float64            `json:"unit_price"`
+	LineTotal float64            `json:"line_total"`
+	Available int32              `json:"available"`
+	Warning   string             `json:"warning,omitempty"`
+}
+
+type PricedCart struct {
+	UserID    primitive.ObjectID `json:"user_id"`
+	Lines     []CartLine         `json:"lines"`
+	Total     float64            `json:"total"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
+}
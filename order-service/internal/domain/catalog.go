@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CatalogProduct is the product data orders and carts are priced with. The
+// inventory service owns the products; the order service only reads them.
+type CatalogProduct struct {
+	ID      primitive.ObjectID `json:"id"`
+	Name    string             `json:"name"`
+	Price   float64            `json:"price"`
+	Stock   int32              `json:"stock"`
+	Deleted bool               `json:"deleted"`
+}
+
+// Product is the local projection of an inventory product, built from the
+// inventory events. Stock is only a hint, since stock is reserved in the
+// inventory service and reservations do not raise events. Deleted products are
+// kept so that orders for them are rejected.
+type Product struct {
+	ID      primitive.ObjectID `json:"id" bson:"_id"`
+	Name    string             `json:"name" bson:"name"`
+	Price   float64            `json:"price" bson:"price"`
+	Stock   int32              `json:"stock" bson:"stock"`
+	Deleted bool               `json:"deleted" bson:"deleted"`
+	// Sequence is the sequence of the last applied inventory event.
+	Sequence int64 `json:"sequence" bson:"sequence"`
+	// UpdatedAt is the time the product was last changed in the inventory
+	// service, SyncedAt the time the projection last took it over.
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+	SyncedAt  time.Time `json:"synced_at" bson:"synced_at"`
+}
+
+func (p *Product) CatalogProduct() CatalogProduct {
+	return CatalogProduct{
+		ID:      p.ID,
+		Name:    p.Name,
+		Price:   p.Price,
+		Stock:   p.Stock,
+		Deleted: p.Deleted,
+	}
+}
+
+// ProductResync is the outcome of backfilling the product projection from the
+// inventory service.
+type ProductResync struct {
+	Synced  int `json:"synced"`
+	Deleted int `json:"deleted"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SagaStatus string
+
+const (
+	SagaStatusRunning      SagaStatus = "running"
+	SagaStatusCompensating SagaStatus = "compensating"
+	SagaStatusCompleted    SagaStatus = "completed"
+	SagaStatusCompensated  SagaStatus = "compensated"
+
+	// SagaStatusFailed marks a checkout whose compensation failed in a way a
+	// retry cannot fix. It is not resumed and needs an operator.
+	SagaStatusFailed SagaStatus = "failed"
+)
+
+// CheckoutStep is the last checkout step that completed successfully.
+// Compensation walks the step back as each action is undone.
+type CheckoutStep string
+
+const (
+	CheckoutStepStarted           CheckoutStep = "started"
+	CheckoutStepOrderCreated      CheckoutStep = "order_created"
+	CheckoutStepStockReserved     CheckoutStep = "stock_reserved"
+	CheckoutStepPaymentAuthorized CheckoutStep = "payment_authorized"
+	CheckoutStepConfirmed         CheckoutStep = "confirmed"
+)
+
+type CheckoutSaga struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Order           Order              `json:"order" bson:"order"`
+	Step            CheckoutStep       `json:"step" bson:"step"`
+	Status          SagaStatus         `json:"status" bson:"status"`
+	AuthorizationID string             `json:"authorization_id,omitempty" bson:"authorization_id,omitempty"`
+	Error           string             `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+}
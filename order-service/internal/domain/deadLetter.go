@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeadLetter is a stream message the order service could not process. It keeps
+// the raw headers and payload so the message can be inspected after a fix; a
+// product resync repairs what it would have changed.
+type DeadLetter struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	Consumer string             `bson:"consumer"`
+	Stream   string             `bson:"stream"`
+	Subject  string             `bson:"subject"`
+	Headers  map[string]string  `bson:"headers,omitempty"`
+	Sequence uint64             `bson:"sequence"`
+	Payload  []byte             `bson:"payload"`
+	Error    string             `bson:"error"`
+	Attempts int                `bson:"attempts"`
+	FailedAt time.Time          `bson:"failed_at"`
+}
@@ -7,8 +7,10 @@ import (
 )
 
 type OrderCreateDTO struct {
-	UserID string         `json:"user_id" binding:"required"`
-	Items  []OrderItemDTO `json:"items" binding:"required,min=1"`
+	UserID          string          `json:"user_id" binding:"required"`
+	Items           []OrderItemDTO  `json:"items" binding:"required,min=1"`
+	ShippingAddress *domain.Address `json:"shipping_address"`
+	BillingAddress  *domain.Address `json:"billing_address"`
 }
 
 type OrderItemDTO struct {
@@ -17,7 +19,7 @@ type OrderItemDTO struct {
 }
 
 type OrderUpdateDTO struct {
-	Status *string `json:"status" binding:"omitempty,oneof=pending completed cancelled"`
+	Status *string `json:"status" binding:"omitempty,oneof=pending paid processing shipped delivered cancelled refunded"`
 }
 
 type OrderFilterDTO struct {
@@ -0,0 +1,7 @@
+package dto
+
+type ReturnCreateDTO struct {
+	OrderID string
+	Items   []OrderItemDTO
+	Reason  string
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+
+type ShipmentCreateDTO struct {
+	OrderID        string
+	Items          []OrderItemDTO
+	Address        *domain.Address
+	Carrier        string
+	TrackingNumber string
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "errors"
+
+// Error kinds returned by the use cases. They are wrapped with a descriptive
+// message and translated into gRPC status codes by the transport layer.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrConflict           = errors.New("conflict")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrPermissionDenied   = errors.New("permission denied")
+)
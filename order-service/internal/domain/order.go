@@ -1,23 +1,20 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-type OrderStatus string
-
-const (
-	OrderStatusPending   OrderStatus = "pending"
-	OrderStatusCompleted OrderStatus = "completed"
-	OrderStatusCancelled OrderStatus = "cancelled"
-)
+var ErrOrderNotFound = fmt.Errorf("order %w", ErrNotFound)
 
 type OrderItem struct {
 	ProductID primitive.ObjectID `json:"product_id" bson:"product_id"`
 	Quantity  int                `json:"quantity" bson:"quantity"`
 	Price     float64            `json:"price" bson:"price"`
+	// RefundedQuantity is the number of units refunded through returns.
+	RefundedQuantity int `json:"refunded_quantity,omitempty" bson:"refunded_quantity,omitempty"`
 }
 
 type Order struct {
@@ -28,15 +25,60 @@ type Order struct {
 	Status    OrderStatus        `json:"status" bson:"status"`
 	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+
+	StatusHistory []StatusTransition `json:"status_history" bson:"status_history"`
+	ReservationID string             `json:"reservation_id,omitempty" bson:"reservation_id,omitempty"`
+	// ReservationExpiresAt is when the inventory service releases the stock
+	// reservation of a pending order. The order is cancelled with it.
+	ReservationExpiresAt *time.Time `json:"reservation_expires_at,omitempty" bson:"reservation_expires_at,omitempty"`
+	CancelReason         string     `json:"cancel_reason,omitempty" bson:"cancel_reason,omitempty"`
+	RefundedTotal        float64    `json:"refunded_total,omitempty" bson:"refunded_total,omitempty"`
+
+	ShippingAddress *Address `json:"shipping_address,omitempty" bson:"shipping_address,omitempty"`
+	BillingAddress  *Address `json:"billing_address,omitempty" bson:"billing_address,omitempty"`
+}
+
+// NetTotal is the order total less the amount refunded for returns.
+func (o *Order) NetTotal() float64 {
+	return o.Total - o.RefundedTotal
+}
+
+// Quantities sums the ordered quantities per product.
+func (o *Order) Quantities() map[primitive.ObjectID]int {
+	quantities := make(map[primitive.ObjectID]int)
+	for _, item := range o.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	return quantities
+}
+
+// ApplyRefund records the refund of returned items on the order. Refunded
+// units are spread over the lines of a product in order.
+func (o *Order) ApplyRefund(items []ReturnItem, amount float64) {
+	for _, returned := range items {
+		remaining := returned.Quantity
+		for i := range o.Items {
+			line := &o.Items[i]
+			if remaining == 0 {
+				break
+			}
+			if line.ProductID != returned.ProductID {
+				continue
+			}
+			n := min(remaining, line.Quantity-line.RefundedQuantity)
+			line.RefundedQuantity += n
+			remaining -= n
+		}
+	}
+	o.RefundedTotal += amount
 }
 
-type Product struct {
-	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Name        string             `json:"name" bson:"name"`
-	Description string             `json:"description" bson:"description"`
-	CategoryID  primitive.ObjectID `json:"category_id" bson:"category_id"`
-	Price       float64            `json:"price" bson:"price"`
-	Stock       int32              `json:"stock" bson:"stock"`
-	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+// FullyRefunded reports whether every unit of the order was refunded.
+func (o *Order) FullyRefunded() bool {
+	for _, item := range o.Items {
+		if item.RefundedQuantity < item.Quantity {
+			return false
+		}
+	}
+	return len(o.Items) > 0
 }
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending    OrderStatus = "pending"
+	OrderStatusPaid       OrderStatus = "paid"
+	OrderStatusProcessing OrderStatus = "processing"
+	OrderStatusShipped    OrderStatus = "shipped"
+	OrderStatusDelivered  OrderStatus = "delivered"
+	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusRefunded   OrderStatus = "refunded"
+
+	// OrderStatusCompleted is only found on orders created before the
+	// extended lifecycle and is treated like delivered.
+	OrderStatusCompleted OrderStatus = "completed"
+)
+
+// SystemActor is recorded as the actor of transitions that were not requested
+// by an authenticated user.
+const SystemActor = "system"
+
+// orderTransitions lists the statuses an order may move to from each status.
+// Cancelled and refunded are final.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:    {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:       {OrderStatusProcessing, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusProcessing: {OrderStatusShipped, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusShipped:    {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered:  {OrderStatusRefunded},
+	OrderStatusCompleted:  {OrderStatusRefunded},
+}
+
+type StatusTransition struct {
+	From   OrderStatus `json:"from,omitempty" bson:"from,omitempty"`
+	To     OrderStatus `json:"to" bson:"to"`
+	Actor  string      `json:"actor" bson:"actor"`
+	Reason string      `json:"reason,omitempty" bson:"reason,omitempty"`
+	At     time.Time   `json:"at" bson:"at"`
+}
+
+func ParseOrderStatus(status string) (OrderStatus, error) {
+	s := OrderStatus(strings.ToLower(status))
+	switch s {
+	case OrderStatusPending, OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped,
+		OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded:
+		return s, nil
+	default:
+		return "", fmt.Errorf("%w: invalid order status %q", ErrInvalidArgument, status)
+	}
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return slices.Contains(orderTransitions[s], next)
+}
+
+// ValidateTransition returns a failed precondition error when the lifecycle
+// does not allow moving from s to next.
+func (s OrderStatus) ValidateTransition(next OrderStatus) error {
+	if !s.CanTransitionTo(next) {
+		return fmt.Errorf("%w: cannot change order status from %s to %s", ErrFailedPrecondition, s, next)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrPaymentNotFound = fmt.Errorf("payment %w", ErrNotFound)
+	ErrPaymentDeclined = fmt.Errorf("payment declined: %w", ErrFailedPrecondition)
+)
+
+// CentTolerance absorbs float rounding when comparing amounts.
+const CentTolerance = 0.005
+
+type PaymentStatus string
+
+const (
+	PaymentStatusPending           PaymentStatus = "pending"
+	PaymentStatusAuthorized        PaymentStatus = "authorized"
+	PaymentStatusCaptured          PaymentStatus = "captured"
+	PaymentStatusVoided            PaymentStatus = "voided"
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+	PaymentStatusDeclined          PaymentStatus = "declined"
+	PaymentStatusFailed            PaymentStatus = "failed"
+)
+
+// Payment is the payment record of an order. ProviderReference identifies the
+// authorization at the payment provider. Attempts counts the authorizations
+// requested for the payment, each of which is sent under its own key.
+type Payment struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrderID           primitive.ObjectID `json:"order_id" bson:"order_id"`
+	Amount            float64            `json:"amount" bson:"amount"`
+	CapturedAmount    float64            `json:"captured_amount" bson:"captured_amount"`
+	RefundedAmount    float64            `json:"refunded_amount" bson:"refunded_amount"`
+	Status            PaymentStatus      `json:"status" bson:"status"`
+	Provider          string             `json:"provider" bson:"provider"`
+	ProviderReference string             `json:"provider_reference,omitempty" bson:"provider_reference,omitempty"`
+	Attempts          int                `json:"attempts,omitempty" bson:"attempts,omitempty"`
+	FailureReason     string             `json:"failure_reason,omitempty" bson:"failure_reason,omitempty"`
+	Refunds           []PaymentRefund    `json:"refunds,omitempty" bson:"refunds,omitempty"`
+	CreatedAt         time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// PaymentRefund is a refund of a payment. Reference names what is refunded,
+// such as an order return, so that it is refunded only once. A refund is
+// recorded as pending before it is sent to the provider, which also reserves
+// its amount, and completed once the provider accepted it.
+type PaymentRefund struct {
+	Reference string    `json:"reference" bson:"reference"`
+	Amount    float64   `json:"amount" bson:"amount"`
+	Pending   bool      `json:"pending,omitempty" bson:"pending,omitempty"`
+	At        time.Time `json:"at" bson:"at"`
+}
+
+// AuthorizationKey identifies the current authorization attempt of the
+// payment at the provider, so that a retried request does not authorize twice
+// and a new attempt after a void gets a new authorization.
+func (p *Payment) AuthorizationKey() string {
+	return fmt.Sprintf("%s-%d", p.ID.Hex(), p.Attempts)
+}
+
+// Refund returns the refund recorded for reference.
+func (p *Payment) Refund(reference string) (PaymentRefund, bool) {
+	i := slices.IndexFunc(p.Refunds, func(r PaymentRefund) bool {
+		return r.Reference == reference
+	})
+	if i < 0 {
+		return PaymentRefund{}, false
+	}
+	return p.Refunds[i], true
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrReturnNotFound = fmt.Errorf("return %w", ErrNotFound)
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusReceived  ReturnStatus = "received"
+	ReturnStatusRefunded  ReturnStatus = "refunded"
+)
+
+// returnTransitions lists the statuses a return may move to from each status.
+// Rejected and refunded are final.
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected},
+	ReturnStatusApproved:  {ReturnStatusReceived},
+	ReturnStatusReceived:  {ReturnStatusRefunded},
+}
+
+// returnableStatuses are the order statuses whose goods left the warehouse
+// and may be sent back. Earlier orders are cancelled instead.
+var returnableStatuses = []OrderStatus{OrderStatusShipped, OrderStatusDelivered, OrderStatusCompleted}
+
+// ReturnItem is a quantity of an order item sent back, priced as ordered.
+type ReturnItem struct {
+	ProductID primitive.ObjectID `json:"product_id" bson:"product_id"`
+	Quantity  int                `json:"quantity" bson:"quantity"`
+	Price     float64            `json:"price" bson:"price"`
+}
+
+type ReturnTransition struct {
+	From  ReturnStatus `json:"from,omitempty" bson:"from,omitempty"`
+	To    ReturnStatus `json:"to" bson:"to"`
+	Actor string       `json:"actor" bson:"actor"`
+	At    time.Time    `json:"at" bson:"at"`
+}
+
+// Return is a request to send back items of an order (an RMA). Amount is what
+// the customer gets refunded once the goods are received.
+type Return struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrderID      primitive.ObjectID `json:"order_id" bson:"order_id"`
+	UserID       primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Items        []ReturnItem       `json:"items" bson:"items"`
+	Amount       float64            `json:"amount" bson:"amount"`
+	Reason       string             `json:"reason,omitempty" bson:"reason,omitempty"`
+	RejectReason string             `json:"reject_reason,omitempty" bson:"reject_reason,omitempty"`
+	Status       ReturnStatus       `json:"status" bson:"status"`
+	Restocked    bool               `json:"restocked" bson:"restocked"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+
+	StatusHistory []ReturnTransition `json:"status_history" bson:"status_history"`
+}
+
+// ValidateTransition returns a failed precondition error when a return may not
+// move from s to next.
+func (s ReturnStatus) ValidateTransition(next ReturnStatus) error {
+	if !slices.Contains(returnTransitions[s], next) {
+		return fmt.Errorf("%w: cannot change return status from %s to %s", ErrFailedPrecondition, s, next)
+	}
+	return nil
+}
+
+// CanReturn reports whether items of an order in status may be returned.
+func (s OrderStatus) CanReturn() bool {
+	return slices.Contains(returnableStatuses, s)
+}
+
+// ReturnedQuantities sums the quantities per product of the returns that were
+// not rejected, which are no longer available to return.
+func ReturnedQuantities(returns []Return) map[primitive.ObjectID]int {
+	quantities := make(map[primitive.ObjectID]int)
+	for _, r := range returns {
+		if r.Status == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range r.Items {
+			quantities[item.ProductID] += item.Quantity
+		}
+	}
+	return quantities
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrShipmentNotFound = fmt.Errorf("shipment %w", ErrNotFound)
+
+type ShipmentStatus string
+
+const (
+	ShipmentStatusPending   ShipmentStatus = "pending"
+	ShipmentStatusShipped   ShipmentStatus = "shipped"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+	ShipmentStatusCancelled ShipmentStatus = "cancelled"
+)
+
+// shipmentTransitions lists the statuses a shipment may move to from each
+// status. Delivered and cancelled are final.
+var shipmentTransitions = map[ShipmentStatus][]ShipmentStatus{
+	ShipmentStatusPending: {ShipmentStatusShipped, ShipmentStatusCancelled},
+	ShipmentStatusShipped: {ShipmentStatusDelivered},
+}
+
+// shippableStatuses are the order statuses shipments may be created for.
+var shippableStatuses = []OrderStatus{OrderStatusPaid, OrderStatusProcessing}
+
+// ShipmentItem is a quantity of an order item packed into a shipment.
+type ShipmentItem struct {
+	ProductID primitive.ObjectID `json:"product_id" bson:"product_id"`
+	Quantity  int                `json:"quantity" bson:"quantity"`
+}
+
+type ShipmentTransition struct {
+	From  ShipmentStatus `json:"from,omitempty" bson:"from,omitempty"`
+	To    ShipmentStatus `json:"to" bson:"to"`
+	Actor string         `json:"actor" bson:"actor"`
+	At    time.Time      `json:"at" bson:"at"`
+}
+
+// Shipment is a parcel with some or all items of an order. Address is the
+// shipping address of the order when the shipment was created.
+type Shipment struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrderID        primitive.ObjectID `json:"order_id" bson:"order_id"`
+	UserID         primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Items          []ShipmentItem     `json:"items" bson:"items"`
+	Address        *Address           `json:"address,omitempty" bson:"address,omitempty"`
+	Carrier        string             `json:"carrier,omitempty" bson:"carrier,omitempty"`
+	TrackingNumber string             `json:"tracking_number,omitempty" bson:"tracking_number,omitempty"`
+	Status         ShipmentStatus     `json:"status" bson:"status"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+
+	StatusHistory []ShipmentTransition `json:"status_history" bson:"status_history"`
+}
+
+func ParseShipmentStatus(status string) (ShipmentStatus, error) {
+	s := ShipmentStatus(strings.ToLower(status))
+	switch s {
+	case ShipmentStatusPending, ShipmentStatusShipped, ShipmentStatusDelivered, ShipmentStatusCancelled:
+		return s, nil
+	default:
+		return "", fmt.Errorf("%w: invalid shipment status %q", ErrInvalidArgument, status)
+	}
+}
+
+// ValidateTransition returns a failed precondition error when a shipment may
+// not move from s to next.
+func (s ShipmentStatus) ValidateTransition(next ShipmentStatus) error {
+	if !slices.Contains(shipmentTransitions[s], next) {
+		return fmt.Errorf("%w: cannot change shipment status from %s to %s", ErrFailedPrecondition, s, next)
+	}
+	return nil
+}
+
+// Final reports whether a shipment in status s can no longer change.
+func (s ShipmentStatus) Final() bool {
+	return len(shipmentTransitions[s]) == 0
+}
+
+// CanShip reports whether shipments may be created for an order in status.
+func (s OrderStatus) CanShip() bool {
+	return slices.Contains(shippableStatuses, s)
+}
+
+// ShippedQuantities sums the quantities per product of the shipments that
+// were not cancelled, which are no longer available to ship.
+func ShippedQuantities(shipments []Shipment) map[primitive.ObjectID]int {
+	quantities := make(map[primitive.ObjectID]int)
+	for _, s := range shipments {
+		if s.Status == ShipmentStatusCancelled {
+			continue
+		}
+		for _, item := range s.Items {
+			quantities[item.ProductID] += item.Quantity
+		}
+	}
+	return quantities
+}
+
+// FulfilmentStatus returns the status an order reaches through its shipments:
+// shipped once every unit is in a shipment and all of them left, delivered
+// once all of them arrived. It returns false while the order is not fully
+// shipped.
+func FulfilmentStatus(order *Order, shipments []Shipment) (OrderStatus, bool) {
+	shipped := ShippedQuantities(shipments)
+	for productID, quantity := range order.Quantities() {
+		if shipped[productID] < quantity {
+			return "", false
+		}
+	}
+
+	status := OrderStatusDelivered
+	for _, s := range shipments {
+		switch s.Status {
+		case ShipmentStatusCancelled, ShipmentStatusDelivered:
+		case ShipmentStatusShipped:
+			status = OrderStatusShipped
+		default:
+			return "", false
+		}
+	}
+	return status, true
+}
+
+// FulfilmentPath returns the statuses an order in status passes through to
+// reach target along the fulfilment lifecycle, or false when target is not
+// ahead of status.
+func FulfilmentPath(status, target OrderStatus) ([]OrderStatus, bool) {
+	steps := []OrderStatus{OrderStatusPaid, OrderStatusProcessing, OrderStatusShipped, OrderStatusDelivered}
+	from := slices.Index(steps, status)
+	to := slices.Index(steps, target)
+	if from < 0 || to <= from {
+		return nil, false
+	}
+	return steps[from+1 : to+1], true
+}
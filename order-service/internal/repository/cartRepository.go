@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// codeIndexOptionsConflict is reported when an index exists with the same
+// keys but other options.
+const codeIndexOptionsConflict = 85
+
+type CartRepository interface {
+	GetCart(ctx context.Context, userID primitive.ObjectID) (*domain.Cart, error)
+	SaveCart(ctx context.Context, cart *domain.Cart) error
+	DeleteCart(ctx context.Context, userID primitive.ObjectID) error
+	EnsureIndexes(ctx context.Context, ttl time.Duration) error
+}
+
+type cartRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCartRepository(db *mongo.Database) *cartRepository {
+	return &cartRepository{
+		collection: db.Collection("carts"),
+	}
+}
+
+func (r *cartRepository) GetCart(ctx context.Context, userID primitive.ObjectID) (*domain.Cart, error) {
+	var cart domain.Cart
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&cart)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cart, nil
+}
+
+func (r *cartRepository) SaveCart(ctx context.Context, cart *domain.Cart) error {
+	now := time.Now()
+	if cart.CreatedAt.IsZero() {
+		cart.CreatedAt = now
+	}
+	cart.UpdatedAt = now
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": cart.UserID},
+		cart,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *cartRepository) DeleteCart(ctx context.Context, userID primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
+
+// EnsureIndexes creates the TTL index that removes carts which have not been
+// updated for ttl. An index created with another ttl is changed in place.
+func (r *cartRepository) EnsureIndexes(ctx context.Context, ttl time.Duration) error {
+	keys := bson.D{{Key: "updated_at", Value: 1}}
+	seconds := int32(ttl.Seconds())
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetExpireAfterSeconds(seconds),
+	})
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == codeIndexOptionsConflict {
+		return r.collection.Database().RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: r.collection.Name()},
+			{Key: "index", Value: bson.D{
+				{Key: "keyPattern", Value: keys},
+				{Key: "expireAfterSeconds", Value: seconds},
+			}},
+		}).Err()
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DeadLetterRepository interface {
+	SaveDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error
+}
+
+type deadLetterRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDeadLetterRepository(db *mongo.Database) *deadLetterRepository {
+	return &deadLetterRepository{
+		collection: db.Collection("dead_letters"),
+	}
+}
+
+func (r *deadLetterRepository) SaveDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error {
+	if deadLetter.ID.IsZero() {
+		deadLetter.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, deadLetter)
+	return err
+}
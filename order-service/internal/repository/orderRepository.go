@@ -16,95 +16,156 @@ import (
 type OrderRepository interface {
 	CreateOrder(ctx context.Context, order *domain.Order) error
 	GetOrderByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error)
-	UpdateOrderStatus(ctx context.Context, id primitive.ObjectID, status domain.OrderStatus) error
+	TransitionStatus(ctx context.Context, id primitive.ObjectID, transition domain.StatusTransition) (bool, error)
+	SetReservation(ctx context.Context, id primitive.ObjectID, reservationID string, expiresAt time.Time) error
+	SaveRefund(ctx context.Context, order *domain.Order) error
+	BumpVersion(ctx context.Context, id primitive.ObjectID) error
 	GetOrders(ctx context.Context, filter dto.OrderFilterDTO) ([]domain.Order, error)
+	GetExpiredOrders(ctx context.Context, now time.Time, limit int64) ([]domain.Order, error)
+	EnsureIndexes(ctx context.Context) error
 }
 
 type orderRepository struct {
-	collection        *mongo.Collection
-	productCollection *mongo.Collection
+	collection *mongo.Collection
 }
 
 func NewOrderRepository(db *mongo.Database) *orderRepository {
 	return &orderRepository{
-		collection:        db.Collection("orders"),
-		productCollection: db.Collection("products"),
+		collection: db.Collection("orders"),
 	}
 }
+
+func (r *orderRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "reservation_expires_at", Value: 1}},
+		Options: options.Index().
+			SetPartialFilterExpression(bson.M{"status": domain.OrderStatusPending}),
+	})
+	return err
+}
+
 func (r *orderRepository) CreateOrder(ctx context.Context, order *domain.Order) error {
 	order.CreatedAt = time.Now()
 	order.UpdatedAt = time.Now()
 
-	productIDs := make([]primitive.ObjectID, len(order.Items))
-	for i, item := range order.Items {
-		productIDs[i] = item.ProductID
-	}
+	_, err := r.collection.InsertOne(ctx, order)
+	return err
+}
 
-	cursor, err := r.productCollection.Find(ctx, bson.M{"_id": bson.M{"$in": productIDs}})
+func (r *orderRepository) GetOrderByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
+	var order domain.Order
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&order)
 	if err != nil {
-		return fmt.Errorf("failed to find products: %w", err)
-	}
-	defer cursor.Close(ctx)
-
-	productPrices := make(map[primitive.ObjectID]float64)
-
-	for cursor.Next(ctx) {
-		var product domain.Product
-		if err := cursor.Decode(&product); err != nil {
-			return fmt.Errorf("failed to decode product: %w", err)
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
 		}
-		productPrices[product.ID] = product.Price
+		return nil, err
 	}
+	return &order, nil
+}
 
-	for i, item := range order.Items {
-		if price, found := productPrices[item.ProductID]; found {
-			order.Items[i].Price = price
-		} else {
-			return fmt.Errorf("price not found for product ID %v", item.ProductID)
-		}
+// TransitionStatus applies a status transition and appends it to the order
+// history. It reports false when the order is no longer in transition.From, so
+// concurrent updates cannot skip a lifecycle check. The reason of a
+// cancellation is also stored on the order.
+func (r *orderRepository) TransitionStatus(ctx context.Context, id primitive.ObjectID, transition domain.StatusTransition) (bool, error) {
+	set := bson.M{
+		"status":     transition.To,
+		"updated_at": transition.At,
+	}
+	if transition.To == domain.OrderStatusCancelled && transition.Reason != "" {
+		set["cancel_reason"] = transition.Reason
 	}
 
-	var total float64
-	for _, item := range order.Items {
-		total += item.Price * float64(item.Quantity)
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": transition.From},
+		bson.M{
+			"$set":  set,
+			"$push": bson.M{"status_history": transition},
+		},
+	)
+	if err != nil {
+		return false, err
 	}
-	order.Total = total
+	return result.ModifiedCount == 1, nil
+}
 
-	_, err = r.collection.InsertOne(ctx, order)
+func (r *orderRepository) SetReservation(ctx context.Context, id primitive.ObjectID, reservationID string, expiresAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"reservation_id":         reservationID,
+			"reservation_expires_at": expiresAt,
+			"updated_at":             time.Now(),
+		}},
+	)
 	return err
 }
 
-func (r *orderRepository) GetOrderByID(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
-	var order domain.Order
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&order)
+// GetExpiredOrders returns pending orders whose stock reservation expired,
+// oldest expiry first.
+func (r *orderRepository) GetExpiredOrders(ctx context.Context, now time.Time, limit int64) ([]domain.Order, error) {
+	opts := options.Find().SetLimit(limit).SetSort(bson.D{{Key: "reservation_expires_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":                 domain.OrderStatusPending,
+		"reservation_expires_at": bson.M{"$lte": now},
+	}, opts)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, nil
-		}
 		return nil, err
 	}
-	return &order, nil
+	defer cursor.Close(ctx)
+
+	var orders []domain.Order
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
 }
 
-func (r *orderRepository) UpdateOrderStatus(ctx context.Context, id primitive.ObjectID, status domain.OrderStatus) error {
+// SaveRefund stores the refunded quantities of the items and the refunded
+// total of an order.
+func (r *orderRepository) SaveRefund(ctx context.Context, order *domain.Order) error {
 	_, err := r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": id},
+		bson.M{"_id": order.ID},
 		bson.M{"$set": bson.M{
-			"status":     status,
-			"updated_at": time.Now(),
+			"items":          order.Items,
+			"refunded_total": order.RefundedTotal,
+			"updated_at":     time.Now(),
 		}},
 	)
 	return err
 }
 
+// BumpVersion increments the version of an order. Called first in a
+// transaction, it makes concurrent transactions that also bump the order
+// conflict and retry, so checks made against the other documents of the
+// order, such as its returns, run one after another.
+func (r *orderRepository) BumpVersion(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrOrderNotFound
+	}
+	return nil
+}
+
 func (r *orderRepository) GetOrders(ctx context.Context, filter dto.OrderFilterDTO) ([]domain.Order, error) {
 	query := bson.M{}
 
 	if filter.UserID != "" {
 		userID, err := primitive.ObjectIDFromHex(filter.UserID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid user ID: %w", err)
+			return nil, fmt.Errorf("%w: invalid user ID %q", domain.ErrInvalidArgument, filter.UserID)
 		}
 		query["user_id"] = userID
 	}
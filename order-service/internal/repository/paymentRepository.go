@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentRepository interface {
+	SavePayment(ctx context.Context, payment *domain.Payment) error
+	GetPaymentByID(ctx context.Context, id primitive.ObjectID) (*domain.Payment, error)
+	GetPaymentByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.Payment, error)
+	GetPaymentByProviderReference(ctx context.Context, reference string) (*domain.Payment, error)
+	ClaimRefund(ctx context.Context, id primitive.ObjectID, refund domain.PaymentRefund) (bool, error)
+	CompleteRefund(ctx context.Context, id primitive.ObjectID, reference string) error
+	ReleaseRefund(ctx context.Context, id primitive.ObjectID, refund domain.PaymentRefund) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+type paymentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPaymentRepository(db *mongo.Database) *paymentRepository {
+	return &paymentRepository{
+		collection: db.Collection("payments"),
+	}
+}
+
+func (r *paymentRepository) SavePayment(ctx context.Context, payment *domain.Payment) error {
+	now := time.Now()
+	if payment.ID.IsZero() {
+		payment.ID = primitive.NewObjectID()
+	}
+	if payment.CreatedAt.IsZero() {
+		payment.CreatedAt = now
+	}
+	payment.UpdatedAt = now
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": payment.ID},
+		payment,
+		options.Replace().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%w: order %s already has a payment", domain.ErrConflict, payment.OrderID.Hex())
+	}
+	return err
+}
+
+func (r *paymentRepository) GetPaymentByID(ctx context.Context, id primitive.ObjectID) (*domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *paymentRepository) GetPaymentByOrderID(ctx context.Context, orderID primitive.ObjectID) (*domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"order_id": orderID})
+}
+
+func (r *paymentRepository) GetPaymentByProviderReference(ctx context.Context, reference string) (*domain.Payment, error) {
+	return r.findOne(ctx, bson.M{"provider_reference": reference})
+}
+
+// ClaimRefund records a pending refund on a captured payment and adds its
+// amount to the refunded amount in one conditional update. It reports false
+// when the payment already has a refund with the same reference, is not
+// captured, or has less than the amount left to refund.
+func (r *paymentRepository) ClaimRefund(ctx context.Context, id primitive.ObjectID, refund domain.PaymentRefund) (bool, error) {
+	refund.Pending = true
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":               id,
+			"status":            bson.M{"$in": []domain.PaymentStatus{domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded}},
+			"refunds.reference": bson.M{"$ne": refund.Reference},
+			"$expr": bson.M{"$lte": bson.A{
+				bson.M{"$add": bson.A{"$refunded_amount", refund.Amount}},
+				bson.M{"$add": bson.A{"$captured_amount", domain.CentTolerance}},
+			}},
+		},
+		bson.M{
+			"$push": bson.M{"refunds": refund},
+			"$inc":  bson.M{"refunded_amount": refund.Amount},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// CompleteRefund marks the refund with reference as done and moves the
+// payment to partially refunded or refunded.
+func (r *paymentRepository) CompleteRefund(ctx context.Context, id primitive.ObjectID, reference string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "refunds.reference": reference},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"refunds": bson.M{"$map": bson.M{
+				"input": "$refunds",
+				"as":    "refund",
+				"in": bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{"$$refund.reference", reference}},
+					bson.M{"$mergeObjects": bson.A{"$$refund", bson.M{"pending": false}}},
+					"$$refund",
+				}},
+			}},
+			"status": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{
+					bson.M{"$add": bson.A{"$refunded_amount", domain.CentTolerance}},
+					"$captured_amount",
+				}},
+				domain.PaymentStatusRefunded,
+				domain.PaymentStatusPartiallyRefunded,
+			}},
+			"updated_at": time.Now(),
+		}}}},
+	)
+	return err
+}
+
+// ReleaseRefund drops a pending refund the provider rejected and gives its
+// amount back.
+func (r *paymentRepository) ReleaseRefund(ctx context.Context, id primitive.ObjectID, refund domain.PaymentRefund) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "refunds": bson.M{"$elemMatch": bson.M{"reference": refund.Reference, "pending": true}}},
+		bson.M{
+			"$pull": bson.M{"refunds": bson.M{"reference": refund.Reference}},
+			"$inc":  bson.M{"refunded_amount": -refund.Amount},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
+
+func (r *paymentRepository) findOne(ctx context.Context, filter bson.M) (*domain.Payment, error) {
+	var payment domain.Payment
+	err := r.collection.FindOne(ctx, filter).Decode(&payment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "order_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "provider_reference", Value: 1}}},
+	})
+	return err
+}
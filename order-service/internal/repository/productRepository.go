@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProductRepository stores the local product projection. It must not share the
+// products collection of the inventory service, which may live in the same
+// database.
+type ProductRepository interface {
+	// ApplyEvent writes the product state of an inventory event unless an
+	// event with the same or a later sequence was applied. Events without a
+	// sequence are ignored once an event with one was applied. It reports
+	// whether the projection changed.
+	ApplyEvent(ctx context.Context, product *domain.Product) (bool, error)
+	// SaveSnapshot writes the product state read from the inventory service
+	// unless the projection holds a state that is as recent.
+	SaveSnapshot(ctx context.Context, product *domain.Product) (bool, error)
+	// MarkDeleted flags the products as deleted that were not synced since
+	// before.
+	MarkDeleted(ctx context.Context, ids []primitive.ObjectID, before time.Time) (int64, error)
+	GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Product, error)
+	// GetLiveProductIDs returns the IDs of the products not flagged as deleted.
+	GetLiveProductIDs(ctx context.Context) ([]primitive.ObjectID, error)
+}
+
+type productRepository struct {
+	collection *mongo.Collection
+}
+
+func NewProductRepository(db *mongo.Database) *productRepository {
+	return &productRepository{
+		collection: db.Collection("product_projections"),
+	}
+}
+
+func (r *productRepository) ApplyEvent(ctx context.Context, product *domain.Product) (bool, error) {
+	filter := bson.M{"_id": product.ID}
+	// Events published before sequences were introduced are applied in the
+	// order they arrive, but only until an event with a sequence was applied:
+	// they are older than any such event.
+	if product.Sequence > 0 {
+		filter["sequence"] = bson.M{"$lt": product.Sequence}
+	} else {
+		filter["sequence"] = bson.M{"$not": bson.M{"$gt": 0}}
+	}
+	return r.upsert(ctx, filter, bson.M{"$set": bson.M{
+		"name":       product.Name,
+		"price":      product.Price,
+		"stock":      product.Stock,
+		"deleted":    product.Deleted,
+		"sequence":   product.Sequence,
+		"updated_at": product.UpdatedAt,
+		"synced_at":  product.SyncedAt,
+	}})
+}
+
+func (r *productRepository) SaveSnapshot(ctx context.Context, product *domain.Product) (bool, error) {
+	filter := bson.M{"_id": product.ID, "updated_at": bson.M{"$lt": product.UpdatedAt}}
+	return r.upsert(ctx, filter, bson.M{
+		"$set": bson.M{
+			"name":       product.Name,
+			"price":      product.Price,
+			"stock":      product.Stock,
+			"deleted":    false,
+			"updated_at": product.UpdatedAt,
+			"synced_at":  product.SyncedAt,
+		},
+		// Snapshots carry no sequence, so any event of the product applies.
+		"$setOnInsert": bson.M{"sequence": int64(0)},
+	})
+}
+
+// upsert updates the product matching filter, or inserts it when it does not
+// exist. A product that exists but does not match filter makes the insert fail
+// on its ID, which means the update is stale.
+func (r *productRepository) upsert(ctx context.Context, filter, update bson.M) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0 || result.UpsertedCount > 0, nil
+}
+
+func (r *productRepository) MarkDeleted(ctx context.Context, ids []primitive.ObjectID, before time.Time) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "synced_at": bson.M{"$lt": before}},
+		bson.M{"$set": bson.M{"deleted": true, "synced_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *productRepository) GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Product, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []domain.Product
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) GetLiveProductIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	cursor, err := r.collection.Find(
+		ctx,
+		bson.M{"deleted": false},
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []primitive.ObjectID
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, cursor.Err()
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReturnRepository interface {
+	CreateReturn(ctx context.Context, ret *domain.Return) error
+	GetReturnByID(ctx context.Context, id primitive.ObjectID) (*domain.Return, error)
+	GetReturnsByOrderID(ctx context.Context, orderID primitive.ObjectID) ([]domain.Return, error)
+	TransitionStatus(ctx context.Context, ret *domain.Return, transition domain.ReturnTransition) (bool, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type returnRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReturnRepository(db *mongo.Database) *returnRepository {
+	return &returnRepository{
+		collection: db.Collection("returns"),
+	}
+}
+
+func (r *returnRepository) CreateReturn(ctx context.Context, ret *domain.Return) error {
+	ret.CreatedAt = time.Now()
+	ret.UpdatedAt = ret.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, ret)
+	return err
+}
+
+func (r *returnRepository) GetReturnByID(ctx context.Context, id primitive.ObjectID) (*domain.Return, error) {
+	var ret domain.Return
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&ret)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (r *returnRepository) GetReturnsByOrderID(ctx context.Context, orderID primitive.ObjectID) ([]domain.Return, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"order_id": orderID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var returns []domain.Return
+	if err := cursor.All(ctx, &returns); err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
+
+// TransitionStatus moves a return to transition.To, storing the restock flag
+// and reject reason of ret with it. Like order transitions, it reports false
+// when the return is no longer in transition.From.
+func (r *returnRepository) TransitionStatus(ctx context.Context, ret *domain.Return, transition domain.ReturnTransition) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": ret.ID, "status": transition.From},
+		bson.M{
+			"$set": bson.M{
+				"status":        transition.To,
+				"restocked":     ret.Restocked,
+				"reject_reason": ret.RejectReason,
+				"updated_at":    transition.At,
+			},
+			"$push": bson.M{"status_history": transition},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *returnRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return err
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SagaRepository interface {
+	SaveSaga(ctx context.Context, saga *domain.CheckoutSaga) error
+	GetSaga(ctx context.Context, id primitive.ObjectID) (*domain.CheckoutSaga, error)
+	GetUnfinishedSagas(ctx context.Context) ([]domain.CheckoutSaga, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type sagaRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSagaRepository(db *mongo.Database) *sagaRepository {
+	return &sagaRepository{
+		collection: db.Collection("checkout_sagas"),
+	}
+}
+
+// SaveSaga upserts the whole saga document so that every step is persisted
+// before the next one starts.
+func (r *sagaRepository) SaveSaga(ctx context.Context, saga *domain.CheckoutSaga) error {
+	now := time.Now()
+	if saga.CreatedAt.IsZero() {
+		saga.CreatedAt = now
+	}
+	saga.UpdatedAt = now
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": saga.ID},
+		saga,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// GetSaga returns the checkout saga of the order with the given ID. Sagas
+// share the ID of their order.
+func (r *sagaRepository) GetSaga(ctx context.Context, id primitive.ObjectID) (*domain.CheckoutSaga, error) {
+	var saga domain.CheckoutSaga
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&saga)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &saga, nil
+}
+
+func (r *sagaRepository) GetUnfinishedSagas(ctx context.Context) ([]domain.CheckoutSaga, error) {
+	filter := bson.M{"status": bson.M{"$in": []domain.SagaStatus{
+		domain.SagaStatusRunning,
+		domain.SagaStatusCompensating,
+	}}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sagas []domain.CheckoutSaga
+	if err := cursor.All(ctx, &sagas); err != nil {
+		return nil, err
+	}
+	return sagas, nil
+}
+
+func (r *sagaRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "order._id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+	})
+	return err
+}
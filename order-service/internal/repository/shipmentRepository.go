@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ShipmentRepository interface {
+	CreateShipment(ctx context.Context, shipment *domain.Shipment) error
+	GetShipmentByID(ctx context.Context, id primitive.ObjectID) (*domain.Shipment, error)
+	GetShipmentsByOrderID(ctx context.Context, orderID primitive.ObjectID) ([]domain.Shipment, error)
+	SetTracking(ctx context.Context, id primitive.ObjectID, carrier, trackingNumber string) (bool, error)
+	TransitionStatus(ctx context.Context, id primitive.ObjectID, transition domain.ShipmentTransition) (bool, error)
+	CancelPendingShipments(ctx context.Context, orderID primitive.ObjectID, actor string) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+type shipmentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewShipmentRepository(db *mongo.Database) *shipmentRepository {
+	return &shipmentRepository{
+		collection: db.Collection("shipments"),
+	}
+}
+
+func (r *shipmentRepository) CreateShipment(ctx context.Context, shipment *domain.Shipment) error {
+	shipment.CreatedAt = time.Now()
+	shipment.UpdatedAt = shipment.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, shipment)
+	return err
+}
+
+func (r *shipmentRepository) GetShipmentByID(ctx context.Context, id primitive.ObjectID) (*domain.Shipment, error) {
+	var shipment domain.Shipment
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&shipment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+func (r *shipmentRepository) GetShipmentsByOrderID(ctx context.Context, orderID primitive.ObjectID) ([]domain.Shipment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"order_id": orderID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var shipments []domain.Shipment
+	if err := cursor.All(ctx, &shipments); err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+// SetTracking stores the carrier and tracking number of a shipment. It reports
+// false when the shipment is delivered or cancelled and can no longer change.
+func (r *shipmentRepository) SetTracking(ctx context.Context, id primitive.ObjectID, carrier, trackingNumber string) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":    id,
+			"status": bson.M{"$in": []domain.ShipmentStatus{domain.ShipmentStatusPending, domain.ShipmentStatusShipped}},
+		},
+		bson.M{"$set": bson.M{
+			"carrier":         carrier,
+			"tracking_number": trackingNumber,
+			"updated_at":      time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// TransitionStatus moves a shipment to transition.To. Like order transitions,
+// it reports false when the shipment is no longer in transition.From.
+func (r *shipmentRepository) TransitionStatus(ctx context.Context, id primitive.ObjectID, transition domain.ShipmentTransition) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": transition.From},
+		bson.M{
+			"$set": bson.M{
+				"status":     transition.To,
+				"updated_at": transition.At,
+			},
+			"$push": bson.M{"status_history": transition},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// CancelPendingShipments cancels the shipments of an order that did not leave
+// yet. It fails with a failed precondition error, and cancels nothing, when a
+// shipment of the order was already shipped or delivered.
+func (r *shipmentRepository) CancelPendingShipments(ctx context.Context, orderID primitive.ObjectID, actor string) error {
+	shipped, err := r.collection.CountDocuments(ctx, bson.M{
+		"order_id": orderID,
+		"status":   bson.M{"$in": []domain.ShipmentStatus{domain.ShipmentStatusShipped, domain.ShipmentStatusDelivered}},
+	})
+	if err != nil {
+		return err
+	}
+	if shipped > 0 {
+		return fmt.Errorf("%w: %d shipments of order %s were already shipped", domain.ErrFailedPrecondition, shipped, orderID.Hex())
+	}
+
+	transition := domain.ShipmentTransition{
+		From:  domain.ShipmentStatusPending,
+		To:    domain.ShipmentStatusCancelled,
+		Actor: actor,
+		At:    time.Now(),
+	}
+	_, err = r.collection.UpdateMany(
+		ctx,
+		bson.M{"order_id": orderID, "status": domain.ShipmentStatusPending},
+		bson.M{
+			"$set": bson.M{
+				"status":     transition.To,
+				"updated_at": transition.At,
+			},
+			"$push": bson.M{"status_history": transition},
+		},
+	)
+	return err
+}
+
+func (r *shipmentRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return err
+}
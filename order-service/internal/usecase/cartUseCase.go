@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain/dto"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CartUseCase interface {
+	GetCart(ctx context.Context, userID string) (*domain.PricedCart, error)
+	AddItem(ctx context.Context, userID, productID string, quantity int) (*domain.PricedCart, error)
+	UpdateItem(ctx context.Context, userID, productID string, quantity int) (*domain.PricedCart, error)
+	RemoveItem(ctx context.Context, userID, productID string) (*domain.PricedCart, error)
+	ClearCart(ctx context.Context, userID string) error
+	Checkout(ctx context.Context, userID string, shipping, billing *domain.Address) (*domain.Order, error)
+}
+
+type cartUseCase struct {
+	cartRepo repository.CartRepository
+	catalog  ProductCatalog
+	orderUC  OrderUseCase
+	ttl      time.Duration
+}
+
+func NewCartUseCase(cartRepo repository.CartRepository, catalog ProductCatalog, orderUC OrderUseCase, ttl time.Duration) *cartUseCase {
+	return &cartUseCase{
+		cartRepo: cartRepo,
+		catalog:  catalog,
+		orderUC:  orderUC,
+		ttl:      ttl,
+	}
+}
+
+func (uc *cartUseCase) GetCart(ctx context.Context, userID string) (*domain.PricedCart, error) {
+	cart, err := uc.loadCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.price(ctx, cart)
+}
+
+func (uc *cartUseCase) AddItem(ctx context.Context, userID, productID string, quantity int) (*domain.PricedCart, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", domain.ErrInvalidArgument)
+	}
+
+	cart, err := uc.loadCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid product ID %q", domain.ErrInvalidArgument, productID)
+	}
+	products, err := uc.catalog.GetProductsByIDs(ctx, []primitive.ObjectID{id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product, found := products[id]; !found || product.Deleted {
+		return nil, fmt.Errorf("product %s %w", productID, domain.ErrNotFound)
+	}
+
+	if i := findCartItem(cart, id); i >= 0 {
+		cart.Items[i].Quantity += quantity
+	} else {
+		cart.Items = append(cart.Items, domain.CartItem{
+			ProductID: id,
+			Quantity:  quantity,
+			AddedAt:   time.Now(),
+		})
+	}
+
+	return uc.save(ctx, cart)
+}
+
+// UpdateItem sets the quantity of a cart item. A quantity of zero removes it.
+func (uc *cartUseCase) UpdateItem(ctx context.Context, userID, productID string, quantity int) (*domain.PricedCart, error) {
+	if quantity < 0 {
+		return nil, fmt.Errorf("%w: quantity must not be negative", domain.ErrInvalidArgument)
+	}
+	if quantity == 0 {
+		return uc.RemoveItem(ctx, userID, productID)
+	}
+
+	cart, i, err := uc.loadCartItem(ctx, userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	cart.Items[i].Quantity = quantity
+
+	return uc.save(ctx, cart)
+}
+
+func (uc *cartUseCase) RemoveItem(ctx context.Context, userID, productID string) (*domain.PricedCart, error) {
+	cart, i, err := uc.loadCartItem(ctx, userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	cart.Items = slices.Delete(cart.Items, i, i+1)
+
+	return uc.save(ctx, cart)
+}
+
+func (uc *cartUseCase) ClearCart(ctx context.Context, userID string) error {
+	cart, err := uc.loadCart(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.cartRepo.DeleteCart(ctx, cart.UserID); err != nil {
+		return fmt.Errorf("failed to clear cart: %w", err)
+	}
+	return nil
+}
+
+// Checkout places an order for the cart items through the order use case and
+// empties the cart once the order is created. The addresses are passed on to
+// the order as given.
+func (uc *cartUseCase) Checkout(ctx context.Context, userID string, shipping, billing *domain.Address) (*domain.Order, error) {
+	cart, err := uc.loadCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, fmt.Errorf("%w: cart is empty", domain.ErrFailedPrecondition)
+	}
+
+	items := make([]dto.OrderItemDTO, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = dto.OrderItemDTO{
+			ProductID: item.ProductID.Hex(),
+			Quantity:  item.Quantity,
+		}
+	}
+
+	order, err := uc.orderUC.CreateOrder(ctx, dto.OrderCreateDTO{
+		UserID:          cart.UserID.Hex(),
+		Items:           items,
+		ShippingAddress: shipping,
+		BillingAddress:  billing,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.cartRepo.DeleteCart(ctx, cart.UserID); err != nil {
+		return nil, fmt.Errorf("failed to clear cart: %w", err)
+	}
+	return order, nil
+}
+
+// loadCart returns the cart of the user, or a new empty cart when the user has
+// none or it has expired but was not removed yet.
+func (uc *cartUseCase) loadCart(ctx context.Context, userID string) (*domain.Cart, error) {
+	userHex, err := resolveUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(userHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user ID %q", domain.ErrInvalidArgument, userHex)
+	}
+
+	cart, err := uc.cartRepo.GetCart(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+	if cart == nil || time.Since(cart.UpdatedAt) > uc.ttl {
+		return &domain.Cart{UserID: id}, nil
+	}
+	return cart, nil
+}
+
+func (uc *cartUseCase) loadCartItem(ctx context.Context, userID, productID string) (*domain.Cart, int, error) {
+	id, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: invalid product ID %q", domain.ErrInvalidArgument, productID)
+	}
+
+	cart, err := uc.loadCart(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	i := findCartItem(cart, id)
+	if i < 0 {
+		return nil, 0, domain.ErrCartItemNotFound
+	}
+	return cart, i, nil
+}
+
+func (uc *cartUseCase) save(ctx context.Context, cart *domain.Cart) (*domain.PricedCart, error) {
+	if err := uc.cartRepo.SaveCart(ctx, cart); err != nil {
+		return nil, fmt.Errorf("failed to save cart: %w", err)
+	}
+	return uc.price(ctx, cart)
+}
+
+// price prices every cart line with the current inventory data. Lines that
+// cannot be ordered are kept with a warning and left out of the total.
+func (uc *cartUseCase) price(ctx context.Context, cart *domain.Cart) (*domain.PricedCart, error) {
+	priced := &domain.PricedCart{
+		UserID:    cart.UserID,
+		Lines:     make([]domain.CartLine, 0, len(cart.Items)),
+		UpdatedAt: cart.UpdatedAt,
+	}
+	if !cart.UpdatedAt.IsZero() {
+		priced.ExpiresAt = cart.UpdatedAt.Add(uc.ttl)
+	}
+
+	if len(cart.Items) == 0 {
+		return priced, nil
+	}
+
+	productIDs := make([]primitive.ObjectID, len(cart.Items))
+	for i, item := range cart.Items {
+		productIDs[i] = item.ProductID
+	}
+	products, err := uc.catalog.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	for _, item := range cart.Items {
+		line := domain.CartLine{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+
+		product, found := products[item.ProductID]
+		switch {
+		case !found || product.Deleted:
+			line.Warning = "product is no longer available"
+		case product.Stock <= 0:
+			line.Name, line.UnitPrice = product.Name, product.Price
+			line.Warning = "out of stock"
+		default:
+			line.Name, line.UnitPrice = product.Name, product.Price
+			line.Available = product.Stock
+			if int(product.Stock) < item.Quantity {
+				line.Warning = fmt.Sprintf("only %d left in stock", product.Stock)
+			}
+			line.LineTotal = product.Price * float64(item.Quantity)
+			priced.Total += line.LineTotal
+		}
+
+		priced.Lines = append(priced.Lines, line)
+	}
+
+	return priced, nil
+}
+
+func findCartItem(cart *domain.Cart, productID primitive.ObjectID) int {
+	return slices.IndexFunc(cart.Items, func(item domain.CartItem) bool {
+		return item.ProductID == productID
+	})
+}
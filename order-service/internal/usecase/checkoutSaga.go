@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
+	producer "github.com/mephirious/advanced-programming-2/order-service/internal/adapter/nats"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
+)
+
+// CheckoutSaga places orders by running the checkout steps in order: create
+// the pending order, reserve stock, authorize payment and confirm. When a step
+// fails the completed steps are compensated in reverse order.
+type CheckoutSaga interface {
+	Checkout(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	Resume(ctx context.Context) error
+}
+
+// PaymentAuthorizer places and voids payment holds for orders. Authorize must
+// be idempotent per order because a resumed checkout may call it again.
+type PaymentAuthorizer interface {
+	Authorize(ctx context.Context, order *domain.Order) (string, error)
+	Void(ctx context.Context, authorizationID string) error
+}
+
+// checkoutFailedReason is recorded on orders cancelled by a failed checkout.
+const checkoutFailedReason = "checkout failed"
+
+type checkoutSaga struct {
+	sagaRepo      repository.SagaRepository
+	orderRepo     repository.OrderRepository
+	eventProducer producer.OrderEventProducer
+	inventory     Inventory
+	payments      PaymentAuthorizer
+	tx            Transactor
+}
+
+func NewCheckoutSaga(
+	sagaRepo repository.SagaRepository,
+	orderRepo repository.OrderRepository,
+	eventProducer producer.OrderEventProducer,
+	inventory Inventory,
+	payments PaymentAuthorizer,
+	tx Transactor,
+) *checkoutSaga {
+	return &checkoutSaga{
+		sagaRepo:      sagaRepo,
+		orderRepo:     orderRepo,
+		eventProducer: eventProducer,
+		inventory:     inventory,
+		payments:      payments,
+		tx:            tx,
+	}
+}
+
+func (s *checkoutSaga) Checkout(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	// The saga must run to a consistent state even if the caller goes away.
+	ctx = context.WithoutCancel(ctx)
+
+	saga := &domain.CheckoutSaga{
+		ID:     order.ID,
+		Order:  *order,
+		Step:   domain.CheckoutStepStarted,
+		Status: domain.SagaStatusRunning,
+	}
+	if err := s.sagaRepo.SaveSaga(ctx, saga); err != nil {
+		return nil, fmt.Errorf("failed to start checkout: %w", err)
+	}
+
+	if err := s.run(ctx, saga); err != nil {
+		return nil, err
+	}
+	return &saga.Order, nil
+}
+
+// Resume finishes the checkouts that were interrupted by a restart. Running
+// sagas continue from their last completed step, compensating sagas finish
+// undoing theirs.
+func (s *checkoutSaga) Resume(ctx context.Context) error {
+	sagas, err := s.sagaRepo.GetUnfinishedSagas(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get unfinished checkouts: %w", err)
+	}
+
+	for i := range sagas {
+		saga := &sagas[i]
+		log.Printf("Resuming checkout of order %s at step %s (%s)", saga.Order.ID.Hex(), saga.Step, saga.Status)
+
+		if saga.Status == domain.SagaStatusCompensating {
+			s.compensate(ctx, saga)
+			continue
+		}
+		if err := s.run(ctx, saga); err != nil {
+			log.Printf("Resumed checkout of order %s failed: %v", saga.Order.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// run executes the remaining steps and compensates on the first failure. The
+// returned error is the one of the failed step.
+func (s *checkoutSaga) run(ctx context.Context, saga *domain.CheckoutSaga) error {
+	for saga.Step != domain.CheckoutStepConfirmed {
+		var (
+			next domain.CheckoutStep
+			err  error
+		)
+		switch saga.Step {
+		case domain.CheckoutStepStarted:
+			next, err = domain.CheckoutStepOrderCreated, s.createOrder(ctx, saga)
+		case domain.CheckoutStepOrderCreated:
+			next, err = domain.CheckoutStepStockReserved, s.reserveStock(ctx, saga)
+		case domain.CheckoutStepStockReserved:
+			next, err = domain.CheckoutStepPaymentAuthorized, s.authorizePayment(ctx, saga)
+		case domain.CheckoutStepPaymentAuthorized:
+			next, err = domain.CheckoutStepConfirmed, s.confirm(ctx, saga)
+		default:
+			err = fmt.Errorf("unknown checkout step %q", saga.Step)
+		}
+		if err != nil {
+			saga.Error = err.Error()
+			s.compensate(ctx, saga)
+			return err
+		}
+
+		saga.Step = next
+		if next == domain.CheckoutStepConfirmed {
+			saga.Status = domain.SagaStatusCompleted
+		}
+		if err := s.sagaRepo.SaveSaga(ctx, saga); err != nil {
+			// The step itself succeeded, a later Resume picks the saga up again.
+			log.Printf("Failed to save checkout of order %s: %v", saga.Order.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+func (s *checkoutSaga) createOrder(ctx context.Context, saga *domain.CheckoutSaga) error {
+	existing, err := s.orderRepo.GetOrderByID(ctx, saga.Order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+	if existing != nil {
+		saga.Order = *existing
+		return nil
+	}
+
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.CreateOrder(ctx, &saga.Order); err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+		return s.eventProducer.Push(ctx, &saga.Order, pb.OrderEventType_CREATED)
+	})
+}
+
+func (s *checkoutSaga) reserveStock(ctx context.Context, saga *domain.CheckoutSaga) error {
+	reservationID, expiresAt, err := s.inventory.ReserveStock(ctx, saga.Order.ID.Hex(), saga.Order.Items)
+	if err != nil {
+		return fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	saga.Order.ReservationID = reservationID
+	saga.Order.ReservationExpiresAt = &expiresAt
+
+	if err := s.orderRepo.SetReservation(ctx, saga.Order.ID, reservationID, expiresAt); err != nil {
+		return fmt.Errorf("failed to save stock reservation: %w", err)
+	}
+	return nil
+}
+
+func (s *checkoutSaga) authorizePayment(ctx context.Context, saga *domain.CheckoutSaga) error {
+	authorizationID, err := s.payments.Authorize(ctx, &saga.Order)
+	if err != nil {
+		return fmt.Errorf("failed to authorize payment: %w", err)
+	}
+	saga.AuthorizationID = authorizationID
+	return nil
+}
+
+// confirm marks the order as paid and commits the stock reservation in the
+// same transaction, so the stock is only committed for an order that became
+// paid. Committing is idempotent, which covers a transaction that fails after
+// the commit.
+func (s *checkoutSaga) confirm(ctx context.Context, saga *domain.CheckoutSaga) error {
+	order, err := s.transition(ctx, saga, domain.OrderStatusPaid, func(ctx context.Context) error {
+		if err := s.inventory.CommitReservation(ctx, saga.Order.ReservationID); err != nil {
+			return fmt.Errorf("failed to commit stock reservation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	saga.Order = *order
+	return nil
+}
+
+// compensate undoes the completed steps in reverse order. It stops at the
+// first failure and leaves the saga compensating so that Resume retries it,
+// unless the failure is one a retry cannot fix; the saga is then marked failed
+// and left for an operator.
+func (s *checkoutSaga) compensate(ctx context.Context, saga *domain.CheckoutSaga) {
+	orderID := saga.Order.ID.Hex()
+	saga.Status = domain.SagaStatusCompensating
+	if err := s.sagaRepo.SaveSaga(ctx, saga); err != nil {
+		log.Printf("Failed to save checkout of order %s: %v", orderID, err)
+	}
+
+	for saga.Step != domain.CheckoutStepStarted {
+		var (
+			prev domain.CheckoutStep
+			err  error
+		)
+		switch saga.Step {
+		case domain.CheckoutStepPaymentAuthorized:
+			prev, err = domain.CheckoutStepStockReserved, s.payments.Void(ctx, saga.AuthorizationID)
+		case domain.CheckoutStepStockReserved:
+			prev, err = domain.CheckoutStepOrderCreated, releaseReservation(ctx, s.inventory, saga.Order.ReservationID)
+		case domain.CheckoutStepOrderCreated:
+			prev = domain.CheckoutStepStarted
+			_, err = s.transition(ctx, saga, domain.OrderStatusCancelled, nil)
+		default:
+			err = fmt.Errorf("cannot compensate checkout step %q", saga.Step)
+		}
+		if err != nil {
+			log.Printf("Failed to compensate checkout of order %s at step %s: %v", orderID, saga.Step, err)
+			if permanent(err) {
+				saga.Status = domain.SagaStatusFailed
+				saga.Error = err.Error()
+				if err := s.sagaRepo.SaveSaga(ctx, saga); err != nil {
+					log.Printf("Failed to save checkout of order %s: %v", orderID, err)
+				}
+			}
+			return
+		}
+
+		saga.Step = prev
+		if err := s.sagaRepo.SaveSaga(ctx, saga); err != nil {
+			log.Printf("Failed to save checkout of order %s: %v", orderID, err)
+		}
+	}
+
+	saga.Status = domain.SagaStatusCompensated
+	if err := s.sagaRepo.SaveSaga(ctx, saga); err != nil {
+		log.Printf("Failed to save checkout of order %s: %v", orderID, err)
+	}
+}
+
+// transition moves the pending order of the saga to status. An order that is
+// already in status is left as it is, which keeps resumed steps idempotent.
+// update, if set, runs in the same transaction once the transition was won.
+func (s *checkoutSaga) transition(
+	ctx context.Context,
+	saga *domain.CheckoutSaga,
+	status domain.OrderStatus,
+	update func(ctx context.Context) error,
+) (*domain.Order, error) {
+	transition := domain.StatusTransition{
+		From:  domain.OrderStatusPending,
+		To:    status,
+		Actor: domain.SystemActor,
+		At:    time.Now(),
+	}
+	eventType := pb.OrderEventType_UPDATED
+	if status == domain.OrderStatusCancelled {
+		transition.Reason = checkoutFailedReason
+		eventType = pb.OrderEventType_CANCELLED
+	}
+
+	var order *domain.Order
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.orderRepo.TransitionStatus(ctx, saga.Order.ID, transition)
+		if err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+
+		order, err = s.orderRepo.GetOrderByID(ctx, saga.Order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			return domain.ErrOrderNotFound
+		}
+		if !ok {
+			if order.Status != status {
+				return fmt.Errorf("%w: order %s is %s", domain.ErrConflict, order.ID.Hex(), order.Status)
+			}
+			return nil
+		}
+		if update != nil {
+			if err := update(ctx); err != nil {
+				return err
+			}
+		}
+
+		return s.eventProducer.Push(ctx, order, eventType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// releaseReservation gives back the stock of a reservation whether it is still
+// held or was already committed, for example by a confirmation whose order
+// change failed afterwards.
+func releaseReservation(ctx context.Context, inventory Inventory, reservationID string) error {
+	err := inventory.ReleaseStock(ctx, reservationID)
+	if errors.Is(err, domain.ErrFailedPrecondition) {
+		err = inventory.ReturnStock(ctx, reservationID)
+	}
+	return err
+}
+
+// permanent reports whether err is a failure that retrying cannot fix. A
+// conflict here means the order already moved on to another status.
+func permanent(err error) bool {
+	return errors.Is(err, domain.ErrFailedPrecondition) ||
+		errors.Is(err, domain.ErrConflict) ||
+		errors.Is(err, domain.ErrNotFound) ||
+		errors.Is(err, domain.ErrInvalidArgument)
+}
@@ -5,14 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strings"
+	"time"
 
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
 	producer "github.com/mephirious/advanced-programming-2/order-service/internal/adapter/nats"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/domain/dto"
 	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
-	pbOrder "github.com/mephirious/advanced-programming-2/order-service/proto"
-	pb "github.com/mephirious/advanced-programming-2/order-service/proto/events"
+	"github.com/mephirious/advanced-programming-2/order-service/pkg/auth"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -20,37 +20,100 @@ type OrderUseCase interface {
 	CreateOrder(ctx context.Context, dto dto.OrderCreateDTO) (*domain.Order, error)
 	GetOrderByID(ctx context.Context, id string) (*domain.Order, error)
 	UpdateOrderStatus(ctx context.Context, id string, status string) (*domain.Order, error)
+	CancelOrder(ctx context.Context, id string, reason string) (*domain.Order, error)
 	GetOrders(ctx context.Context, filter dto.OrderFilterDTO) ([]domain.Order, error)
+	ExpireOrders(ctx context.Context) (int, error)
+}
+
+const (
+	expiredOrdersBatch = 100
+
+	// reservationExpiredReason is recorded on orders cancelled because their
+	// stock reservation expired.
+	reservationExpiredReason = "stock reservation expired"
+)
+
+// Inventory reserves stock for orders in the inventory service.
+type Inventory interface {
+	// ReserveStock returns the reservation ID and when the reservation expires.
+	ReserveStock(ctx context.Context, orderID string, items []domain.OrderItem) (string, time.Time, error)
+	ReleaseStock(ctx context.Context, reservationID string) error
+	CommitReservation(ctx context.Context, reservationID string) error
+	ReturnStock(ctx context.Context, reservationID string) error
+}
+
+// PaymentReleaser gives back the payment of a cancelled order. It must be
+// idempotent because a failed cancellation may be retried.
+type PaymentReleaser interface {
+	ReleaseOrderPayment(ctx context.Context, order *domain.Order) error
+}
+
+// ProductCatalog looks up the current product data in the inventory service.
+type ProductCatalog interface {
+	// GetProductsByIDs returns the products that exist among ids, keyed by
+	// product ID. Deleted products may be returned flagged as deleted.
+	GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]domain.CatalogProduct, error)
 }
 
 type orderUseCase struct {
 	orderRepo     repository.OrderRepository
+	sagaRepo      repository.SagaRepository
+	shipmentRepo  repository.ShipmentRepository
 	eventProducer producer.OrderEventProducer
+	inventory     Inventory
+	catalog       ProductCatalog
+	payments      PaymentReleaser
+	checkout      CheckoutSaga
+	tx            Transactor
 }
 
-func NewOrderUseCase(repo repository.OrderRepository, eventProducer producer.OrderEventProducer) *orderUseCase {
+func NewOrderUseCase(
+	repo repository.OrderRepository,
+	sagaRepo repository.SagaRepository,
+	shipmentRepo repository.ShipmentRepository,
+	eventProducer producer.OrderEventProducer,
+	inventory Inventory,
+	catalog ProductCatalog,
+	payments PaymentReleaser,
+	checkout CheckoutSaga,
+	tx Transactor,
+) *orderUseCase {
 	return &orderUseCase{
 		orderRepo:     repo,
+		sagaRepo:      sagaRepo,
+		shipmentRepo:  shipmentRepo,
 		eventProducer: eventProducer,
+		inventory:     inventory,
+		catalog:       catalog,
+		payments:      payments,
+		checkout:      checkout,
+		tx:            tx,
 	}
 }
 
 func (uc *orderUseCase) CreateOrder(ctx context.Context, dto dto.OrderCreateDTO) (*domain.Order, error) {
-	userID, err := primitive.ObjectIDFromHex(dto.UserID)
+	userHex, err := resolveUserID(ctx, dto.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+		return nil, err
 	}
 
-	productIDs := make([]string, len(dto.Items))
-	for i, item := range dto.Items {
-		productIDs[i] = item.ProductID
+	userID, err := primitive.ObjectIDFromHex(userHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user ID %q", domain.ErrInvalidArgument, userHex)
+	}
+
+	if len(dto.Items) == 0 {
+		return nil, fmt.Errorf("%w: order has no items", domain.ErrInvalidArgument)
 	}
 
 	items := make([]domain.OrderItem, len(dto.Items))
 	for i, item := range dto.Items {
 		productID, err := primitive.ObjectIDFromHex(item.ProductID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid product ID: %w", err)
+			return nil, fmt.Errorf("%w: invalid product ID %q", domain.ErrInvalidArgument, item.ProductID)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity of product %s must be positive", domain.ErrInvalidArgument, item.ProductID)
 		}
 		items[i] = domain.OrderItem{
 			ProductID: productID,
@@ -58,97 +121,378 @@ func (uc *orderUseCase) CreateOrder(ctx context.Context, dto dto.OrderCreateDTO)
 		}
 	}
 
+	shipping, billing, err := orderAddresses(dto.ShippingAddress, dto.BillingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.priceItems(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
 	order := &domain.Order{
-		ID:     primitive.ObjectID(primitive.NewObjectID()),
-		UserID: userID,
-		Items:  items,
-		Status: domain.OrderStatusPending,
+		ID:              primitive.ObjectID(primitive.NewObjectID()),
+		UserID:          userID,
+		Items:           items,
+		Total:           total,
+		Status:          domain.OrderStatusPending,
+		ShippingAddress: shipping,
+		BillingAddress:  billing,
+		StatusHistory: []domain.StatusTransition{{
+			To:    domain.OrderStatusPending,
+			Actor: actor(ctx),
+			At:    time.Now(),
+		}},
 	}
 
-	if err := uc.orderRepo.CreateOrder(ctx, order); err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+	return uc.checkout.Checkout(ctx, order)
+}
+
+// orderAddresses validates the addresses of a new order. Without a billing
+// address the order is billed to the shipping address.
+func orderAddresses(shipping, billing *domain.Address) (*domain.Address, *domain.Address, error) {
+	if shipping != nil {
+		if err := shipping.Validate("shipping"); err != nil {
+			return nil, nil, err
+		}
+	}
+	if billing == nil {
+		return shipping, shipping, nil
 	}
+	if err := billing.Validate("billing"); err != nil {
+		return nil, nil, err
+	}
+	return shipping, billing, nil
+}
 
-	if err := uc.eventProducer.Push(ctx, order, pb.OrderEventType_CREATED); err != nil {
-		log.Printf("Failed to push create event to NATS: %v", err)
+// priceItems sets the current inventory price on every item and returns the
+// order total.
+func (uc *orderUseCase) priceItems(ctx context.Context, items []domain.OrderItem) (float64, error) {
+	productIDs := make([]primitive.ObjectID, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
 	}
 
-	return order, nil
+	products, err := uc.catalog.GetProductsByIDs(ctx, productIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	var total float64
+	for i, item := range items {
+		product, found := products[item.ProductID]
+		if !found {
+			return 0, fmt.Errorf("%w: product %s", domain.ErrNotFound, item.ProductID.Hex())
+		}
+		if product.Deleted {
+			return 0, fmt.Errorf("%w: product %s was deleted", domain.ErrFailedPrecondition, item.ProductID.Hex())
+		}
+		items[i].Price = product.Price
+		total += product.Price * float64(item.Quantity)
+	}
+	return total, nil
 }
 
 func (uc *orderUseCase) GetOrderByID(ctx context.Context, id string) (*domain.Order, error) {
+	return uc.getOrder(ctx, id)
+}
+
+func (uc *orderUseCase) getOrder(ctx context.Context, id string) (*domain.Order, error) {
 	orderID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid order ID: %w", err)
+		return nil, fmt.Errorf("%w: invalid order ID %q", domain.ErrInvalidArgument, id)
 	}
 
 	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
-	if order == nil {
-		return nil, errors.New("order not found")
+	if order == nil || !canAccessOrder(ctx, order) {
+		return nil, domain.ErrOrderNotFound
 	}
 
 	return order, nil
 }
 
+// UpdateOrderStatus moves an order along its lifecycle. Customers may only
+// cancel their orders, every other change is made by admins or the system.
 func (uc *orderUseCase) UpdateOrderStatus(ctx context.Context, id string, status string) (*domain.Order, error) {
-	orderID, err := primitive.ObjectIDFromHex(id)
+	orderStatus, err := domain.ParseOrderStatus(status)
 	if err != nil {
-		return nil, fmt.Errorf("invalid order ID: %w", err)
+		return nil, err
+	}
+	if orderStatus == domain.OrderStatusCancelled {
+		return uc.CancelOrder(ctx, id, "")
+	}
+	if !isAdminOrSystem(ctx) {
+		return nil, fmt.Errorf("%w: admin role required to set order status %s", domain.ErrPermissionDenied, orderStatus)
 	}
 
-	normalized := strings.ToLower(status)
-	orderStatus := domain.OrderStatus(normalized)
-	switch orderStatus {
-	case domain.OrderStatusPending, domain.OrderStatusCompleted, domain.OrderStatusCancelled:
-	default:
-		return nil, errors.New("invalid order status")
+	existing, err := uc.getOrder(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := uc.orderRepo.UpdateOrderStatus(ctx, orderID, orderStatus); err != nil {
-		return nil, fmt.Errorf("failed to update order status: %w", err)
+	if err := existing.Status.ValidateTransition(orderStatus); err != nil {
+		return nil, err
 	}
 
-	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	return uc.transition(ctx, existing, domain.StatusTransition{
+		From:  existing.Status,
+		To:    orderStatus,
+		Actor: actor(ctx),
+		At:    time.Now(),
+	}, pb.OrderEventType_UPDATED, func(ctx context.Context) error {
+		return uc.settleReservation(ctx, existing, orderStatus)
+	})
+}
+
+// CancelOrder cancels a pending, paid or processing order. The stock of the
+// order is put back and its payment is voided or refunded before the order is
+// marked cancelled, so a failed cancellation can simply be retried. Once a
+// shipment left the order can no longer be cancelled; pending shipments are
+// cancelled with it.
+func (uc *orderUseCase) CancelOrder(ctx context.Context, id string, reason string) (*domain.Order, error) {
+	existing, err := uc.getOrder(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch updated order: %w", err)
+		return nil, err
+	}
+
+	if err := existing.Status.ValidateTransition(domain.OrderStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	if existing.Status == domain.OrderStatusPending {
+		saga, err := uc.sagaRepo.GetSaga(ctx, existing.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checkout: %w", err)
+		}
+		if saga != nil && (saga.Status == domain.SagaStatusRunning || saga.Status == domain.SagaStatusCompensating) {
+			return nil, fmt.Errorf("%w: checkout of order %s is in progress", domain.ErrFailedPrecondition, id)
+		}
+	}
+
+	// Pending shipments are cancelled before stock and payment are given back,
+	// so none of them can leave while the order is being cancelled.
+	if err := uc.cancelShipments(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	if err := uc.restoreStock(ctx, existing); err != nil {
+		return nil, err
 	}
-	if order == nil {
-		return nil, errors.New("order not found after update")
+	if err := uc.payments.ReleaseOrderPayment(ctx, existing); err != nil {
+		return nil, err
 	}
 
-	if err := uc.eventProducer.Push(ctx, order, pb.OrderEventType_UPDATED); err != nil {
-		log.Printf("Failed to push update event to NATS: %v", err)
+	return uc.transition(ctx, existing, domain.StatusTransition{
+		From:   existing.Status,
+		To:     domain.OrderStatusCancelled,
+		Actor:  actor(ctx),
+		Reason: reason,
+		At:     time.Now(),
+	}, pb.OrderEventType_CANCELLED, func(ctx context.Context) error {
+		// Shipments created meanwhile are cancelled too, or the cancellation
+		// fails if one of them was shipped.
+		if err := uc.shipmentRepo.CancelPendingShipments(ctx, existing.ID, actor(ctx)); err != nil {
+			return fmt.Errorf("failed to cancel shipments: %w", err)
+		}
+		return nil
+	})
+}
+
+// cancelShipments cancels the pending shipments of an order in a transaction
+// that bumps the order, which serializes it with shipment status changes. It
+// fails when a shipment of the order was already shipped.
+func (uc *orderUseCase) cancelShipments(ctx context.Context, order *domain.Order) error {
+	return uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.orderRepo.BumpVersion(ctx, order.ID); err != nil {
+			return fmt.Errorf("failed to lock order: %w", err)
+		}
+		if err := uc.shipmentRepo.CancelPendingShipments(ctx, order.ID, actor(ctx)); err != nil {
+			return fmt.Errorf("failed to cancel shipments: %w", err)
+		}
+		return nil
+	})
+}
+
+// transition applies a status transition to order and publishes the updated
+// order as an event of eventType. update, if set, runs in the same
+// transaction.
+func (uc *orderUseCase) transition(
+	ctx context.Context,
+	existing *domain.Order,
+	transition domain.StatusTransition,
+	eventType pb.OrderEventType,
+	update func(ctx context.Context) error,
+) (*domain.Order, error) {
+	var order *domain.Order
+	err := uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := uc.orderRepo.TransitionStatus(ctx, existing.ID, transition)
+		if err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: order %s was changed concurrently", domain.ErrConflict, existing.ID.Hex())
+		}
+		if update != nil {
+			if err := update(ctx); err != nil {
+				return err
+			}
+		}
+
+		order, err = uc.orderRepo.GetOrderByID(ctx, existing.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch updated order: %w", err)
+		}
+		if order == nil {
+			return domain.ErrOrderNotFound
+		}
+
+		return uc.eventProducer.Push(ctx, order, eventType)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return order, nil
 }
 
 func (uc *orderUseCase) GetOrders(ctx context.Context, filter dto.OrderFilterDTO) ([]domain.Order, error) {
-	if authUserID, exists := ctx.Value("userID").(string); exists && authUserID != "" {
-		if filter.UserID != "" && filter.UserID != authUserID {
-			return nil, errors.New("not authorized to view other users' orders")
+	userID, err := resolveUserID(ctx, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = userID
+
+	return uc.orderRepo.GetOrders(ctx, filter)
+}
+
+// ExpireOrders cancels pending orders whose stock reservation expired, since
+// they could never commit it, and reports how many were cancelled. Orders
+// whose checkout is still running are left to the checkout, which fails to
+// commit the reservation and cancels the order itself.
+func (uc *orderUseCase) ExpireOrders(ctx context.Context) (int, error) {
+	orders, err := uc.orderRepo.GetExpiredOrders(ctx, time.Now(), expiredOrdersBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired orders: %w", err)
+	}
+
+	cancelled := 0
+	for _, order := range orders {
+		_, err := uc.CancelOrder(ctx, order.ID.Hex(), reservationExpiredReason)
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			continue
+		}
+		if err != nil {
+			return cancelled, fmt.Errorf("failed to cancel order %s: %w", order.ID.Hex(), err)
 		}
+		cancelled++
+	}
+	return cancelled, nil
+}
 
-		if filter.UserID == "" {
-			filter.UserID = authUserID
+// StartOrderExpirer periodically cancels the orders whose stock reservation
+// expired.
+func StartOrderExpirer(ctx context.Context, uc OrderUseCase, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				cancelled, err := uc.ExpireOrders(ctx)
+				if err != nil {
+					log.Printf("Failed to expire orders: %v", err)
+				}
+				if cancelled > 0 {
+					log.Printf("Cancelled %d orders with expired stock reservations", cancelled)
+				}
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
+}
+
+// settleReservation commits the stock reservation once an order is paid. It
+// runs in the transaction of the status change, after the transition was won,
+// so a lost race never commits the stock. Committing is idempotent, which
+// keeps a retried status change safe when the transaction fails afterwards.
+func (uc *orderUseCase) settleReservation(ctx context.Context, order *domain.Order, status domain.OrderStatus) error {
+	if order.ReservationID == "" || status != domain.OrderStatusPaid {
+		return nil
 	}
 
-	return uc.orderRepo.GetOrders(ctx, filter)
+	if err := uc.inventory.CommitReservation(ctx, order.ReservationID); err != nil {
+		return fmt.Errorf("failed to commit stock reservation: %w", err)
+	}
+	return nil
+}
+
+// restoreStock puts the stock of a cancelled order back. The reservation of a
+// pending order is normally still held and is released, paid orders already
+// took the stock and return it.
+func (uc *orderUseCase) restoreStock(ctx context.Context, order *domain.Order) error {
+	if order.ReservationID == "" {
+		return nil
+	}
+
+	if order.Status == domain.OrderStatusPending {
+		if err := releaseReservation(ctx, uc.inventory, order.ReservationID); err != nil {
+			return fmt.Errorf("failed to release stock reservation: %w", err)
+		}
+		return nil
+	}
+
+	if err := uc.inventory.ReturnStock(ctx, order.ReservationID); err != nil {
+		return fmt.Errorf("failed to return stock: %w", err)
+	}
+	return nil
+}
+
+// actor identifies who triggered a status transition.
+func actor(ctx context.Context) string {
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		return userID
+	}
+	return domain.SystemActor
+}
+
+// resolveUserID returns the user an operation acts on. Callers authenticated by
+// the gateway may only act on their own orders unless they are admins.
+func resolveUserID(ctx context.Context, userID string) (string, error) {
+	authUserID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return userID, nil
+	}
+	if userID == "" {
+		return authUserID, nil
+	}
+	if userID != authUserID && !auth.HasRole(ctx, auth.RoleAdmin) {
+		return "", fmt.Errorf("%w: not authorized to access other users' orders", domain.ErrPermissionDenied)
+	}
+	return userID, nil
+}
+
+// isAdminOrSystem reports whether the caller is an admin or an internal caller
+// that was not authenticated by the gateway.
+func isAdminOrSystem(ctx context.Context) bool {
+	_, ok := auth.UserIDFromContext(ctx)
+	return !ok || auth.HasRole(ctx, auth.RoleAdmin)
+}
+
+func canAccessOrder(ctx context.Context, order *domain.Order) bool {
+	return canAccessUser(ctx, order.UserID)
 }
 
-func ParseOrderStatus(statusStr string) (pbOrder.OrderStatus, error) {
-	switch statusStr {
-	case "PENDING":
-		return pbOrder.OrderStatus_PENDING, nil
-	case "COMPLETED":
-		return pbOrder.OrderStatus_COMPLETED, nil
-	case "CANCELLED":
-		return pbOrder.OrderStatus_CANCELLED, nil
-	default:
-		return pbOrder.OrderStatus_PENDING, fmt.Errorf("invalid order status")
+// canAccessUser reports whether the caller may see the data of userID.
+func canAccessUser(ctx context.Context, userID primitive.ObjectID) bool {
+	authUserID, ok := auth.UserIDFromContext(ctx)
+	if !ok || auth.HasRole(ctx, auth.RoleAdmin) {
+		return true
 	}
+	return userID.Hex() == authUserID
 }
@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PaymentProvider is a payment service provider. References identify an
+// authorization at the provider.
+type PaymentProvider interface {
+	Name() string
+	// Authorize places a hold for amount and returns its reference. key
+	// identifies the authorization, sending it again returns the same one.
+	Authorize(ctx context.Context, key string, amount float64) (string, error)
+	Capture(ctx context.Context, reference string, amount float64) error
+	Void(ctx context.Context, reference string) error
+	// Refund refunds amount of a captured authorization. refundReference
+	// identifies the refund, sending it again does not refund twice.
+	Refund(ctx context.Context, reference, refundReference string, amount float64) error
+}
+
+type PaymentUseCase interface {
+	PaymentAuthorizer
+	PaymentReleaser
+	PaymentRefunder
+	PayOrder(ctx context.Context, orderID string) (*domain.Payment, error)
+	GetOrderPayment(ctx context.Context, orderID string) (*domain.Payment, error)
+}
+
+// cancellationRefund is the refund reference of cancelled orders.
+const cancellationRefund = "cancellation"
+
+type paymentUseCase struct {
+	paymentRepo repository.PaymentRepository
+	orderRepo   repository.OrderRepository
+	provider    PaymentProvider
+	timeout     time.Duration
+}
+
+func NewPaymentUseCase(paymentRepo repository.PaymentRepository, orderRepo repository.OrderRepository, provider PaymentProvider, timeout time.Duration) *paymentUseCase {
+	return &paymentUseCase{
+		paymentRepo: paymentRepo,
+		orderRepo:   orderRepo,
+		provider:    provider,
+		timeout:     timeout,
+	}
+}
+
+// Authorize places a hold for the order total and returns the payment ID. An
+// order that already holds an authorization is not charged again.
+func (uc *paymentUseCase) Authorize(ctx context.Context, order *domain.Order) (string, error) {
+	payment, err := uc.paymentRepo.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment != nil && (payment.Status == domain.PaymentStatusAuthorized || payment.Status == domain.PaymentStatusCaptured) {
+		return payment.ID.Hex(), nil
+	}
+	if payment == nil {
+		payment = &domain.Payment{OrderID: order.ID}
+	}
+	payment.Amount = order.Total
+	payment.Provider = uc.provider.Name()
+	payment.Status = domain.PaymentStatusPending
+	payment.FailureReason = ""
+	payment.Attempts++
+
+	// The pending record is stored first so that an authorization is never
+	// made at the provider without a payment pointing at it.
+	if err := uc.paymentRepo.SavePayment(ctx, payment); err != nil {
+		return "", fmt.Errorf("failed to save payment: %w", err)
+	}
+
+	var reference string
+	err = uc.call(ctx, func(ctx context.Context) error {
+		var err error
+		reference, err = uc.provider.Authorize(ctx, payment.AuthorizationKey(), order.Total)
+		return err
+	})
+	if err != nil {
+		payment.Status = domain.PaymentStatusFailed
+		if errors.Is(err, domain.ErrPaymentDeclined) {
+			payment.Status = domain.PaymentStatusDeclined
+		}
+		payment.FailureReason = err.Error()
+		if saveErr := uc.paymentRepo.SavePayment(ctx, payment); saveErr != nil {
+			return "", fmt.Errorf("failed to save payment: %w", saveErr)
+		}
+		return "", err
+	}
+
+	payment.Status = domain.PaymentStatusAuthorized
+	payment.ProviderReference = reference
+	if err := uc.paymentRepo.SavePayment(ctx, payment); err != nil {
+		return "", fmt.Errorf("failed to save payment: %w", err)
+	}
+	return payment.ID.Hex(), nil
+}
+
+// Void releases the authorization of a payment. Payments that never got an
+// authorization have nothing to release.
+func (uc *paymentUseCase) Void(ctx context.Context, paymentID string) error {
+	id, err := primitive.ObjectIDFromHex(paymentID)
+	if err != nil {
+		return fmt.Errorf("%w: invalid payment ID %q", domain.ErrInvalidArgument, paymentID)
+	}
+
+	payment, err := uc.paymentRepo.GetPaymentByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil {
+		return domain.ErrPaymentNotFound
+	}
+
+	switch payment.Status {
+	case domain.PaymentStatusAuthorized:
+	case domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded, domain.PaymentStatusRefunded:
+		return fmt.Errorf("%w: payment %s is %s", domain.ErrFailedPrecondition, paymentID, payment.Status)
+	default:
+		return nil
+	}
+
+	if err := uc.call(ctx, func(ctx context.Context) error {
+		return uc.provider.Void(ctx, payment.ProviderReference)
+	}); err != nil {
+		return fmt.Errorf("failed to void payment: %w", err)
+	}
+
+	payment.Status = domain.PaymentStatusVoided
+	if err := uc.paymentRepo.SavePayment(ctx, payment); err != nil {
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+	return nil
+}
+
+// ReleaseOrderPayment gives back what a cancelled order holds at the provider.
+// Authorizations are voided and captured amounts are refunded in full under
+// the cancellation reference, so a retried cancellation finishes the same
+// refund instead of refunding again. Orders without a payment, or whose
+// payment was already released, are left alone.
+func (uc *paymentUseCase) ReleaseOrderPayment(ctx context.Context, order *domain.Order) error {
+	payment, err := uc.paymentRepo.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil {
+		return nil
+	}
+
+	if _, ok := payment.Refund(cancellationRefund); ok {
+		return uc.refund(ctx, payment, cancellationRefund, 0)
+	}
+
+	switch payment.Status {
+	case domain.PaymentStatusAuthorized:
+		return uc.Void(ctx, payment.ID.Hex())
+	case domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded:
+	default:
+		return nil
+	}
+
+	amount := payment.CapturedAmount - payment.RefundedAmount
+	if amount <= domain.CentTolerance {
+		return nil
+	}
+	return uc.refund(ctx, payment, cancellationRefund, amount)
+}
+
+// RefundOrderPayment refunds amount of the captured payment of an order.
+// Reference names what is refunded; a reference that was already refunded is
+// not refunded again, and one that is still pending is finished.
+func (uc *paymentUseCase) RefundOrderPayment(ctx context.Context, order *domain.Order, reference string, amount float64) error {
+	payment, err := uc.paymentRepo.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil {
+		return fmt.Errorf("%w: order %s has no payment", domain.ErrFailedPrecondition, order.ID.Hex())
+	}
+	if _, ok := payment.Refund(reference); ok {
+		return uc.refund(ctx, payment, reference, amount)
+	}
+
+	switch payment.Status {
+	case domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded:
+	default:
+		return fmt.Errorf("%w: cannot refund %s payment of order %s", domain.ErrFailedPrecondition, payment.Status, order.ID.Hex())
+	}
+	if amount <= 0 || amount > payment.CapturedAmount-payment.RefundedAmount+domain.CentTolerance {
+		return fmt.Errorf("%w: refund of %.2f exceeds the refundable amount of order %s", domain.ErrFailedPrecondition, amount, order.ID.Hex())
+	}
+
+	return uc.refund(ctx, payment, reference, amount)
+}
+
+// refund refunds amount of a captured payment under reference. The refund is
+// claimed on the payment before the provider is called, which reserves the
+// amount and makes concurrent refunds of the same reference meet on one
+// record. A pending refund, left by a failure or a concurrent call, is sent
+// again with the same reference and its recorded amount; a completed one is
+// done.
+func (uc *paymentUseCase) refund(ctx context.Context, payment *domain.Payment, reference string, amount float64) error {
+	refund, ok := payment.Refund(reference)
+	if !ok {
+		refund = domain.PaymentRefund{Reference: reference, Amount: amount, Pending: true, At: time.Now()}
+		claimed, err := uc.paymentRepo.ClaimRefund(ctx, payment.ID, refund)
+		if err != nil {
+			return fmt.Errorf("failed to save refund: %w", err)
+		}
+		if !claimed {
+			current, err := uc.paymentRepo.GetPaymentByID(ctx, payment.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get payment: %w", err)
+			}
+			if current == nil {
+				return domain.ErrPaymentNotFound
+			}
+			if refund, ok = current.Refund(reference); !ok {
+				return fmt.Errorf("%w: refund of %.2f exceeds the refundable amount of payment %s", domain.ErrFailedPrecondition, amount, payment.ID.Hex())
+			}
+		}
+	}
+	if !refund.Pending {
+		return nil
+	}
+
+	if err := uc.call(ctx, func(ctx context.Context) error {
+		return uc.provider.Refund(ctx, payment.ProviderReference, reference, refund.Amount)
+	}); err != nil {
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			// The provider will not make this refund, give the amount back.
+			if releaseErr := uc.paymentRepo.ReleaseRefund(ctx, payment.ID, refund); releaseErr != nil {
+				return fmt.Errorf("failed to release refund: %w", releaseErr)
+			}
+		}
+		return fmt.Errorf("failed to refund payment: %w", err)
+	}
+
+	if err := uc.paymentRepo.CompleteRefund(ctx, payment.ID, reference); err != nil {
+		return fmt.Errorf("failed to save refund: %w", err)
+	}
+	return nil
+}
+
+// PayOrder captures the authorized payment of a confirmed order. Orders whose
+// payment was not authorized are authorized first.
+func (uc *paymentUseCase) PayOrder(ctx context.Context, orderID string) (*domain.Payment, error) {
+	order, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case domain.OrderStatusPaid, domain.OrderStatusProcessing, domain.OrderStatusShipped, domain.OrderStatusDelivered:
+	default:
+		return nil, fmt.Errorf("%w: cannot pay for %s order %s", domain.ErrFailedPrecondition, order.Status, orderID)
+	}
+
+	paymentID, err := uc.Authorize(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := primitive.ObjectIDFromHex(paymentID)
+	payment, err := uc.paymentRepo.GetPaymentByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil {
+		return nil, domain.ErrPaymentNotFound
+	}
+	if payment.Status == domain.PaymentStatusCaptured {
+		return payment, nil
+	}
+
+	if err := uc.call(ctx, func(ctx context.Context) error {
+		return uc.provider.Capture(ctx, payment.ProviderReference, payment.Amount)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to capture payment: %w", err)
+	}
+
+	payment.Status = domain.PaymentStatusCaptured
+	payment.CapturedAmount = payment.Amount
+	if err := uc.paymentRepo.SavePayment(ctx, payment); err != nil {
+		return nil, fmt.Errorf("failed to save payment: %w", err)
+	}
+	return payment, nil
+}
+
+func (uc *paymentUseCase) GetOrderPayment(ctx context.Context, orderID string) (*domain.Payment, error) {
+	order, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := uc.paymentRepo.GetPaymentByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil {
+		return nil, domain.ErrPaymentNotFound
+	}
+	return payment, nil
+}
+
+func (uc *paymentUseCase) getOrder(ctx context.Context, id string) (*domain.Order, error) {
+	orderID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid order ID %q", domain.ErrInvalidArgument, id)
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil || !canAccessOrder(ctx, order) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// call runs a provider request with the configured timeout.
+func (uc *paymentUseCase) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	return fn(ctx)
+}
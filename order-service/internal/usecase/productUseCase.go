@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
+	"github.com/mephirious/advanced-programming-2/order-service/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// resyncPageSize is the number of products read from the inventory service
+// per page during a resync.
+const resyncPageSize = 100
+
+// ProductSource reads the products of the inventory service.
+type ProductSource interface {
+	ProductCatalog
+	// ListProducts returns a page of products ordered by ID, starting at 1.
+	ListProducts(ctx context.Context, page, limit int) ([]domain.Product, error)
+}
+
+// ProductUseCase keeps the local product projection. It is a ProductCatalog
+// that prices orders without calling the inventory service for every product
+// it has seen.
+type ProductUseCase interface {
+	ProductCatalog
+	// ApplyEvent applies the product state carried by an inventory event.
+	// Redelivered and outdated events are ignored.
+	ApplyEvent(ctx context.Context, product *domain.Product) error
+	// ResyncProducts backfills the projection from the inventory service.
+	ResyncProducts(ctx context.Context) (*domain.ProductResync, error)
+}
+
+type productUseCase struct {
+	productRepo repository.ProductRepository
+	inventory   ProductSource
+	resyncing   atomic.Bool
+}
+
+func NewProductUseCase(productRepo repository.ProductRepository, inventory ProductSource) *productUseCase {
+	return &productUseCase{
+		productRepo: productRepo,
+		inventory:   inventory,
+	}
+}
+
+func (uc *productUseCase) ApplyEvent(ctx context.Context, product *domain.Product) error {
+	applied, err := uc.productRepo.ApplyEvent(ctx, product)
+	if err != nil {
+		return fmt.Errorf("failed to apply event of product %s: %w", product.ID.Hex(), err)
+	}
+	if !applied {
+		log.Printf("Ignoring outdated event %d of product %s", product.Sequence, product.ID.Hex())
+	}
+	return nil
+}
+
+// GetProductsByIDs returns the products from the projection, including the
+// deleted ones. Products the projection does not know yet, for example before
+// the first resync, are looked up in the inventory service.
+func (uc *productUseCase) GetProductsByIDs(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]domain.CatalogProduct, error) {
+	projected, err := uc.productRepo.GetProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	products := make(map[primitive.ObjectID]domain.CatalogProduct, len(ids))
+	for _, product := range projected {
+		products[product.ID] = product.CatalogProduct()
+	}
+
+	var unknown []primitive.ObjectID
+	for _, id := range ids {
+		if _, ok := products[id]; !ok && !slices.Contains(unknown, id) {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) == 0 {
+		return products, nil
+	}
+
+	fetched, err := uc.inventory.GetProductsByIDs(ctx, unknown)
+	if err != nil {
+		return nil, err
+	}
+	for id, product := range fetched {
+		products[id] = product
+	}
+	return products, nil
+}
+
+func (uc *productUseCase) ResyncProducts(ctx context.Context) (*domain.ProductResync, error) {
+	if !auth.HasRole(ctx, auth.RoleAdmin) {
+		return nil, fmt.Errorf("%w: admin role required", domain.ErrPermissionDenied)
+	}
+	if !uc.resyncing.CompareAndSwap(false, true) {
+		return nil, fmt.Errorf("%w: a resync is already running", domain.ErrFailedPrecondition)
+	}
+	defer uc.resyncing.Store(false)
+
+	started := time.Now()
+	resync := &domain.ProductResync{}
+	seen := make(map[primitive.ObjectID]bool)
+	for page := 1; ; page++ {
+		products, err := uc.inventory.ListProducts(ctx, page, resyncPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list products: %w", err)
+		}
+		for _, product := range products {
+			product.SyncedAt = time.Now()
+			if _, err := uc.productRepo.SaveSnapshot(ctx, &product); err != nil {
+				return nil, fmt.Errorf("failed to save product %s: %w", product.ID.Hex(), err)
+			}
+			seen[product.ID] = true
+			resync.Synced++
+		}
+		if len(products) < resyncPageSize {
+			break
+		}
+	}
+
+	deleted, err := uc.markDeleted(ctx, seen, started)
+	if err != nil {
+		return nil, err
+	}
+	resync.Deleted = deleted
+
+	log.Printf("Resynced %d products, %d deleted", resync.Synced, resync.Deleted)
+	return resync, nil
+}
+
+// markDeleted flags the projected products that the resync did not see as
+// deleted. Pages shift when products are deleted meanwhile, so every product
+// that was not seen is looked up again before it is flagged.
+func (uc *productUseCase) markDeleted(ctx context.Context, seen map[primitive.ObjectID]bool, started time.Time) (int, error) {
+	live, err := uc.productRepo.GetLiveProductIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get products: %w", err)
+	}
+	unseen := slices.DeleteFunc(live, func(id primitive.ObjectID) bool { return seen[id] })
+	if len(unseen) == 0 {
+		return 0, nil
+	}
+
+	existing, err := uc.inventory.GetProductsByIDs(ctx, unseen)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get products: %w", err)
+	}
+	missing := slices.DeleteFunc(unseen, func(id primitive.ObjectID) bool {
+		_, ok := existing[id]
+		return ok
+	})
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	deleted, err := uc.productRepo.MarkDeleted(ctx, missing, started)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark products deleted: %w", err)
+	}
+	return int(deleted), nil
+}
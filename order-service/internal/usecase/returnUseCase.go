@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
+	producer "github.com/mephirious/advanced-programming-2/order-service/internal/adapter/nats"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain/dto"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
+	"github.com/mephirious/advanced-programming-2/order-service/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReturnUseCase runs order returns (RMAs). Customers open returns for items
+// of their shipped orders; admins approve or reject them, receive the goods
+// and refund them.
+type ReturnUseCase interface {
+	OpenReturn(ctx context.Context, dto dto.ReturnCreateDTO) (*domain.Return, error)
+	GetReturn(ctx context.Context, id string) (*domain.Return, error)
+	ListOrderReturns(ctx context.Context, orderID string) ([]domain.Return, error)
+	ApproveReturn(ctx context.Context, id string) (*domain.Return, error)
+	RejectReturn(ctx context.Context, id string, reason string) (*domain.Return, error)
+	ReceiveReturn(ctx context.Context, id string, restock bool) (*domain.Return, error)
+	RefundReturn(ctx context.Context, id string) (*domain.Return, error)
+}
+
+// Restocker puts returned goods back into stock in the inventory service. It
+// must be idempotent per reference.
+type Restocker interface {
+	RestockItems(ctx context.Context, reference, orderID string, items []domain.ReturnItem) error
+}
+
+// PaymentRefunder refunds part of the payment of an order. It must be
+// idempotent per reference.
+type PaymentRefunder interface {
+	RefundOrderPayment(ctx context.Context, order *domain.Order, reference string, amount float64) error
+}
+
+type returnUseCase struct {
+	returnRepo    repository.ReturnRepository
+	orderRepo     repository.OrderRepository
+	eventProducer producer.OrderEventProducer
+	restocker     Restocker
+	payments      PaymentRefunder
+	tx            Transactor
+}
+
+func NewReturnUseCase(
+	returnRepo repository.ReturnRepository,
+	orderRepo repository.OrderRepository,
+	eventProducer producer.OrderEventProducer,
+	restocker Restocker,
+	payments PaymentRefunder,
+	tx Transactor,
+) *returnUseCase {
+	return &returnUseCase{
+		returnRepo:    returnRepo,
+		orderRepo:     orderRepo,
+		eventProducer: eventProducer,
+		restocker:     restocker,
+		payments:      payments,
+		tx:            tx,
+	}
+}
+
+// OpenReturn requests the return of items of a shipped or delivered order. A
+// product can be returned up to the quantity ordered, less what other returns
+// that were not rejected already cover.
+func (uc *returnUseCase) OpenReturn(ctx context.Context, dto dto.ReturnCreateDTO) (*domain.Return, error) {
+	order, err := uc.getOrder(ctx, dto.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.Status.CanReturn() {
+		return nil, fmt.Errorf("%w: cannot return items of %s order %s", domain.ErrFailedPrecondition, order.Status, dto.OrderID)
+	}
+
+	var ret *domain.Return
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		// Bumping the order first makes concurrent returns of it conflict, so
+		// the returnable quantities below are checked one return at a time.
+		if err := uc.orderRepo.BumpVersion(ctx, order.ID); err != nil {
+			return fmt.Errorf("failed to lock order: %w", err)
+		}
+
+		items, err := uc.returnItems(ctx, order, dto.Items)
+		if err != nil {
+			return err
+		}
+
+		var amount float64
+		for _, item := range items {
+			amount += item.Price * float64(item.Quantity)
+		}
+
+		ret = &domain.Return{
+			ID:      primitive.NewObjectID(),
+			OrderID: order.ID,
+			UserID:  order.UserID,
+			Items:   items,
+			Amount:  amount,
+			Reason:  dto.Reason,
+			Status:  domain.ReturnStatusRequested,
+			StatusHistory: []domain.ReturnTransition{{
+				To:    domain.ReturnStatusRequested,
+				Actor: actor(ctx),
+				At:    time.Now(),
+			}},
+		}
+		if err := uc.returnRepo.CreateReturn(ctx, ret); err != nil {
+			return fmt.Errorf("failed to create return: %w", err)
+		}
+		return uc.eventProducer.PushReturn(ctx, order, ret, pb.OrderEventType_RETURN_REQUESTED)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// returnItems validates the requested items against the order and the
+// earlier returns of it, merges duplicate products and prices them as ordered.
+func (uc *returnUseCase) returnItems(ctx context.Context, order *domain.Order, requested []dto.OrderItemDTO) ([]domain.ReturnItem, error) {
+	if len(requested) == 0 {
+		return nil, fmt.Errorf("%w: return has no items", domain.ErrInvalidArgument)
+	}
+
+	var items []domain.ReturnItem
+	index := make(map[primitive.ObjectID]int)
+	for _, item := range requested {
+		productID, err := primitive.ObjectIDFromHex(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid product ID %q", domain.ErrInvalidArgument, item.ProductID)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity for product %s must be positive", domain.ErrInvalidArgument, item.ProductID)
+		}
+		if i, ok := index[productID]; ok {
+			items[i].Quantity += item.Quantity
+			continue
+		}
+		index[productID] = len(items)
+		items = append(items, domain.ReturnItem{ProductID: productID, Quantity: item.Quantity})
+	}
+
+	existing, err := uc.returnRepo.GetReturnsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns: %w", err)
+	}
+	returned := domain.ReturnedQuantities(existing)
+	ordered := order.Quantities()
+
+	for i, item := range items {
+		if ordered[item.ProductID] == 0 {
+			return nil, fmt.Errorf("%w: product %s is not part of order %s", domain.ErrInvalidArgument, item.ProductID.Hex(), order.ID.Hex())
+		}
+		if available := ordered[item.ProductID] - returned[item.ProductID]; item.Quantity > available {
+			return nil, fmt.Errorf("%w: only %d units of product %s can be returned", domain.ErrFailedPrecondition, available, item.ProductID.Hex())
+		}
+		for _, line := range order.Items {
+			if line.ProductID == item.ProductID {
+				items[i].Price = line.Price
+				break
+			}
+		}
+	}
+	return items, nil
+}
+
+func (uc *returnUseCase) GetReturn(ctx context.Context, id string) (*domain.Return, error) {
+	return uc.getReturn(ctx, id)
+}
+
+func (uc *returnUseCase) ListOrderReturns(ctx context.Context, orderID string) ([]domain.Return, error) {
+	order, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	returns, err := uc.returnRepo.GetReturnsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get returns: %w", err)
+	}
+	return returns, nil
+}
+
+func (uc *returnUseCase) ApproveReturn(ctx context.Context, id string) (*domain.Return, error) {
+	ret, err := uc.getReturnForUpdate(ctx, id, domain.ReturnStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+	return uc.transition(ctx, ret, domain.ReturnStatusApproved, pb.OrderEventType_RETURN_APPROVED, nil)
+}
+
+func (uc *returnUseCase) RejectReturn(ctx context.Context, id string, reason string) (*domain.Return, error) {
+	ret, err := uc.getReturnForUpdate(ctx, id, domain.ReturnStatusRejected)
+	if err != nil {
+		return nil, err
+	}
+	ret.RejectReason = reason
+	return uc.transition(ctx, ret, domain.ReturnStatusRejected, pb.OrderEventType_RETURN_REJECTED, nil)
+}
+
+// ReceiveReturn records that the goods of an approved return arrived. With
+// restock the items are put back into stock, otherwise they are written off,
+// for example because they are damaged.
+func (uc *returnUseCase) ReceiveReturn(ctx context.Context, id string, restock bool) (*domain.Return, error) {
+	ret, err := uc.getReturnForUpdate(ctx, id, domain.ReturnStatusReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	if restock {
+		if err := uc.restocker.RestockItems(ctx, ret.ID.Hex(), ret.OrderID.Hex(), ret.Items); err != nil {
+			return nil, fmt.Errorf("failed to restock items: %w", err)
+		}
+	}
+	ret.Restocked = restock
+	return uc.transition(ctx, ret, domain.ReturnStatusReceived, pb.OrderEventType_RETURN_RECEIVED, nil)
+}
+
+// RefundReturn refunds the amount of a received return through the payment
+// of its order and records the refunded items on the order. An order whose
+// items were all refunded becomes refunded.
+func (uc *returnUseCase) RefundReturn(ctx context.Context, id string) (*domain.Return, error) {
+	ret, err := uc.getReturnForUpdate(ctx, id, domain.ReturnStatusRefunded)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	// The refund is claimed on the payment under the return ID before the
+	// provider is called, so retried or concurrent refunds of this return
+	// refund it once.
+	if err := uc.payments.RefundOrderPayment(ctx, order, ret.ID.Hex(), ret.Amount); err != nil {
+		return nil, err
+	}
+
+	return uc.transition(ctx, ret, domain.ReturnStatusRefunded, pb.OrderEventType_RETURN_REFUNDED, func(ctx context.Context, order *domain.Order) error {
+		order.ApplyRefund(ret.Items, ret.Amount)
+		if err := uc.orderRepo.SaveRefund(ctx, order); err != nil {
+			return fmt.Errorf("failed to save refund: %w", err)
+		}
+		if !order.FullyRefunded() || !order.Status.CanTransitionTo(domain.OrderStatusRefunded) {
+			return nil
+		}
+
+		ok, err := uc.orderRepo.TransitionStatus(ctx, order.ID, domain.StatusTransition{
+			From:  order.Status,
+			To:    domain.OrderStatusRefunded,
+			Actor: actor(ctx),
+			At:    time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: order %s was changed concurrently", domain.ErrConflict, order.ID.Hex())
+		}
+		return nil
+	})
+}
+
+// transition moves ret to status and publishes eventType with the return and
+// the state of its order. update, if set, changes the order in the same
+// transaction.
+func (uc *returnUseCase) transition(
+	ctx context.Context,
+	ret *domain.Return,
+	status domain.ReturnStatus,
+	eventType pb.OrderEventType,
+	update func(ctx context.Context, order *domain.Order) error,
+) (*domain.Return, error) {
+	transition := domain.ReturnTransition{
+		From:  ret.Status,
+		To:    status,
+		Actor: actor(ctx),
+		At:    time.Now(),
+	}
+
+	var updated *domain.Return
+	err := uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := uc.returnRepo.TransitionStatus(ctx, ret, transition)
+		if err != nil {
+			return fmt.Errorf("failed to update return status: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: return %s was changed concurrently", domain.ErrConflict, ret.ID.Hex())
+		}
+
+		order, err := uc.orderRepo.GetOrderByID(ctx, ret.OrderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if order == nil {
+			return domain.ErrOrderNotFound
+		}
+		if update != nil {
+			if err := update(ctx, order); err != nil {
+				return err
+			}
+			if order, err = uc.orderRepo.GetOrderByID(ctx, ret.OrderID); err != nil {
+				return fmt.Errorf("failed to fetch updated order: %w", err)
+			}
+		}
+
+		updated, err = uc.returnRepo.GetReturnByID(ctx, ret.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch updated return: %w", err)
+		}
+		if updated == nil {
+			return domain.ErrReturnNotFound
+		}
+
+		return uc.eventProducer.PushReturn(ctx, order, updated, eventType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// getReturnForUpdate loads a return an admin moves to status and checks that
+// the lifecycle allows it.
+func (uc *returnUseCase) getReturnForUpdate(ctx context.Context, id string, status domain.ReturnStatus) (*domain.Return, error) {
+	if !auth.HasRole(ctx, auth.RoleAdmin) {
+		return nil, fmt.Errorf("%w: admin role required", domain.ErrPermissionDenied)
+	}
+
+	ret, err := uc.getReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := ret.Status.ValidateTransition(status); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (uc *returnUseCase) getReturn(ctx context.Context, id string) (*domain.Return, error) {
+	returnID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid return ID %q", domain.ErrInvalidArgument, id)
+	}
+
+	ret, err := uc.returnRepo.GetReturnByID(ctx, returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+	if ret == nil || !canAccessUser(ctx, ret.UserID) {
+		return nil, domain.ErrReturnNotFound
+	}
+	return ret, nil
+}
+
+func (uc *returnUseCase) getOrder(ctx context.Context, id string) (*domain.Order, error) {
+	orderID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid order ID %q", domain.ErrInvalidArgument, id)
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil || !canAccessOrder(ctx, order) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
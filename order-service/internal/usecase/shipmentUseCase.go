@@ -0,0 +1,372 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
+	producer "github.com/mephirious/advanced-programming-2/order-service/internal/adapter/nats"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/domain/dto"
+	"github.com/mephirious/advanced-programming-2/order-service/internal/repository"
+	"github.com/mephirious/advanced-programming-2/order-service/pkg/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ShipmentUseCase ships orders in one or more parcels. Admins create shipments
+// for paid orders and track them; customers can read the shipments of their
+// orders. The order follows its shipments: it is shipped once all of them
+// left and delivered once all of them arrived.
+type ShipmentUseCase interface {
+	CreateShipment(ctx context.Context, dto dto.ShipmentCreateDTO) (*domain.Shipment, error)
+	GetShipment(ctx context.Context, id string) (*domain.Shipment, error)
+	ListOrderShipments(ctx context.Context, orderID string) ([]domain.Shipment, error)
+	SetShipmentTracking(ctx context.Context, id, carrier, trackingNumber string) (*domain.Shipment, error)
+	UpdateShipmentStatus(ctx context.Context, id, status string) (*domain.Shipment, error)
+}
+
+type shipmentUseCase struct {
+	shipmentRepo  repository.ShipmentRepository
+	orderRepo     repository.OrderRepository
+	eventProducer producer.OrderEventProducer
+	tx            Transactor
+}
+
+func NewShipmentUseCase(
+	shipmentRepo repository.ShipmentRepository,
+	orderRepo repository.OrderRepository,
+	eventProducer producer.OrderEventProducer,
+	tx Transactor,
+) *shipmentUseCase {
+	return &shipmentUseCase{
+		shipmentRepo:  shipmentRepo,
+		orderRepo:     orderRepo,
+		eventProducer: eventProducer,
+		tx:            tx,
+	}
+}
+
+// CreateShipment packs items of a paid or processing order into a shipment.
+// Without items every unit that is not in a shipment yet is packed. The
+// shipment goes to the given address, or else to the shipping address of the
+// order; one of them is required. A paid order starts processing with its
+// first shipment.
+func (uc *shipmentUseCase) CreateShipment(ctx context.Context, dto dto.ShipmentCreateDTO) (*domain.Shipment, error) {
+	if !auth.HasRole(ctx, auth.RoleAdmin) {
+		return nil, fmt.Errorf("%w: admin role required", domain.ErrPermissionDenied)
+	}
+
+	order, err := uc.getOrder(ctx, dto.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	address := dto.Address
+	if address == nil {
+		address = order.ShippingAddress
+	}
+	if address == nil {
+		return nil, fmt.Errorf("%w: order %s has no shipping address", domain.ErrFailedPrecondition, dto.OrderID)
+	}
+	if err := address.Validate("shipping"); err != nil {
+		return nil, err
+	}
+
+	var shipment *domain.Shipment
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		// Bumping the order first makes concurrent shipments and cancellations
+		// of it conflict, so the checks below see their results.
+		current, err := uc.lockOrder(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if !current.Status.CanShip() {
+			return fmt.Errorf("%w: cannot ship %s order %s", domain.ErrFailedPrecondition, current.Status, dto.OrderID)
+		}
+
+		items, err := uc.shipmentItems(ctx, current, dto.Items)
+		if err != nil {
+			return err
+		}
+
+		shipment = &domain.Shipment{
+			ID:             primitive.NewObjectID(),
+			OrderID:        order.ID,
+			UserID:         order.UserID,
+			Items:          items,
+			Address:        address,
+			Carrier:        dto.Carrier,
+			TrackingNumber: dto.TrackingNumber,
+			Status:         domain.ShipmentStatusPending,
+			StatusHistory: []domain.ShipmentTransition{{
+				To:    domain.ShipmentStatusPending,
+				Actor: actor(ctx),
+				At:    time.Now(),
+			}},
+		}
+		if err := uc.shipmentRepo.CreateShipment(ctx, shipment); err != nil {
+			return fmt.Errorf("failed to create shipment: %w", err)
+		}
+		return uc.advanceOrder(ctx, current, domain.OrderStatusProcessing)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// shipmentItems validates the requested items against the order and its
+// other shipments and merges duplicate products. Without requested items it
+// returns everything that is left to ship.
+func (uc *shipmentUseCase) shipmentItems(ctx context.Context, order *domain.Order, requested []dto.OrderItemDTO) ([]domain.ShipmentItem, error) {
+	existing, err := uc.shipmentRepo.GetShipmentsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipments: %w", err)
+	}
+	shipped := domain.ShippedQuantities(existing)
+	ordered := order.Quantities()
+
+	var items []domain.ShipmentItem
+	if len(requested) == 0 {
+		for _, line := range order.Items {
+			if left := ordered[line.ProductID] - shipped[line.ProductID]; left > 0 {
+				items = append(items, domain.ShipmentItem{ProductID: line.ProductID, Quantity: left})
+				shipped[line.ProductID] += left
+			}
+		}
+		if len(items) == 0 {
+			return nil, fmt.Errorf("%w: every item of order %s is already in a shipment", domain.ErrFailedPrecondition, order.ID.Hex())
+		}
+		return items, nil
+	}
+
+	index := make(map[primitive.ObjectID]int)
+	for _, item := range requested {
+		productID, err := primitive.ObjectIDFromHex(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid product ID %q", domain.ErrInvalidArgument, item.ProductID)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity for product %s must be positive", domain.ErrInvalidArgument, item.ProductID)
+		}
+		if i, ok := index[productID]; ok {
+			items[i].Quantity += item.Quantity
+			continue
+		}
+		index[productID] = len(items)
+		items = append(items, domain.ShipmentItem{ProductID: productID, Quantity: item.Quantity})
+	}
+
+	for _, item := range items {
+		if ordered[item.ProductID] == 0 {
+			return nil, fmt.Errorf("%w: product %s is not part of order %s", domain.ErrInvalidArgument, item.ProductID.Hex(), order.ID.Hex())
+		}
+		if left := ordered[item.ProductID] - shipped[item.ProductID]; item.Quantity > left {
+			return nil, fmt.Errorf("%w: only %d units of product %s are left to ship", domain.ErrFailedPrecondition, left, item.ProductID.Hex())
+		}
+	}
+	return items, nil
+}
+
+func (uc *shipmentUseCase) GetShipment(ctx context.Context, id string) (*domain.Shipment, error) {
+	return uc.getShipment(ctx, id)
+}
+
+func (uc *shipmentUseCase) ListOrderShipments(ctx context.Context, orderID string) ([]domain.Shipment, error) {
+	order, err := uc.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments, err := uc.shipmentRepo.GetShipmentsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipments: %w", err)
+	}
+	return shipments, nil
+}
+
+// SetShipmentTracking sets the carrier and tracking number of a shipment that
+// was not delivered or cancelled yet.
+func (uc *shipmentUseCase) SetShipmentTracking(ctx context.Context, id, carrier, trackingNumber string) (*domain.Shipment, error) {
+	if !auth.HasRole(ctx, auth.RoleAdmin) {
+		return nil, fmt.Errorf("%w: admin role required", domain.ErrPermissionDenied)
+	}
+	if strings.TrimSpace(trackingNumber) == "" {
+		return nil, fmt.Errorf("%w: tracking number is required", domain.ErrInvalidArgument)
+	}
+
+	shipment, err := uc.getShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.Status.Final() {
+		return nil, fmt.Errorf("%w: shipment %s is %s", domain.ErrFailedPrecondition, id, shipment.Status)
+	}
+
+	ok, err := uc.shipmentRepo.SetTracking(ctx, shipment.ID, carrier, trackingNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update shipment: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: shipment %s was changed concurrently", domain.ErrConflict, id)
+	}
+	return uc.getShipment(ctx, id)
+}
+
+// UpdateShipmentStatus moves a shipment along its lifecycle and moves the
+// order to shipped or delivered once all of its shipments got there. The
+// shipments of cancelled and refunded orders no longer change.
+func (uc *shipmentUseCase) UpdateShipmentStatus(ctx context.Context, id, status string) (*domain.Shipment, error) {
+	if !auth.HasRole(ctx, auth.RoleAdmin) {
+		return nil, fmt.Errorf("%w: admin role required", domain.ErrPermissionDenied)
+	}
+
+	shipmentStatus, err := domain.ParseShipmentStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment, err := uc.getShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := shipment.Status.ValidateTransition(shipmentStatus); err != nil {
+		return nil, err
+	}
+
+	transition := domain.ShipmentTransition{
+		From:  shipment.Status,
+		To:    shipmentStatus,
+		Actor: actor(ctx),
+		At:    time.Now(),
+	}
+
+	var updated *domain.Shipment
+	err = uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		order, err := uc.lockOrder(ctx, shipment.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status == domain.OrderStatusCancelled || order.Status == domain.OrderStatusRefunded {
+			return fmt.Errorf("%w: order %s is %s", domain.ErrFailedPrecondition, order.ID.Hex(), order.Status)
+		}
+
+		ok, err := uc.shipmentRepo.TransitionStatus(ctx, shipment.ID, transition)
+		if err != nil {
+			return fmt.Errorf("failed to update shipment status: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: shipment %s was changed concurrently", domain.ErrConflict, id)
+		}
+
+		shipments, err := uc.shipmentRepo.GetShipmentsByOrderID(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get shipments: %w", err)
+		}
+		if target, ok := domain.FulfilmentStatus(order, shipments); ok {
+			if err := uc.advanceOrder(ctx, order, target); err != nil {
+				return err
+			}
+		}
+
+		updated, err = uc.shipmentRepo.GetShipmentByID(ctx, shipment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch updated shipment: %w", err)
+		}
+		if updated == nil {
+			return domain.ErrShipmentNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// advanceOrder moves order forward along the fulfilment lifecycle to target,
+// passing the statuses in between, and publishes the updated order. An order
+// that is already at or past target is left as it is.
+func (uc *shipmentUseCase) advanceOrder(ctx context.Context, order *domain.Order, target domain.OrderStatus) error {
+	path, ok := domain.FulfilmentPath(order.Status, target)
+	if !ok {
+		return nil
+	}
+
+	status := order.Status
+	for _, next := range path {
+		ok, err := uc.orderRepo.TransitionStatus(ctx, order.ID, domain.StatusTransition{
+			From:  status,
+			To:    next,
+			Actor: actor(ctx),
+			At:    time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: order %s was changed concurrently", domain.ErrConflict, order.ID.Hex())
+		}
+		status = next
+	}
+
+	updated, err := uc.orderRepo.GetOrderByID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch updated order: %w", err)
+	}
+	if updated == nil {
+		return domain.ErrOrderNotFound
+	}
+	return uc.eventProducer.Push(ctx, updated, pb.OrderEventType_UPDATED)
+}
+
+// lockOrder bumps the version of an order inside a transaction and returns
+// the order as the transaction sees it.
+func (uc *shipmentUseCase) lockOrder(ctx context.Context, id primitive.ObjectID) (*domain.Order, error) {
+	if err := uc.orderRepo.BumpVersion(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (uc *shipmentUseCase) getShipment(ctx context.Context, id string) (*domain.Shipment, error) {
+	shipmentID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid shipment ID %q", domain.ErrInvalidArgument, id)
+	}
+
+	shipment, err := uc.shipmentRepo.GetShipmentByID(ctx, shipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	if shipment == nil || !canAccessUser(ctx, shipment.UserID) {
+		return nil, domain.ErrShipmentNotFound
+	}
+	return shipment, nil
+}
+
+func (uc *shipmentUseCase) getOrder(ctx context.Context, id string) (*domain.Order, error) {
+	orderID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid order ID %q", domain.ErrInvalidArgument, id)
+	}
+
+	order, err := uc.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil || !canAccessOrder(ctx, order) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	MetadataUserID    = "x-user-id"
+	MetadataRoles     = "x-user-roles"
+	MetadataRequestID = "x-request-id"
+
+	RoleAdmin = "admin"
+)
+
+type contextKey string
+
+const (
+	userIDKey    contextKey = "userID"
+	rolesKey     contextKey = "roles"
+	requestIDKey contextKey = "requestID"
+)
+
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesKey, roles)
+}
+
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey).(string)
+	return userID, ok && userID != ""
+}
+
+func RolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(rolesKey).([]string)
+	return roles
+}
+
+func HasRole(ctx context.Context, role string) bool {
+	return slices.Contains(RolesFromContext(ctx), role)
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the ID the gateway assigned to the request that
+// led to the current call.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey).(string)
+	return requestID, ok && requestID != ""
+}
+
+// UnaryServerInterceptor copies the identity and request ID forwarded by the
+// gateway from the incoming gRPC metadata into the request context.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if values := md.Get(MetadataUserID); len(values) > 0 && values[0] != "" {
+			ctx = WithUserID(ctx, values[0])
+		}
+		if values := md.Get(MetadataRoles); len(values) > 0 && values[0] != "" {
+			ctx = WithRoles(ctx, strings.Split(values[0], ","))
+		}
+		if values := md.Get(MetadataRequestID); len(values) > 0 && values[0] != "" {
+			ctx = WithRequestID(ctx, values[0])
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,25 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WithTransaction runs fn in a multi-document transaction and commits it when
+// fn returns nil. The context passed to fn carries the session, so repository
+// calls made with it take part in the transaction. Transactions require
+// MongoDB to run as a replica set.
+func (db *DB) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := db.Client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start mongo session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
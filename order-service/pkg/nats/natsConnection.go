@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 type Client struct {
@@ -40,3 +41,11 @@ func (c *Client) Close() {
 		c.Conn.Close()
 	}
 }
+
+func (c *Client) JetStream() (jetstream.JetStream, error) {
+	js, err := jetstream.New(c.Conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	return js, nil
+}
@@ -1,376 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.6
-// 	protoc        v3.21.12
-// source: events/events.proto
-
-package events
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-type OrderEventType int32
-
-const (
-	OrderEventType_CREATED   OrderEventType = 0
-	OrderEventType_UPDATED   OrderEventType = 1
-	OrderEventType_CANCELLED OrderEventType = 2
-	OrderEventType_DELETED   OrderEventType = 3
-)
-
-// Enum value maps for OrderEventType.
-var (
-	OrderEventType_name = map[int32]string{
-		0: "CREATED",
-		1: "UPDATED",
-		2: "CANCELLED",
-		3: "DELETED",
-	}
-	OrderEventType_value = map[string]int32{
-		"CREATED":   0,
-		"UPDATED":   1,
-		"CANCELLED": 2,
-		"DELETED":   3,
-	}
-)
-
-func (x OrderEventType) Enum() *OrderEventType {
-	p := new(OrderEventType)
-	*p = x
-	return p
-}
-
-func (x OrderEventType) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (OrderEventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_events_events_proto_enumTypes[0].Descriptor()
-}
-
-func (OrderEventType) Type() protoreflect.EnumType {
-	return &file_events_events_proto_enumTypes[0]
-}
-
-func (x OrderEventType) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use OrderEventType.Descriptor instead.
-func (OrderEventType) EnumDescriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{0}
-}
-
-type OrderStatus int32
-
-const (
-	OrderStatus_S_PENDING   OrderStatus = 0
-	OrderStatus_S_COMPLETED OrderStatus = 1
-	OrderStatus_S_CANCELLED OrderStatus = 2
-)
-
-// Enum value maps for OrderStatus.
-var (
-	OrderStatus_name = map[int32]string{
-		0: "S_PENDING",
-		1: "S_COMPLETED",
-		2: "S_CANCELLED",
-	}
-	OrderStatus_value = map[string]int32{
-		"S_PENDING":   0,
-		"S_COMPLETED": 1,
-		"S_CANCELLED": 2,
-	}
-)
-
-func (x OrderStatus) Enum() *OrderStatus {
-	p := new(OrderStatus)
-	*p = x
-	return p
-}
-
-func (x OrderStatus) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
-}
-
-func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_events_events_proto_enumTypes[1].Descriptor()
-}
-
-func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_events_events_proto_enumTypes[1]
-}
-
-func (x OrderStatus) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
-}
-
-// Deprecated: Use OrderStatus.Descriptor instead.
-func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{1}
-}
-
-type OrderEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	Total         float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=events.OrderStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	EventType     OrderEventType         `protobuf:"varint,8,opt,name=event_type,json=eventType,proto3,enum=events.OrderEventType" json:"event_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *OrderEvent) Reset() {
-	*x = OrderEvent{}
-	mi := &file_events_events_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *OrderEvent) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*OrderEvent) ProtoMessage() {}
-
-func (x *OrderEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
-func (*OrderEvent) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *OrderEvent) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *OrderEvent) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *OrderEvent) GetItems() []*OrderItem {
-	if x != nil {
-		return x.Items
-	}
-	return nil
-}
-
-func (x *OrderEvent) GetTotal() float64 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-func (x *OrderEvent) GetStatus() OrderStatus {
-	if x != nil {
-		return x.Status
-	}
-	return OrderStatus_S_PENDING
-}
-
-func (x *OrderEvent) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-func (x *OrderEvent) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
-}
-
-func (x *OrderEvent) GetEventType() OrderEventType {
-	if x != nil {
-		return x.EventType
-	}
-	return OrderEventType_CREATED
-}
-
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_events_events_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *OrderItem) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*OrderItem) ProtoMessage() {}
-
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_events_events_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_events_events_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *OrderItem) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
-}
-
-func (x *OrderItem) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-func (x *OrderItem) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-var File_events_events_proto protoreflect.FileDescriptor
-
-const file_events_events_proto_rawDesc = "" +
-	"\n" +
-	"\x13events/events.proto\x12\x06events\x1a\x1fgoogle/protobuf/timestamp.proto\"\xce\x02\n" +
-	"\n" +
-	"OrderEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
-	"\x05items\x18\x03 \x03(\v2\x11.events.OrderItemR\x05items\x12\x14\n" +
-	"\x05total\x18\x04 \x01(\x01R\x05total\x12+\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x13.events.OrderStatusR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x125\n" +
-	"\n" +
-	"event_type\x18\b \x01(\x0e2\x16.events.OrderEventTypeR\teventType\"\\\n" +
-	"\tOrderItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price*F\n" +
-	"\x0eOrderEventType\x12\v\n" +
-	"\aCREATED\x10\x00\x12\v\n" +
-	"\aUPDATED\x10\x01\x12\r\n" +
-	"\tCANCELLED\x10\x02\x12\v\n" +
-	"\aDELETED\x10\x03*>\n" +
-	"\vOrderStatus\x12\r\n" +
-	"\tS_PENDING\x10\x00\x12\x0f\n" +
-	"\vS_COMPLETED\x10\x01\x12\x0f\n" +
-	"\vS_CANCELLED\x10\x02BRZPgithub.com/mephirious/advanced-programming-2/order-service/pkg/api/events;eventsb\x06proto3"
-
-var (
-	file_events_events_proto_rawDescOnce sync.Once
-	file_events_events_proto_rawDescData []byte
-)
-
-func file_events_events_proto_rawDescGZIP() []byte {
-	file_events_events_proto_rawDescOnce.Do(func() {
-		file_events_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_events_proto_rawDesc), len(file_events_events_proto_rawDesc)))
-	})
-	return file_events_events_proto_rawDescData
-}
-
-var file_events_events_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_events_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_events_events_proto_goTypes = []any{
-	(OrderEventType)(0),           // 0: events.OrderEventType
-	(OrderStatus)(0),              // 1: events.OrderStatus
-	(*OrderEvent)(nil),            // 2: events.OrderEvent
-	(*OrderItem)(nil),             // 3: events.OrderItem
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
-}
-var file_events_events_proto_depIdxs = []int32{
-	3, // 0: events.OrderEvent.items:type_name -> events.OrderItem
-	1, // 1: events.OrderEvent.status:type_name -> events.OrderStatus
-	4, // 2: events.OrderEvent.created_at:type_name -> google.protobuf.Timestamp
-	4, // 3: events.OrderEvent.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 4: events.OrderEvent.event_type:type_name -> events.OrderEventType
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
-}
-
-func init() { file_events_events_proto_init() }
-func file_events_events_proto_init() {
-	if File_events_events_proto != nil {
-		return
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_events_proto_rawDesc), len(file_events_events_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   2,
-			NumExtensions: 0,
-			NumServices:   0,
-		},
-		GoTypes:           file_events_events_proto_goTypes,
-		DependencyIndexes: file_events_events_proto_depIdxs,
-		EnumInfos:         file_events_events_proto_enumTypes,
-		MessageInfos:      file_events_events_proto_msgTypes,
-	}.Build()
-	File_events_events_proto = out.File
-	file_events_events_proto_goTypes = nil
-	file_events_events_proto_depIdxs = nil
-}
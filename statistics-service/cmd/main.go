@@ -2,10 +2,14 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"os"
+	_ "time/tzdata"
 
 	"github.com/mephirious/advanced-programming-2/statistics-service/config"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/app"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
 )
 
 func main() {
@@ -17,6 +21,13 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "rebuild" {
+		if err := rebuild(ctx, cfg, os.Args[2:]); err != nil {
+			log.Fatalf("failed to rebuild projections: %v", err)
+		}
+		return
+	}
+
 	application, err := app.New(ctx, cfg)
 	if err != nil {
 		log.Printf("failed to setup application: %v", err)
@@ -29,3 +40,20 @@ func main() {
 		return
 	}
 }
+
+// rebuild runs the rebuild subcommand:
+//
+//	statistics-service rebuild [-source events|stream]
+func rebuild(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("rebuild", flag.ExitOnError)
+	sourceFlag := flags.String("source", string(domain.RebuildFromEvents), "events to rebuild from: events or stream")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	source, err := domain.ParseRebuildSource(*sourceFlag)
+	if err != nil {
+		return err
+	}
+	return app.Rebuild(ctx, cfg, source)
+}
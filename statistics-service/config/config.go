@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/mephirious/advanced-programming-2/statistics-service/pkg/mongo"
@@ -28,6 +29,15 @@ type (
 
 	NATSConfig struct {
 		URL string `env:"NATS_URL,required"`
+
+		// MaxDeliver bounds how often a failing message is delivered.
+		MaxDeliver int           `env:"NATS_MAX_DELIVER" envDefault:"5"`
+		AckWait    time.Duration `env:"NATS_ACK_WAIT" envDefault:"30s"`
+
+		// ReplayFromSequence or ReplayFromTime recreate the durable consumers
+		// so that they deliver the streams again from that position.
+		ReplayFromSequence uint64    `env:"NATS_REPLAY_FROM_SEQUENCE"`
+		ReplayFromTime     time.Time `env:"NATS_REPLAY_FROM_TIME"`
 	}
 )
 
@@ -61,5 +71,45 @@ func New() (*Config, error) {
 
 	cfg.NATS.URL = os.Getenv("NATS_URL")
 
+	cfg.NATS.MaxDeliver = 5
+	if v := os.Getenv("NATS_MAX_DELIVER"); v != "" {
+		cfg.NATS.MaxDeliver, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS_MAX_DELIVER value: %w", err)
+		}
+	}
+	cfg.NATS.AckWait, err = durationEnv("NATS_ACK_WAIT", 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	if v := os.Getenv("NATS_REPLAY_FROM_SEQUENCE"); v != "" {
+		cfg.NATS.ReplayFromSequence, err = strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS_REPLAY_FROM_SEQUENCE value: %w", err)
+		}
+	}
+	if v := os.Getenv("NATS_REPLAY_FROM_TIME"); v != "" {
+		cfg.NATS.ReplayFromTime, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NATS_REPLAY_FROM_TIME value: %w", err)
+		}
+	}
+	if cfg.NATS.ReplayFromSequence > 0 && !cfg.NATS.ReplayFromTime.IsZero() {
+		return nil, fmt.Errorf("NATS_REPLAY_FROM_SEQUENCE and NATS_REPLAY_FROM_TIME are mutually exclusive")
+	}
+
 	return &cfg, nil
 }
+
+func durationEnv(key string, defaultVal time.Duration) (time.Duration, error) {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return d, nil
+}
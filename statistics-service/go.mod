@@ -22,11 +22,14 @@ require (
 )
 
 require (
+	github.com/mephirious/advanced-programming-2/contracts v0.0.0
 	github.com/nats-io/nats.go v1.42.0
 	go.mongodb.org/mongo-driver v1.17.3
 	golang.org/x/net v0.35.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
-	google.golang.org/protobuf v1.36.5 // indirect
+	google.golang.org/protobuf v1.36.5
 )
+
+replace github.com/mephirious/advanced-programming-2/contracts => ../contracts
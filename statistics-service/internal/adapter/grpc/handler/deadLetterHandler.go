@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/contracts/events"
+	"github.com/mephirious/advanced-programming-2/contracts/streams"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/statistics-service/pkg/auth"
+	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *GRPCHandler) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	deadLetters, total, err := h.deadLetters.ListDeadLetters(ctx, domain.DeadLetterFilter{
+		Subject:         req.Subject,
+		IncludeReplayed: req.IncludeReplayed,
+		Page:            int(req.Page),
+		Limit:           int(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListDeadLettersResponse{Total: total}
+	for i := range deadLetters {
+		res.DeadLetters = append(res.DeadLetters, mapDeadLetterToProto(&deadLetters[i]))
+	}
+	return res, nil
+}
+
+func (h *GRPCHandler) GetDeadLetter(ctx context.Context, req *pb.DeadLetterRequest) (*pb.DeadLetterResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	deadLetter, err := h.deadLetters.GetDeadLetter(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return deadLetterResponse(deadLetter), nil
+}
+
+func (h *GRPCHandler) ReplayDeadLetter(ctx context.Context, req *pb.DeadLetterRequest) (*pb.DeadLetterResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	deadLetter, err := h.deadLetters.ReplayDeadLetter(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return deadLetterResponse(deadLetter), nil
+}
+
+func (h *GRPCHandler) PurgeDeadLetters(ctx context.Context, req *pb.PurgeDeadLettersRequest) (*pb.PurgeDeadLettersResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	purged, err := h.deadLetters.PurgeDeadLetters(ctx, req.Ids, req.ReplayedOnly, req.All)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PurgeDeadLettersResponse{Purged: purged}, nil
+}
+
+func requireAdmin(ctx context.Context) error {
+	if !auth.HasRole(ctx, auth.RoleAdmin) {
+		return status.Error(codes.PermissionDenied, "admin role required")
+	}
+	return nil
+}
+
+func deadLetterResponse(deadLetter *domain.DeadLetter) *pb.DeadLetterResponse {
+	return &pb.DeadLetterResponse{
+		DeadLetter:  mapDeadLetterToProto(deadLetter),
+		PayloadJson: decodePayload(deadLetter.Subject, deadLetter.Headers, deadLetter.Payload),
+	}
+}
+
+// decodePayload renders the protobuf payload of a dead letter as JSON for
+// inspection, in the schema version named by its headers. Payloads that do
+// not decode are returned as an empty string.
+func decodePayload(subject string, headers map[string]string, payload []byte) string {
+	version := headers[events.HeaderSchemaVersion]
+	var msg proto.Message
+	var err error
+	switch subject {
+	case streams.Orders.Subjects[0]:
+		msg, err = events.DecodeOrderEvent(version, payload)
+	case streams.Inventory.Subjects[0]:
+		msg, err = events.DecodeInventoryEvent(version, payload)
+	default:
+		return ""
+	}
+	if err != nil {
+		return ""
+	}
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func mapDeadLetterToProto(deadLetter *domain.DeadLetter) *pb.DeadLetter {
+	res := &pb.DeadLetter{
+		Id:       deadLetter.ID.Hex(),
+		Stream:   deadLetter.Stream,
+		Subject:  deadLetter.Subject,
+		Sequence: deadLetter.Sequence,
+		Headers:  deadLetter.Headers,
+		Payload:  deadLetter.Payload,
+		Error:    deadLetter.Error,
+		Attempts: int32(deadLetter.Attempts),
+		FailedAt: timestamppb.New(deadLetter.FailedAt),
+	}
+	if deadLetter.ReplayedAt != nil {
+		res.ReplayedAt = timestamppb.New(*deadLetter.ReplayedAt)
+	}
+	return res
+}
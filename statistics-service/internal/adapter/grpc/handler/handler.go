@@ -3,8 +3,10 @@ package handler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/usecase"
+	"github.com/mephirious/advanced-programming-2/statistics-service/pkg/auth"
 	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -12,21 +14,31 @@ import (
 
 type GRPCHandler struct {
 	pb.UnimplementedStatisticsServiceServer
-	uc usecase.StatsUseCase
+	uc          usecase.StatsUseCase
+	sales       usecase.SalesUseCase
+	series      usecase.TimeSeriesUseCase
+	deadLetters usecase.DeadLetterUseCase
+	rebuilds    usecase.RebuildUseCase
 }
 
-func NewGRPCHandler(uc usecase.StatsUseCase) *GRPCHandler {
-	return &GRPCHandler{uc: uc}
+func NewGRPCHandler(uc usecase.StatsUseCase, sales usecase.SalesUseCase, series usecase.TimeSeriesUseCase, deadLetters usecase.DeadLetterUseCase, rebuilds usecase.RebuildUseCase) *GRPCHandler {
+	return &GRPCHandler{uc: uc, sales: sales, series: series, deadLetters: deadLetters, rebuilds: rebuilds}
 }
 
 func (h *GRPCHandler) GetUserOrdersStatistics(ctx context.Context, req *pb.UserOrderStatisticsRequest) (*pb.UserOrderStatisticsResponse, error) {
-	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	userID, err := authorizeUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
 	}
 
-	stats, err := h.uc.GetUserOrderStatistics(ctx, req.UserId)
+	loc, err := loadLocation(req.Timezone)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get order stats: %v", err)
+		return nil, err
+	}
+
+	stats, err := h.uc.GetUserOrderStatistics(ctx, userID, loc)
+	if err != nil {
+		return nil, err
 	}
 
 	hourly := make(map[string]int32)
@@ -43,13 +55,23 @@ func (h *GRPCHandler) GetUserOrdersStatistics(ctx context.Context, req *pb.UserO
 }
 
 func (h *GRPCHandler) GetUserStatistics(ctx context.Context, req *pb.UserStatisticsRequest) (*pb.UserStatisticsResponse, error) {
-	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	userID, err := authorizeUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
 	}
 
-	stats, err := h.uc.GetUserOrderStatistics(ctx, req.UserId)
+	loc, err := loadLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := h.uc.GetUserOrderStatistics(ctx, userID, loc)
+	if err != nil {
+		return nil, err
+	}
+	global, err := h.uc.GetGlobalOrderStatistics(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get user stats: %v", err)
+		return nil, err
 	}
 
 	var mostActiveHour int
@@ -62,13 +84,39 @@ func (h *GRPCHandler) GetUserStatistics(ctx context.Context, req *pb.UserStatist
 	}
 
 	return &pb.UserStatisticsResponse{
-		UserId:         req.UserId,
-		TotalUsers:     int32(stats.TotalOrders),
+		UserId:         userID,
+		TotalUsers:     int32(global.TotalUsers),
 		UserOrderCount: int32(stats.TotalOrders),
 		MostActiveHour: int32(mostActiveHour),
 	}, nil
 }
 
+// authorizeUser resolves the user whose statistics are requested. Callers
+// authenticated by the gateway may only read their own statistics unless they
+// are admins.
+func authorizeUser(ctx context.Context, userID string) (string, error) {
+	authUserID, ok := auth.UserIDFromContext(ctx)
+	if ok && userID == "" {
+		userID = authUserID
+	}
+	if userID == "" {
+		return "", status.Error(codes.InvalidArgument, "user ID is required")
+	}
+	if ok && userID != authUserID && !auth.HasRole(ctx, auth.RoleAdmin) {
+		return "", status.Error(codes.PermissionDenied, "not authorized to view other users' statistics")
+	}
+	return userID, nil
+}
+
+// loadLocation loads an IANA time zone, UTC for the empty name.
+func loadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
 func formatHour(hour int) string {
 	return fmt.Sprintf("%02d:00", hour)
 }
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RebuildProjections starts a rebuild and returns right away. Its progress is
+// polled with GetRebuild.
+func (h *GRPCHandler) RebuildProjections(ctx context.Context, req *pb.RebuildProjectionsRequest) (*pb.RebuildResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	source, err := domain.ParseRebuildSource(req.Source)
+	if err != nil {
+		return nil, err
+	}
+	rebuild, err := h.rebuilds.StartRebuild(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RebuildResponse{Rebuild: mapRebuildToProto(rebuild)}, nil
+}
+
+func (h *GRPCHandler) GetRebuild(ctx context.Context, req *pb.GetRebuildRequest) (*pb.RebuildResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	rebuild, err := h.rebuilds.GetRebuild(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RebuildResponse{Rebuild: mapRebuildToProto(rebuild)}, nil
+}
+
+func mapRebuildToProto(rebuild *domain.Rebuild) *pb.Rebuild {
+	res := &pb.Rebuild{
+		Id:        rebuild.ID.Hex(),
+		Source:    string(rebuild.Source),
+		State:     string(rebuild.State),
+		Total:     rebuild.Total,
+		Processed: rebuild.Processed,
+		Progress:  rebuild.Progress(),
+		Error:     rebuild.Error,
+		StartedAt: timestamppb.New(rebuild.StartedAt),
+		UpdatedAt: timestamppb.New(rebuild.UpdatedAt),
+	}
+	if rebuild.FinishedAt != nil {
+		res.FinishedAt = timestamppb.New(*rebuild.FinishedAt)
+	}
+	return res
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *GRPCHandler) GetRevenue(ctx context.Context, req *pb.RevenueRequest) (*pb.RevenueResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	granularity, err := domain.ParseGranularity(req.Granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := h.sales.GetRevenue(ctx, dateRange(req.From, req.To), granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.RevenueResponse{}
+	for _, point := range points {
+		res.Points = append(res.Points, &pb.RevenuePoint{
+			PeriodStart: timestamppb.New(point.PeriodStart),
+			Revenue:     point.Revenue,
+			Orders:      int32(point.Orders),
+		})
+		res.TotalRevenue += point.Revenue
+	}
+	return res, nil
+}
+
+func (h *GRPCHandler) GetRevenueSummary(ctx context.Context, req *pb.RevenueSummaryRequest) (*pb.RevenueSummaryResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	summary, err := h.sales.GetRevenueSummary(ctx, dateRange(req.From, req.To))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevenueSummaryResponse{
+		Revenue:           summary.Revenue,
+		Orders:            int32(summary.Orders),
+		AverageOrderValue: summary.AverageOrderValue,
+		CompletedRevenue:  summary.CompletedRevenue,
+		CompletedOrders:   int32(summary.CompletedOrders),
+		CancelledRevenue:  summary.CancelledRevenue,
+		CancelledOrders:   int32(summary.CancelledOrders),
+		RefundedRevenue:   summary.RefundedRevenue,
+		NetRevenue:        summary.NetRevenue,
+	}, nil
+}
+
+func (h *GRPCHandler) GetProductSales(ctx context.Context, req *pb.ProductSalesRequest) (*pb.ProductSalesResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	sales, err := h.sales.GetProductSales(ctx, dateRange(req.From, req.To), int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ProductSalesResponse{}
+	for i := range sales {
+		res.Products = append(res.Products, mapProductSalesToProto(&sales[i]))
+	}
+	return res, nil
+}
+
+func (h *GRPCHandler) GetCategorySales(ctx context.Context, req *pb.CategorySalesRequest) (*pb.CategorySalesResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	sales, err := h.sales.GetCategorySales(ctx, dateRange(req.From, req.To))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.CategorySalesResponse{}
+	for _, category := range sales {
+		res.Categories = append(res.Categories, &pb.CategorySales{
+			CategoryId: category.CategoryID,
+			Units:      int32(category.Units),
+			Revenue:    category.Revenue,
+			Orders:     int32(category.Orders),
+		})
+	}
+	return res, nil
+}
+
+func (h *GRPCHandler) GetProductRefunds(ctx context.Context, req *pb.ProductRefundsRequest) (*pb.ProductRefundsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	refunds, err := h.sales.GetProductRefunds(ctx, dateRange(req.From, req.To), int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ProductRefundsResponse{}
+	for _, product := range refunds {
+		res.Products = append(res.Products, &pb.ProductRefunds{
+			ProductId:      product.ProductID,
+			Name:           product.Name,
+			Units:          int32(product.Units),
+			RefundedUnits:  int32(product.RefundedUnits),
+			RefundedAmount: product.RefundedAmount,
+			RefundRate:     product.RefundRate,
+		})
+	}
+	return res, nil
+}
+
+func (h *GRPCHandler) GetTopProducts(ctx context.Context, req *pb.TopProductsRequest) (*pb.TopProductsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	metric, err := domain.ParseSalesMetric(req.RankBy)
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := h.sales.GetTopProducts(ctx, domain.ProductSalesQuery{
+		Range:      dateRange(req.From, req.To),
+		SortBy:     metric,
+		CategoryID: req.CategoryId,
+		Limit:      int(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.TopProductsResponse{}
+	for i := range sales {
+		res.Products = append(res.Products, mapProductSalesToProto(&sales[i]))
+	}
+	return res, nil
+}
+
+func (h *GRPCHandler) GetTrendingProducts(ctx context.Context, req *pb.TrendingProductsRequest) (*pb.TrendingProductsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	metric, err := domain.ParseSalesMetric(req.RankBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var end time.Time
+	if req.To != nil {
+		end = req.To.AsTime()
+	}
+	window := time.Duration(req.WindowDays) * 24 * time.Hour
+
+	trends, err := h.sales.GetTrendingProducts(ctx, end, window, domain.ProductSalesQuery{
+		SortBy:     metric,
+		CategoryID: req.CategoryId,
+		Limit:      int(req.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.TrendingProductsResponse{}
+	for i := range trends {
+		trend := &trends[i]
+		res.Products = append(res.Products, &pb.ProductTrend{
+			ProductId: trend.ProductID,
+			Name:      trend.Name,
+			Current:   mapProductSalesToProto(&trend.Current),
+			Previous:  mapProductSalesToProto(&trend.Previous),
+			Growth:    trend.Growth,
+			New:       trend.New,
+		})
+	}
+	return res, nil
+}
+
+func mapProductSalesToProto(sales *domain.ProductSales) *pb.ProductSales {
+	return &pb.ProductSales{
+		ProductId: sales.ProductID,
+		Name:      sales.Name,
+		Units:     int32(sales.Units),
+		Revenue:   sales.Revenue,
+		Orders:    int32(sales.Orders),
+	}
+}
+
+// dateRange converts optional request timestamps. Missing bounds stay zero and
+// are filled in by the use case.
+func dateRange(from, to *timestamppb.Timestamp) domain.DateRange {
+	var dateRange domain.DateRange
+	if from != nil {
+		dateRange.From = from.AsTime()
+	}
+	if to != nil {
+		dateRange.To = to.AsTime()
+	}
+	return dateRange
+}
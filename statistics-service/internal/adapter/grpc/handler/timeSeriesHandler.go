@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *GRPCHandler) GetTimeSeries(ctx context.Context, req *pb.TimeSeriesRequest) (*pb.TimeSeriesResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	metric, err := domain.ParseMetric(req.Metric)
+	if err != nil {
+		return nil, err
+	}
+	granularity, err := domain.ParseGranularity(req.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := loadLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := h.series.GetTimeSeries(ctx, domain.TimeSeriesQuery{
+		Metric:      metric,
+		Range:       dateRange(req.From, req.To),
+		Granularity: granularity,
+		Location:    loc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.TimeSeriesResponse{
+		Metric:      string(metric),
+		Granularity: string(granularity),
+		Timezone:    loc.String(),
+		Points:      make([]*pb.TimeSeriesPoint, len(points)),
+	}
+	for i, point := range points {
+		res.Points[i] = &pb.TimeSeriesPoint{
+			Start:      timestamppb.New(point.Start),
+			LocalStart: point.Start.In(loc).Format(time.RFC3339),
+			Value:      point.Value,
+		}
+	}
+	return res, nil
+}
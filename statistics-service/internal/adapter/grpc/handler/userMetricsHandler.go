@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *GRPCHandler) GetActiveUsers(ctx context.Context, req *pb.ActiveUsersRequest) (*pb.ActiveUsersResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	granularity, err := domain.ParseGranularity(req.Granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := h.uc.GetActiveUsers(ctx, dateRange(req.From, req.To), granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ActiveUsersResponse{Points: make([]*pb.ActiveUsersPoint, len(points))}
+	for i, point := range points {
+		res.Points[i] = &pb.ActiveUsersPoint{
+			Start:          timestamppb.New(point.Start),
+			ActiveUsers:    int32(point.Active),
+			NewUsers:       int32(point.New),
+			ReturningUsers: int32(point.Returning),
+		}
+	}
+	return res, nil
+}
+
+func (h *GRPCHandler) GetCohortRetention(ctx context.Context, req *pb.CohortRetentionRequest) (*pb.CohortRetentionResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	cohorts, err := h.uc.GetCohortRetention(ctx, dateRange(req.From, req.To))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.CohortRetentionResponse{}
+	for _, cohort := range cohorts {
+		pbCohort := &pb.Cohort{
+			Start: timestamppb.New(cohort.Start),
+			Size:  int32(cohort.Size),
+		}
+		for _, period := range cohort.Retention {
+			pbCohort.Retention = append(pbCohort.Retention, &pb.CohortPeriod{
+				MonthOffset: int32(period.Offset),
+				Users:       int32(period.Users),
+				Rate:        period.Rate,
+			})
+		}
+		res.Cohorts = append(res.Cohorts, pbCohort)
+	}
+	return res, nil
+}
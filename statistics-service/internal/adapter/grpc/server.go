@@ -7,6 +7,7 @@ import (
 
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/adapter/grpc/handler"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/usecase"
+	"github.com/mephirious/advanced-programming-2/statistics-service/pkg/auth"
 	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -17,14 +18,14 @@ type Server struct {
 	listener net.Listener
 }
 
-func NewServer(port int, uc usecase.StatsUseCase) (*Server, error) {
+func NewServer(port int, uc usecase.StatsUseCase, salesUC usecase.SalesUseCase, seriesUC usecase.TimeSeriesUseCase, deadLetterUC usecase.DeadLetterUseCase, rebuildUC usecase.RebuildUseCase) (*Server, error) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	handler := handler.NewGRPCHandler(uc)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(), errorInterceptor))
+	handler := handler.NewGRPCHandler(uc, salesUC, seriesUC, deadLetterUC, rebuildUC)
 	pb.RegisterStatisticsServiceServer(grpcServer, handler)
 	reflection.Register(grpcServer)
 
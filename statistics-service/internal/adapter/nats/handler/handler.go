@@ -2,72 +2,406 @@ package handler
 
 import (
 	"context"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
+	"github.com/mephirious/advanced-programming-2/contracts/events"
+	inventoryv1 "github.com/mephirious/advanced-programming-2/contracts/events/inventory/v1"
+	orderv1 "github.com/mephirious/advanced-programming-2/contracts/events/order/v1"
+	"github.com/mephirious/advanced-programming-2/contracts/streams"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/usecase"
-	pb "github.com/mephirious/advanced-programming-2/statistics-service/proto"
-	"github.com/nats-io/nats.go"
-	"google.golang.org/protobuf/proto"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
+const (
+	orderConsumer     = "statistics-orders"
+	inventoryConsumer = "statistics-inventory"
+
+	// replayBatchSize and replayMaxWait bound the fetches of stream replays.
+	replayBatchSize = 100
+	replayMaxWait   = time.Second
+)
+
+type ConsumerConfig struct {
+	MaxDeliver         int
+	AckWait            time.Duration
+	ReplayFromSequence uint64
+	ReplayFromTime     time.Time
+}
+
+// NATSHandler consumes the order and inventory streams with durable pull
+// consumers. Messages are acknowledged after they were processed and are
+// redelivered with backoff when processing fails, up to MaxDeliver times.
+// Invalid messages and messages that failed their last delivery are written
+// to the dead-letter store.
 type NATSHandler struct {
 	statsUseCase usecase.StatsUseCase
-	natsConn     *nats.Conn
+	deadLetters  repository.DeadLetterRepository
+	js           jetstream.JetStream
+	cfg          ConsumerConfig
+
+	mu        sync.Mutex
+	durables  []jetstream.Consumer
+	consumers []jetstream.ConsumeContext
+}
+
+// eventKind is what an envelope type stands for.
+type eventKind struct {
+	inventory bool
+	eventType string
 }
 
-func NewNATSHandler(statsUC usecase.StatsUseCase, nc *nats.Conn) *NATSHandler {
+var eventKinds = map[string]eventKind{
+	events.TypeOrderCreated:    {eventType: "CREATED"},
+	events.TypeOrderUpdated:    {eventType: "UPDATED"},
+	events.TypeOrderCancelled:  {eventType: "CANCELLED"},
+	events.TypeOrderDeleted:    {eventType: "DELETED"},
+	events.TypeReturnRequested: {eventType: "RETURN_REQUESTED"},
+	events.TypeReturnApproved:  {eventType: "RETURN_APPROVED"},
+	events.TypeReturnRejected:  {eventType: "RETURN_REJECTED"},
+	events.TypeReturnReceived:  {eventType: "RETURN_RECEIVED"},
+	events.TypeReturnRefunded:  {eventType: "RETURN_REFUNDED"},
+	events.TypeProductCreated:  {inventory: true, eventType: "CREATED"},
+	events.TypeProductUpdated:  {inventory: true, eventType: "UPDATED"},
+	events.TypeProductDeleted:  {inventory: true, eventType: "DELETED"},
+}
+
+func NewNATSHandler(statsUC usecase.StatsUseCase, deadLetters repository.DeadLetterRepository, js jetstream.JetStream, cfg ConsumerConfig) *NATSHandler {
 	return &NATSHandler{
 		statsUseCase: statsUC,
-		natsConn:     nc,
+		deadLetters:  deadLetters,
+		js:           js,
+		cfg:          cfg,
 	}
 }
 
-func (h *NATSHandler) Start() error {
-	_, err := h.natsConn.Subscribe("order.events", func(m *nats.Msg) {
-		log.Printf("Received on order.events: %s", hex.EncodeToString(m.Data))
-		var orderEvent pb.OrderEvent
-		if err := proto.Unmarshal(m.Data, &orderEvent); err != nil {
-			log.Printf("Failed to unmarshal order event: %v", err)
-			return
+func (h *NATSHandler) Start(ctx context.Context) error {
+	if err := h.consume(ctx, streams.Orders.Name, orderConsumer); err != nil {
+		return err
+	}
+	if err := h.consume(ctx, streams.Inventory.Name, inventoryConsumer); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (h *NATSHandler) Stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, cc := range h.consumers {
+		cc.Stop()
+	}
+	h.consumers = nil
+}
+
+// Pause stops consuming and returns once the messages already received are
+// processed. Messages published meanwhile wait in the durable consumers.
+func (h *NATSHandler) Pause() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, cc := range h.consumers {
+		cc.Drain()
+		<-cc.Closed()
+	}
+	h.consumers = nil
+	log.Println("Paused stream consumers")
+}
+
+// Resume consumes the durable consumers again from where Pause left them.
+func (h *NATSHandler) Resume(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, consumer := range h.durables {
+		if err := h.subscribe(consumer); err != nil {
+			return err
+		}
+	}
+	log.Println("Resumed stream consumers")
+	return nil
+}
+
+// Process handles a raw message like the consumers do. Messages are
+// dispatched on the type in their envelope headers. Messages published before
+// the envelope was introduced have no headers and are dispatched on their
+// subject and the event type in their payload.
+func (h *NATSHandler) Process(ctx context.Context, subject string, headers map[string]string, data []byte) error {
+	envelope, err := events.ParseEnvelope(headers)
+	if errors.Is(err, events.ErrMissingEnvelope) {
+		switch subject {
+		case streams.Orders.Subjects[0]:
+			return h.processOrderMessage(ctx, envelope, data)
+		case streams.Inventory.Subjects[0]:
+			return h.processInventoryMessage(ctx, envelope, data)
+		default:
+			return fmt.Errorf("%w: unknown subject %q", streams.ErrInvalidEvent, subject)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", streams.ErrInvalidEvent, err)
+	}
+
+	kind, ok := eventKinds[envelope.Type]
+	if !ok {
+		return fmt.Errorf("%w: unknown event type %q", streams.ErrInvalidEvent, envelope.Type)
+	}
+	if envelope.ContentType != events.ContentTypeProtobuf {
+		return fmt.Errorf("%w: unsupported content type %q of %s event %s", streams.ErrInvalidEvent, envelope.ContentType, envelope.Type, envelope.ID)
+	}
+
+	log.Printf("Received %s event %s from %s (trace %s)", envelope.Type, envelope.ID, envelope.Source, envelope.TraceID)
+	if kind.inventory {
+		return h.processInventoryMessage(ctx, envelope, data)
+	}
+	return h.processOrderMessage(ctx, envelope, data)
+}
+
+func (h *NATSHandler) consume(ctx context.Context, stream, durable string) error {
+	consumer, err := h.consumer(ctx, stream, durable)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.subscribe(consumer); err != nil {
+		return err
+	}
+	h.durables = append(h.durables, consumer)
+	log.Printf("Consuming stream %s with durable consumer %s", stream, durable)
+	return nil
+}
+
+func (h *NATSHandler) subscribe(consumer jetstream.Consumer) error {
+	cc, err := consumer.Consume(h.handleMessage)
+	if err != nil {
+		return fmt.Errorf("failed to consume %s: %w", consumer.CachedInfo().Name, err)
+	}
+	h.consumers = append(h.consumers, cc)
+	return nil
+}
+
+// ConsumersActive reports whether a running service is consuming one of the
+// durable consumers, which is seen from the pull requests it keeps waiting on
+// them.
+func (h *NATSHandler) ConsumersActive(ctx context.Context) (bool, error) {
+	for stream, durable := range map[string]string{
+		streams.Orders.Name:    orderConsumer,
+		streams.Inventory.Name: inventoryConsumer,
+	} {
+		consumer, err := h.js.Consumer(ctx, stream, durable)
+		if errors.Is(err, jetstream.ErrConsumerNotFound) || errors.Is(err, jetstream.ErrStreamNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to get consumer %s: %w", durable, err)
+		}
+		if consumer.CachedInfo().NumWaiting > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// StreamStates returns the message count and last sequence of the consumed
+// streams.
+func (h *NATSHandler) StreamStates(ctx context.Context) (map[string]usecase.StreamState, error) {
+	states := make(map[string]usecase.StreamState)
+	for _, name := range []string{streams.Orders.Name, streams.Inventory.Name} {
+		stream, err := h.js.Stream(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stream %s: %w", name, err)
+		}
+		info, err := stream.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stream %s info: %w", name, err)
+		}
+		states[name] = usecase.StreamState{
+			Messages:     info.State.Msgs,
+			LastSequence: info.State.LastSeq,
 		}
-		log.Printf("Successfully unmarshaled OrderEvent: %+v", &orderEvent)
-		h.processOrderEvent(context.Background(), &orderEvent)
+	}
+	return states, nil
+}
+
+// ReplayStream reads the stream with an ephemeral ordered consumer, leaving the
+// durable consumers alone. Invalid messages are skipped, as they were
+// dead-lettered when they were consumed.
+func (h *NATSHandler) ReplayStream(ctx context.Context, stream string, after, until uint64, processor usecase.EventProcessor, progress func(ctx context.Context) error) error {
+	if until <= after {
+		return nil
+	}
+
+	consumer, err := h.js.OrderedConsumer(ctx, stream, jetstream.OrderedConsumerConfig{
+		DeliverPolicy: jetstream.DeliverByStartSequencePolicy,
+		OptStartSeq:   after + 1,
 	})
 	if err != nil {
-		log.Printf("Error subscribing to subject order.events: %v", err)
-		return fmt.Errorf("failed to subscribe to subject order.events: %w", err)
-	}
-	log.Printf("Subscribed to NATS subject: order.events")
-
-	_, err = h.natsConn.Subscribe("inventory.events", func(m *nats.Msg) {
-		log.Printf("Received on inventory.events: %s", hex.EncodeToString(m.Data))
-		var inventoryEvent pb.InventoryEvent
-		if err := proto.Unmarshal(m.Data, &inventoryEvent); err != nil {
-			log.Printf("Failed to unmarshal inventory event: %v", err)
-			var orderEvent pb.OrderEvent
-			if err := proto.Unmarshal(m.Data, &orderEvent); err == nil {
-				log.Printf("Message is actually an OrderEvent: %+v", orderEvent)
+		return fmt.Errorf("failed to create replay consumer: %w", err)
+	}
+
+	for {
+		batch, err := consumer.Fetch(replayBatchSize, jetstream.FetchMaxWait(replayMaxWait))
+		if err != nil {
+			return fmt.Errorf("failed to fetch messages: %w", err)
+		}
+
+		received := 0
+		for msg := range batch.Messages() {
+			received++
+			meta, err := msg.Metadata()
+			if err != nil {
+				return fmt.Errorf("failed to read message metadata: %w", err)
+			}
+			seq := meta.Sequence.Stream
+			if seq > until {
+				return nil
+			}
+
+			if err := processor.Process(ctx, msg.Subject(), streams.HeaderMap(msg.Headers()), msg.Data()); err != nil {
+				if !errors.Is(err, streams.ErrInvalidEvent) {
+					return fmt.Errorf("message %d: %w", seq, err)
+				}
+				log.Printf("Skipping invalid message %d of stream %s: %v", seq, stream, err)
+			}
+			if err := progress(ctx); err != nil {
+				return err
+			}
+			if seq == until {
+				return nil
+			}
+		}
+		if err := batch.Error(); err != nil {
+			return fmt.Errorf("failed to fetch messages: %w", err)
+		}
+		// The last messages were removed from the stream meanwhile.
+		if received == 0 {
+			return nil
+		}
+	}
+}
+
+// consumer creates or updates the durable consumer. A configured replay
+// position recreates it, since the deliver policy of an existing consumer
+// cannot be changed.
+func (h *NATSHandler) consumer(ctx context.Context, stream, durable string) (jetstream.Consumer, error) {
+	cfg := jetstream.ConsumerConfig{
+		Durable:       durable,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       h.cfg.AckWait,
+		MaxDeliver:    h.cfg.MaxDeliver,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+	}
+
+	replay := false
+	switch {
+	case h.cfg.ReplayFromSequence > 0:
+		cfg.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		cfg.OptStartSeq = h.cfg.ReplayFromSequence
+		replay = true
+	case !h.cfg.ReplayFromTime.IsZero():
+		cfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		cfg.OptStartTime = &h.cfg.ReplayFromTime
+		replay = true
+	}
+
+	if replay {
+		err := h.js.DeleteConsumer(ctx, stream, durable)
+		if err != nil && !errors.Is(err, jetstream.ErrConsumerNotFound) {
+			return nil, fmt.Errorf("failed to delete consumer %s for replay: %w", durable, err)
+		}
+		log.Printf("Replaying stream %s into %s (%s)", stream, durable, cfg.DeliverPolicy)
+	}
+
+	consumer, err := h.js.CreateOrUpdateConsumer(ctx, stream, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer %s: %w", durable, err)
+	}
+	return consumer, nil
+}
+
+func (h *NATSHandler) handleMessage(msg jetstream.Msg) {
+	err := h.Process(context.Background(), msg.Subject(), streams.HeaderMap(msg.Headers()), msg.Data())
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			log.Printf("Failed to ack message on %s: %v", msg.Subject(), err)
+		}
+		return
+	}
+
+	var delivered uint64 = 1
+	meta, metaErr := msg.Metadata()
+	if metaErr == nil {
+		delivered = meta.NumDelivered
+	}
+
+	if errors.Is(err, streams.ErrInvalidEvent) || (h.cfg.MaxDeliver > 0 && delivered >= uint64(h.cfg.MaxDeliver)) {
+		log.Printf("Dead-lettering message on %s after %d deliveries: %v", msg.Subject(), delivered, err)
+		if dlqErr := h.deadLetter(msg, meta, delivered, err); dlqErr != nil {
+			// Without a dead letter the message must not be dropped, so it is
+			// left to JetStream to redeliver.
+			log.Printf("Failed to dead-letter message on %s: %v", msg.Subject(), dlqErr)
+			if err := msg.NakWithDelay(streams.RedeliveryDelay(delivered)); err != nil {
+				log.Printf("Failed to nak message on %s: %v", msg.Subject(), err)
 			}
 			return
 		}
-		log.Printf("Successfully unmarshaled InventoryEvent: id=%s, name=%s, description=%s, category_id=%s, price=%f, quantity=%d, event_type=%s",
-			inventoryEvent.Id, inventoryEvent.Name, inventoryEvent.Description, inventoryEvent.CategoryId, inventoryEvent.Price, inventoryEvent.Quantity, inventoryEvent.EventType)
-		h.processInventoryEvent(context.Background(), &inventoryEvent)
-	})
+		if err := msg.Term(); err != nil {
+			log.Printf("Failed to terminate message on %s: %v", msg.Subject(), err)
+		}
+		return
+	}
+
+	log.Printf("Failed to process message on %s (delivery %d of %d): %v", msg.Subject(), delivered, h.cfg.MaxDeliver, err)
+	if err := msg.NakWithDelay(streams.RedeliveryDelay(delivered)); err != nil {
+		log.Printf("Failed to nak message on %s: %v", msg.Subject(), err)
+	}
+}
+
+func (h *NATSHandler) deadLetter(msg jetstream.Msg, meta *jetstream.MsgMetadata, delivered uint64, cause error) error {
+	deadLetter := &domain.DeadLetter{
+		Subject:  msg.Subject(),
+		Headers:  streams.HeaderMap(msg.Headers()),
+		Payload:  msg.Data(),
+		Error:    cause.Error(),
+		Attempts: int(delivered),
+		FailedAt: time.Now(),
+	}
+	if meta != nil {
+		deadLetter.Stream = meta.Stream
+		deadLetter.Sequence = meta.Sequence.Stream
+	}
+	return h.deadLetters.SaveDeadLetter(context.Background(), deadLetter)
+}
+
+func (h *NATSHandler) processOrderMessage(ctx context.Context, envelope events.Envelope, data []byte) error {
+	orderEvent, err := events.DecodeOrderEvent(envelope.SchemaVersion, data)
 	if err != nil {
-		log.Printf("Error subscribing to subject inventory.events: %v", err)
-		return fmt.Errorf("failed to subscribe to subject inventory.events: %w", err)
+		return fmt.Errorf("%w: %v", streams.ErrInvalidEvent, err)
 	}
-	log.Printf("Subscribed to NATS subject: inventory.events")
+	log.Printf("Successfully decoded OrderEvent: %+v", orderEvent)
+	return h.processOrderEvent(ctx, envelope, orderEvent)
+}
 
-	return nil
+func (h *NATSHandler) processInventoryMessage(ctx context.Context, envelope events.Envelope, data []byte) error {
+	inventoryEvent, err := events.DecodeInventoryEvent(envelope.SchemaVersion, data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", streams.ErrInvalidEvent, err)
+	}
+	log.Printf("Successfully decoded InventoryEvent: id=%s, name=%s, description=%s, category_id=%s, price=%f, stock=%d, event_type=%s",
+		inventoryEvent.Id, inventoryEvent.Name, inventoryEvent.Description, inventoryEvent.CategoryId, inventoryEvent.Price, inventoryEvent.Stock, inventoryEvent.EventType)
+	return h.processInventoryEvent(ctx, envelope, inventoryEvent)
 }
 
-func (h *NATSHandler) processOrderEvent(ctx context.Context, pbEvent *pb.OrderEvent) {
+func (h *NATSHandler) processOrderEvent(ctx context.Context, envelope events.Envelope, pbEvent *orderv1.OrderEvent) error {
 	var createdAt, updatedAt time.Time
 	if pbEvent.CreatedAt != nil {
 		createdAt = pbEvent.CreatedAt.AsTime()
@@ -77,27 +411,35 @@ func (h *NATSHandler) processOrderEvent(ctx context.Context, pbEvent *pb.OrderEv
 	}
 
 	domainEvent := &domain.OrderEvent{
-		ID:        pbEvent.Id,
-		UserID:    pbEvent.UserId,
-		Total:     pbEvent.Total,
-		Status:    pbEvent.Status.String(),
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
-		EventType: mapEventTypeToString(pbEvent.EventType),
+		ID:            pbEvent.Id,
+		UserID:        pbEvent.UserId,
+		Total:         pbEvent.Total,
+		RefundedTotal: pbEvent.RefundedTotal,
+		Status:        pbEvent.Status.String(),
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+		EventType:     eventType(envelope, orderv1.OrderEventType_name[int32(pbEvent.EventType)]),
+		EventID:       eventID(envelope, pbEvent.EventId),
+		Sequence:      pbEvent.Sequence,
+	}
+	// A cancellation always counts as one, whatever status the payload carries.
+	if domainEvent.EventType == "CANCELLED" {
+		domainEvent.Status = domain.EventStatusCancelled
 	}
 
 	for _, item := range pbEvent.Items {
 		domainEvent.Items = append(domainEvent.Items, domain.OrderItem{
-			ProductID: item.ProductId,
-			Quantity:  int(item.Quantity),
-			Price:     item.Price,
+			ProductID:        item.ProductId,
+			Quantity:         int(item.Quantity),
+			Price:            item.Price,
+			RefundedQuantity: int(item.RefundedQuantity),
 		})
 	}
 
-	h.handleOrderEvent(ctx, domainEvent)
+	return h.handleOrderEvent(ctx, domainEvent)
 }
 
-func (h *NATSHandler) processInventoryEvent(ctx context.Context, pbEvent *pb.InventoryEvent) {
+func (h *NATSHandler) processInventoryEvent(ctx context.Context, envelope events.Envelope, pbEvent *inventoryv1.InventoryEvent) error {
 	var createdAt, updatedAt time.Time
 	if pbEvent.CreatedAt != nil {
 		createdAt = pbEvent.CreatedAt.AsTime()
@@ -111,64 +453,59 @@ func (h *NATSHandler) processInventoryEvent(ctx context.Context, pbEvent *pb.Inv
 		Name:        pbEvent.Name,
 		Description: pbEvent.Description,
 		CategoryID:  pbEvent.CategoryId,
-		Price:       float64(pbEvent.Price),
-		Quantity:    int(pbEvent.Quantity),
+		Price:       pbEvent.Price,
+		Stock:       int(pbEvent.Stock),
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
-		EventType:   mapEventTypeToString(pbEvent.EventType),
+		EventType:   eventType(envelope, inventoryv1.InventoryEventType_name[int32(pbEvent.EventType)]),
+		EventID:     eventID(envelope, pbEvent.EventId),
+		Sequence:    pbEvent.Sequence,
 	}
 
-	h.handleInventoryEvent(ctx, domainEvent)
+	return h.handleInventoryEvent(ctx, domainEvent)
 }
 
-func (h *NATSHandler) handleOrderEvent(ctx context.Context, event *domain.OrderEvent) {
+func (h *NATSHandler) handleOrderEvent(ctx context.Context, event *domain.OrderEvent) error {
 	if event.EventType == "UNKNOWN" {
-		log.Printf("Unknown event type for order event %s", event.ID)
-		return
+		return fmt.Errorf("%w: unknown event type for order event %s", streams.ErrInvalidEvent, event.ID)
 	}
 
 	if err := h.statsUseCase.HandleOrderEvent(ctx, event); err != nil {
-		log.Printf("Error handling order event: %v", err)
+		return fmt.Errorf("error handling order event: %w", err)
 	}
+	return nil
 }
 
-func (h *NATSHandler) handleInventoryEvent(ctx context.Context, event *domain.InventoryEvent) {
+func (h *NATSHandler) handleInventoryEvent(ctx context.Context, event *domain.InventoryEvent) error {
 	if event.EventType == "UNKNOWN" {
-		log.Printf("Unknown event type for inventory event %s", event.ID)
-		return
+		return fmt.Errorf("%w: unknown event type for inventory event %s", streams.ErrInvalidEvent, event.ID)
 	}
 
-	if event.EventType == "CREATED" {
-		if event.Price <= 0 {
-			log.Printf("Invalid price for CREATED inventory event %s: %f", event.ID, event.Price)
-			return
-		}
-		if event.Quantity <= 0 {
-			log.Printf("Invalid quantity for CREATED inventory event %s: %d", event.ID, event.Quantity)
-			return
-		}
-		if event.CreatedAt.IsZero() {
-			log.Printf("CreatedAt is zero for CREATED inventory event %s", event.ID)
-			return
-		}
+	if event.EventType == "CREATED" && event.CreatedAt.IsZero() {
+		return fmt.Errorf("%w: CreatedAt is zero for CREATED inventory event %s", streams.ErrInvalidEvent, event.ID)
 	}
 
 	if err := h.statsUseCase.HandleInventoryEvent(ctx, event); err != nil {
-		log.Printf("Error handling inventory event: %v", err)
+		return fmt.Errorf("error handling inventory event: %w", err)
 	}
+	return nil
 }
 
-func mapEventTypeToString(eventType pb.OrderEventType) string {
-	switch eventType {
-	case pb.OrderEventType_CREATED:
-		return "CREATED"
-	case pb.OrderEventType_UPDATED:
-		return "UPDATED"
-	case pb.OrderEventType_CANCELLED:
-		return "CANCELLED"
-	case pb.OrderEventType_DELETED:
-		return "DELETED"
-	default:
+// eventType returns the event type named by the envelope, or the name of the
+// one in the payload of messages without envelope.
+func eventType(envelope events.Envelope, payloadType string) string {
+	if kind, ok := eventKinds[envelope.Type]; ok {
+		return kind.eventType
+	}
+	if payloadType == "" {
 		return "UNKNOWN"
 	}
+	return payloadType
+}
+
+func eventID(envelope events.Envelope, payloadID string) string {
+	if payloadID != "" {
+		return payloadID
+	}
+	return envelope.ID
 }
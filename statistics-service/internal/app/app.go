@@ -8,9 +8,11 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/mephirious/advanced-programming-2/contracts/streams"
 	"github.com/mephirious/advanced-programming-2/statistics-service/config"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/adapter/grpc"
 	handler "github.com/mephirious/advanced-programming-2/statistics-service/internal/adapter/nats/handler"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/usecase"
 	"github.com/mephirious/advanced-programming-2/statistics-service/pkg/mongo"
@@ -19,9 +21,14 @@ import (
 
 const serviceName = "statistics-service"
 
+// shadowSuffix names the database rebuilds write to before their collections
+// are swapped in.
+const shadowSuffix = "_rebuild"
+
 type App struct {
 	grpcServer  *grpc.Server
 	natsHandler *handler.NATSHandler
+	rebuilds    usecase.RebuildUseCase
 	mongoDB     *mongo.DB
 	natsConn    *nats.Client
 }
@@ -42,34 +49,170 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 	}
 
 	repo := repository.NewMongoStatsRepository(mongoDB.Connection)
-	uc := usecase.NewStatsUseCase(repo)
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("event indexes: %w", err)
+	}
+	projectionRepo := repository.NewMongoProjectionRepository(mongoDB.Connection)
+	if err := projectionRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("projection indexes: %w", err)
+	}
+	catalogRepo := repository.NewMongoCatalogRepository(mongoDB.Connection)
+	rollupRepo := repository.NewMongoRollupRepository(mongoDB.Connection)
+	if err := rollupRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("rollup indexes: %w", err)
+	}
+	activityRepo := repository.NewMongoActivityRepository(mongoDB.Connection)
+	if err := activityRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("activity indexes: %w", err)
+	}
+	uc := usecase.NewStatsUseCase(repo, projectionRepo, catalogRepo, rollupRepo, activityRepo, mongoDB)
+
+	salesRepo := repository.NewMongoSalesRepository(mongoDB.Connection)
+	if err := salesRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("sales indexes: %w", err)
+	}
+	salesUC := usecase.NewSalesUseCase(salesRepo)
+	seriesUC := usecase.NewTimeSeriesUseCase(rollupRepo)
 
-	grpcServer, err := grpc.NewServer(cfg.Server.GRPCServer.Port, uc)
+	js, err := nc.JetStream()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create gRPC server: %w", err)
+		return nil, err
+	}
+	if err := streams.Ensure(ctx, js, streams.Orders, streams.Inventory); err != nil {
+		return nil, err
 	}
 
-	natsHandler := handler.NewNATSHandler(uc, nc.Conn)
+	deadLetterRepo := repository.NewMongoDeadLetterRepository(mongoDB.Connection)
+	natsHandler := handler.NewNATSHandler(uc, deadLetterRepo, js, handler.ConsumerConfig{
+		MaxDeliver:         cfg.NATS.MaxDeliver,
+		AckWait:            cfg.NATS.AckWait,
+		ReplayFromSequence: cfg.NATS.ReplayFromSequence,
+		ReplayFromTime:     cfg.NATS.ReplayFromTime,
+	})
+	deadLetterUC := usecase.NewDeadLetterUseCase(deadLetterRepo, natsHandler)
+	rebuildUC := newRebuildUseCase(mongoDB, cfg, repo, natsHandler, natsHandler)
+
+	grpcServer, err := grpc.NewServer(cfg.Server.GRPCServer.Port, uc, salesUC, seriesUC, deadLetterUC, rebuildUC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC server: %w", err)
+	}
 
 	return &App{
 		grpcServer:  grpcServer,
 		natsHandler: natsHandler,
+		rebuilds:    rebuildUC,
 		mongoDB:     mongoDB,
 		natsConn:    nc,
 	}, nil
 }
 
+// Rebuild recomputes all projections from source and returns when they are
+// swapped in. It cannot pause the consumers of a running service, so it
+// refuses to run while the service consumes the streams.
+func Rebuild(ctx context.Context, cfg *config.Config, source domain.RebuildSource) error {
+	nc, err := nats.Connect(cfg.NATS.URL)
+	if err != nil {
+		return fmt.Errorf("nats connection failed: %w", err)
+	}
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	if err != nil {
+		return err
+	}
+	natsHandler := handler.NewNATSHandler(nil, nil, js, handler.ConsumerConfig{})
+	active, err := natsHandler.ConsumersActive(ctx)
+	if err != nil {
+		return err
+	}
+	if active {
+		return fmt.Errorf("%s is consuming the streams: stop it or use the RebuildProjections RPC", serviceName)
+	}
+
+	mongoDB, err := mongo.NewDB(ctx, cfg.Mongo)
+	if err != nil {
+		return fmt.Errorf("mongo: %w", err)
+	}
+	defer mongoDB.Client.Disconnect(ctx)
+
+	repo := repository.NewMongoStatsRepository(mongoDB.Connection)
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		return fmt.Errorf("event indexes: %w", err)
+	}
+
+	var stream usecase.EventStream
+	if source == domain.RebuildFromStream {
+		stream = natsHandler
+	}
+
+	rebuild, err := newRebuildUseCase(mongoDB, cfg, repo, stream, nil).Rebuild(ctx, source)
+	if err != nil {
+		return err
+	}
+	log.Printf("rebuilt projections from %d events", rebuild.Processed)
+	return nil
+}
+
+// newRebuildUseCase wires rebuilds to the shadow database. The shadow use case
+// is built like the live one, over shadow repositories.
+func newRebuildUseCase(mongoDB *mongo.DB, cfg *config.Config, repo repository.StatsRepository, stream usecase.EventStream, consumer usecase.EventConsumer) usecase.RebuildUseCase {
+	shadowDB := mongoDB.Client.Database(cfg.Mongo.Database + shadowSuffix)
+
+	shadow := func(ctx context.Context) (usecase.StatsUseCase, usecase.EventProcessor, error) {
+		shadowRepo := repository.NewMongoStatsRepository(shadowDB)
+		if err := shadowRepo.EnsureIndexes(ctx); err != nil {
+			return nil, nil, fmt.Errorf("event indexes: %w", err)
+		}
+		projectionRepo := repository.NewMongoProjectionRepository(shadowDB)
+		if err := projectionRepo.EnsureIndexes(ctx); err != nil {
+			return nil, nil, fmt.Errorf("projection indexes: %w", err)
+		}
+		rollupRepo := repository.NewMongoRollupRepository(shadowDB)
+		if err := rollupRepo.EnsureIndexes(ctx); err != nil {
+			return nil, nil, fmt.Errorf("rollup indexes: %w", err)
+		}
+		activityRepo := repository.NewMongoActivityRepository(shadowDB)
+		if err := activityRepo.EnsureIndexes(ctx); err != nil {
+			return nil, nil, fmt.Errorf("activity indexes: %w", err)
+		}
+		if err := repository.NewMongoSalesRepository(shadowDB).EnsureIndexes(ctx); err != nil {
+			return nil, nil, fmt.Errorf("sales indexes: %w", err)
+		}
+
+		uc := usecase.NewStatsUseCase(
+			shadowRepo,
+			projectionRepo,
+			repository.NewMongoCatalogRepository(shadowDB),
+			rollupRepo,
+			activityRepo,
+			mongoDB,
+		)
+		return uc, handler.NewNATSHandler(uc, nil, nil, handler.ConsumerConfig{}), nil
+	}
+
+	rebuildRepo := repository.NewMongoRebuildRepository(mongoDB.Connection, shadowDB)
+	return usecase.NewRebuildUseCase(rebuildRepo, repo, shadow, stream, consumer)
+}
+
 func (a *App) Close() {
 	a.grpcServer.Stop()
+	a.natsHandler.Stop()
 	a.natsConn.Close()
 }
 
 func (a *App) Run() error {
-	go func() {
-		if err := a.natsHandler.Start(); err != nil {
-			log.Fatalf("NATS handler failed: %v", err)
-		}
-	}()
+	if err := a.natsHandler.Start(context.Background()); err != nil {
+		return fmt.Errorf("NATS handler failed: %w", err)
+	}
+
+	// Events stored before the projections existed are projected once.
+	started, err := a.rebuilds.Backfill(context.Background())
+	if err != nil {
+		return fmt.Errorf("projection backfill failed: %w", err)
+	}
+	if started {
+		log.Println("backfilling projections from the stored events")
+	}
 
 	go func() {
 		if err := a.grpcServer.Start(); err != nil {
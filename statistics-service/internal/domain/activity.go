@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// ActiveUsersPoint counts the distinct users that placed an order in a period.
+// New users placed their first order in the period, returning users before it.
+type ActiveUsersPoint struct {
+	Start     time.Time
+	Active    int
+	New       int
+	Returning int
+}
+
+// Cohort groups the users by the month of their first order. Retention holds
+// one entry per month since then, Offset 0 being the cohort month itself.
+type Cohort struct {
+	Start     time.Time
+	Size      int
+	Retention []CohortPeriod
+}
+
+type CohortPeriod struct {
+	Offset int
+	Users  int
+	Rate   float64
+}
+
+// CohortActivity is the number of users of a cohort active in a month.
+type CohortActivity struct {
+	Cohort time.Time
+	Month  time.Time
+	Users  int
+}
+
+// ActivityKey identifies the orders of a user on a UTC day.
+type ActivityKey struct {
+	UserID string
+	Day    time.Time
+}
+
+// NewActivityDeltas returns the change of the orders per user and UTC day
+// when an order goes from prev to next; either may be nil. Only orders in a
+// revenue status count, on the day they were created, so an order that is
+// cancelled or whose checkout failed never makes its user active and stops
+// counting when it leaves a revenue status.
+func NewActivityDeltas(prev, next *OrderProjection) map[ActivityKey]int {
+	deltas := make(map[ActivityKey]int)
+	contribute := func(p *OrderProjection, n int) {
+		if p == nil || p.UserID == "" || p.CreatedAt.IsZero() || !IsRevenueStatus(p.Status) {
+			return
+		}
+		key := ActivityKey{UserID: p.UserID, Day: BucketStart(p.CreatedAt.UTC(), GranularityDay)}
+		deltas[key] += n
+		if deltas[key] == 0 {
+			delete(deltas, key)
+		}
+	}
+
+	contribute(next, 1)
+	contribute(prev, -1)
+	return deltas
+}
+
+// MonthsBetween returns the number of calendar months from a to b.
+func MonthsBetween(a, b time.Time) int {
+	return (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+}
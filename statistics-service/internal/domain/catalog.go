@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// CatalogProduct is the read model of an inventory product. It keeps every
+// category the product was assigned to, so sales are attributed to the
+// category the product was in when it was ordered.
+type CatalogProduct struct {
+	ID         string               `bson:"_id"`
+	Name       string               `bson:"name"`
+	Price      float64              `bson:"price"`
+	Categories []CategoryAssignment `bson:"categories"`
+	Deleted    bool                 `bson:"deleted"`
+	CreatedAt  time.Time            `bson:"created_at"`
+	UpdatedAt  time.Time            `bson:"updated_at"`
+	// Sequence is the sequence number of the last event applied.
+	Sequence int64 `bson:"sequence"`
+}
+
+// CategoryAssignment records that a product was in a category from a point in
+// time until the next assignment.
+type CategoryAssignment struct {
+	CategoryID string    `bson:"category_id"`
+	From       time.Time `bson:"from"`
+}
+
+// CategoryAt returns the category of the product at t. Before the first known
+// assignment the first category is returned.
+func (p *CatalogProduct) CategoryAt(t time.Time) string {
+	if len(p.Categories) == 0 {
+		return ""
+	}
+	category := p.Categories[0].CategoryID
+	for _, assignment := range p.Categories {
+		if assignment.From.After(t) {
+			break
+		}
+		category = assignment.CategoryID
+	}
+	return category
+}
+
+// AssignCategory records that the product is in category from the given time
+// on. Assignments are kept ordered by time, and an assignment that does not
+// change the category at that time is not recorded.
+func (p *CatalogProduct) AssignCategory(category string, from time.Time) {
+	if len(p.Categories) > 0 && p.CategoryAt(from) == category {
+		return
+	}
+
+	i := len(p.Categories)
+	for i > 0 && p.Categories[i-1].From.After(from) {
+		i--
+	}
+	p.Categories = append(p.Categories, CategoryAssignment{})
+	copy(p.Categories[i+1:], p.Categories[i:])
+	p.Categories[i] = CategoryAssignment{CategoryID: category, From: from}
+}
+
+type CategorySales struct {
+	CategoryID string
+	Units      int
+	Revenue    float64
+	Orders     int
+}
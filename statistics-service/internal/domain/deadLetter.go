@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrDeadLetterNotFound = fmt.Errorf("dead letter %w", ErrNotFound)
+
+// DeadLetter is a stream message the statistics service could not process. It
+// keeps the raw headers and payload so the message can be replayed after a
+// fix.
+type DeadLetter struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Stream     string             `bson:"stream"`
+	Subject    string             `bson:"subject"`
+	Headers    map[string]string  `bson:"headers,omitempty"`
+	Sequence   uint64             `bson:"sequence"`
+	Payload    []byte             `bson:"payload"`
+	Error      string             `bson:"error"`
+	Attempts   int                `bson:"attempts"`
+	FailedAt   time.Time          `bson:"failed_at"`
+	ReplayedAt *time.Time         `bson:"replayed_at,omitempty"`
+}
+
+type DeadLetterFilter struct {
+	Subject         string
+	IncludeReplayed bool
+	Page            int
+	Limit           int
+}
@@ -28,12 +28,18 @@ type OrderEvent struct {
 	CreatedAt time.Time   `bson:"createdat"`
 	UpdatedAt time.Time   `bson:"updatedat"`
 	EventType string      `bson:"eventtype"`
+	EventID   string      `bson:"event_id,omitempty"`
+	Sequence  int64       `bson:"sequence,omitempty"`
+
+	RefundedTotal float64 `bson:"refunded_total,omitempty"`
 }
 
 type OrderItem struct {
 	ProductID string  `bson:"product_id"`
 	Quantity  int     `bson:"quantity"`
 	Price     float64 `bson:"price"`
+	// RefundedQuantity is the number of units refunded through returns.
+	RefundedQuantity int `bson:"refunded_quantity,omitempty"`
 }
 
 type InventoryEvent struct {
@@ -42,10 +48,12 @@ type InventoryEvent struct {
 	Description string    `bson:"description"`
 	CategoryID  string    `bson:"category_id"`
 	Price       float64   `bson:"price"`
-	Quantity    int       `bson:"quantity"`
+	Stock       int       `bson:"stock"`
 	CreatedAt   time.Time `bson:"created_at"`
 	UpdatedAt   time.Time `bson:"updated_at"`
 	EventType   string    `bson:"event_type"`
+	EventID     string    `bson:"event_id,omitempty"`
+	Sequence    int64     `bson:"sequence,omitempty"`
 }
 
 type UserOrderStatistics struct {
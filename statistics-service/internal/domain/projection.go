@@ -0,0 +1,218 @@
+package domain
+
+import "time"
+
+// Order statuses as carried by order events.
+const (
+	EventStatusPending    = "S_PENDING"
+	EventStatusCompleted  = "S_COMPLETED"
+	EventStatusCancelled  = "S_CANCELLED"
+	EventStatusPaid       = "S_PAID"
+	EventStatusProcessing = "S_PROCESSING"
+	EventStatusShipped    = "S_SHIPPED"
+	EventStatusDelivered  = "S_DELIVERED"
+	EventStatusRefunded   = "S_REFUNDED"
+)
+
+// IsCompletedStatus reports whether an order in status counts as completed.
+// Completed is the legacy final status, delivered replaced it.
+func IsCompletedStatus(status string) bool {
+	return status == EventStatusCompleted || status == EventStatusDelivered
+}
+
+// OrderProjection is the latest known state of an order. There is one per
+// order, so repeated events for an order replace it instead of adding to it.
+type OrderProjection struct {
+	OrderID   string      `bson:"_id"`
+	UserID    string      `bson:"user_id"`
+	Status    string      `bson:"status"`
+	Total     float64     `bson:"total"`
+	Items     []OrderItem `bson:"items"`
+	CreatedAt time.Time   `bson:"created_at"`
+	UpdatedAt time.Time   `bson:"updated_at"`
+	// Sequence is the sequence number of the last event applied.
+	Sequence int64 `bson:"sequence"`
+	// RefundedTotal is the amount refunded for returned items.
+	RefundedTotal float64 `bson:"refunded_total,omitempty"`
+	// Deleted marks the tombstone of a deleted order. It keeps the sequence
+	// of the delete so that older events cannot bring the order back.
+	Deleted bool `bson:"deleted,omitempty"`
+}
+
+func NewOrderProjection(event *OrderEvent) *OrderProjection {
+	return &OrderProjection{
+		OrderID:   event.ID,
+		UserID:    event.UserID,
+		Status:    event.Status,
+		Total:     event.Total,
+		Items:     event.Items,
+		CreatedAt: event.CreatedAt,
+		UpdatedAt: event.UpdatedAt,
+		Sequence:  event.Sequence,
+
+		RefundedTotal: event.RefundedTotal,
+	}
+}
+
+// IsStale reports whether an event with sequence is older than the last event
+// applied to the projection. Events without a sequence are never stale.
+func (p *OrderProjection) IsStale(sequence int64) bool {
+	return sequence > 0 && sequence <= p.Sequence
+}
+
+// NewOrderTombstone returns the projection left behind by a deleted order.
+func NewOrderTombstone(orderID string, sequence int64) *OrderProjection {
+	return &OrderProjection{OrderID: orderID, Sequence: sequence, Deleted: true}
+}
+
+// UserOrderProjection holds the order counters of a user. When the orders
+// were created is kept in the user's order buckets.
+type UserOrderProjection struct {
+	UserID       string         `bson:"_id"`
+	TotalOrders  int            `bson:"total_orders"`
+	StatusCounts map[string]int `bson:"status_counts"`
+}
+
+// UserBucketSize is the length of the user order buckets. Zone offsets are
+// multiples of a quarter hour, so every bucket falls in one local hour of
+// every zone.
+const UserBucketSize = 15 * time.Minute
+
+// UserOrderBucket counts the orders a user created in the UTC quarter hour
+// starting at Start, and how many of them are completed.
+type UserOrderBucket struct {
+	UserID    string    `bson:"user_id"`
+	Start     time.Time `bson:"start"`
+	Orders    int       `bson:"orders"`
+	Completed int       `bson:"completed"`
+}
+
+// UserBucketKey identifies the order bucket of a user that a delta applies to.
+type UserBucketKey struct {
+	UserID string
+	Start  time.Time
+}
+
+type UserBucketDelta struct {
+	Orders    int
+	Completed int
+}
+
+// NewUserBucketDeltas returns the change of the user order buckets when an
+// order goes from prev to next; either may be nil. Orders are counted in the
+// bucket they were created in.
+func NewUserBucketDeltas(prev, next *OrderProjection) map[UserBucketKey]UserBucketDelta {
+	deltas := make(map[UserBucketKey]UserBucketDelta)
+	contribute := func(p *OrderProjection, n int) {
+		if p == nil || p.UserID == "" || p.CreatedAt.IsZero() {
+			return
+		}
+		key := UserBucketKey{UserID: p.UserID, Start: p.CreatedAt.UTC().Truncate(UserBucketSize)}
+		delta := deltas[key]
+		delta.Orders += n
+		if IsCompletedStatus(p.Status) {
+			delta.Completed += n
+		}
+		if delta == (UserBucketDelta{}) {
+			delete(deltas, key)
+			return
+		}
+		deltas[key] = delta
+	}
+
+	contribute(next, 1)
+	contribute(prev, -1)
+	return deltas
+}
+
+// CompletedPerHour returns the completed orders of the buckets per UTC hour.
+func CompletedPerHour(buckets []UserOrderBucket) map[int]int {
+	hours := make(map[int]int)
+	for _, bucket := range buckets {
+		if bucket.Completed != 0 {
+			hours[bucket.Start.UTC().Hour()] += bucket.Completed
+		}
+	}
+	return hours
+}
+
+// GlobalOrderProjection holds the order counters over all users.
+type GlobalOrderProjection struct {
+	TotalOrders  int            `bson:"total_orders"`
+	TotalUsers   int            `bson:"total_users"`
+	StatusCounts map[string]int `bson:"status_counts"`
+}
+
+// OrderStatsDelta is the change an order event makes to the counters.
+type OrderStatsDelta struct {
+	Orders   int
+	Users    int
+	Statuses map[string]int
+}
+
+// NewOrderStatsDelta returns the change of the counters when an order goes
+// from prev to next. Either may be nil for an order that is new or removed.
+// An order moving between statuses moves its count between the status
+// buckets, a repeated event yields an empty delta.
+func NewOrderStatsDelta(prev, next *OrderProjection) OrderStatsDelta {
+	delta := OrderStatsDelta{
+		Statuses: make(map[string]int),
+	}
+	delta.add(next, 1)
+	delta.add(prev, -1)
+	return delta
+}
+
+func (d *OrderStatsDelta) add(p *OrderProjection, n int) {
+	if p == nil {
+		return
+	}
+	d.Orders += n
+	d.Statuses[p.Status] += n
+	if d.Statuses[p.Status] == 0 {
+		delete(d.Statuses, p.Status)
+	}
+}
+
+func (d OrderStatsDelta) Empty() bool {
+	return d.Orders == 0 && d.Users == 0 && len(d.Statuses) == 0
+}
+
+// UserCountDelta returns the change of the user count when a user goes from
+// prevOrders to orders orders. Users are counted while they have an order.
+func UserCountDelta(prevOrders, orders int) int {
+	switch {
+	case prevOrders <= 0 && orders > 0:
+		return 1
+	case prevOrders > 0 && orders <= 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Statistics returns the counters of the projection with the orders of
+// buckets distributed over the hours of loc. Every bucket is converted with
+// the offset loc has at its start, so the distribution is right across
+// daylight saving changes and for offsets that are not whole hours.
+func (p *UserOrderProjection) Statistics(buckets []UserOrderBucket, loc *time.Location) *UserOrderStatistics {
+	stats := &UserOrderStatistics{
+		UserID:        p.UserID,
+		TotalOrders:   p.TotalOrders,
+		OrdersPerHour: make(map[int]int),
+	}
+	for status, count := range p.StatusCounts {
+		switch {
+		case IsCompletedStatus(status):
+			stats.TotalCompletedOrders += count
+		case status == EventStatusCancelled:
+			stats.TotalCancelledOrders += count
+		}
+	}
+	for _, bucket := range buckets {
+		if bucket.Orders != 0 {
+			stats.OrdersPerHour[bucket.Start.In(loc).Hour()] += bucket.Orders
+		}
+	}
+	return stats
+}
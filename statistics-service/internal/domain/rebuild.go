@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrRebuildNotFound = fmt.Errorf("rebuild %w", ErrNotFound)
+
+// RebuildSource is where a rebuild reads the events from.
+type RebuildSource string
+
+const (
+	// RebuildFromEvents replays the raw events stored by the service.
+	RebuildFromEvents RebuildSource = "events"
+	// RebuildFromStream replays the JetStream streams. The raw event
+	// collections are rebuilt as well.
+	RebuildFromStream RebuildSource = "stream"
+)
+
+// ParseRebuildSource parses a rebuild source, the raw events when empty.
+func ParseRebuildSource(s string) (RebuildSource, error) {
+	switch source := RebuildSource(s); source {
+	case "":
+		return RebuildFromEvents, nil
+	case RebuildFromEvents, RebuildFromStream:
+		return source, nil
+	default:
+		return "", fmt.Errorf("%w: unknown rebuild source %q", ErrInvalidArgument, s)
+	}
+}
+
+// ProjectionVersion is the layout of the projections. It is raised when the
+// projections change in a way that needs them rebuilt from the events.
+const ProjectionVersion = 4
+
+type RebuildState string
+
+const (
+	RebuildRunning   RebuildState = "running"
+	RebuildCompleted RebuildState = "completed"
+	RebuildFailed    RebuildState = "failed"
+)
+
+// Rebuild is a run that recomputes all projections from past events into
+// shadow collections and swaps them in for the live ones when it finishes.
+type Rebuild struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	Source     RebuildSource      `bson:"source"`
+	Version    int                `bson:"version,omitempty"`
+	State      RebuildState       `bson:"state"`
+	Total      int64              `bson:"total"`
+	Processed  int64              `bson:"processed"`
+	Error      string             `bson:"error,omitempty"`
+	StartedAt  time.Time          `bson:"started_at"`
+	UpdatedAt  time.Time          `bson:"updated_at"`
+	FinishedAt *time.Time         `bson:"finished_at,omitempty"`
+}
+
+// Progress returns the share of the events processed so far. Events arriving
+// during the rebuild are processed on top of the total, so it is capped at 1.
+func (r *Rebuild) Progress() float64 {
+	if r.State == RebuildCompleted {
+		return 1
+	}
+	if r.Total <= 0 {
+		return 0
+	}
+	return min(float64(r.Processed)/float64(r.Total), 1)
+}
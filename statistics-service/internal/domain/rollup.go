@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Metric is a measure kept in the time-series rollups.
+type Metric string
+
+const (
+	MetricOrders        Metric = "orders"
+	MetricRevenue       Metric = "revenue"
+	MetricCancellations Metric = "cancellations"
+	MetricNewProducts   Metric = "new_products"
+)
+
+func ParseMetric(s string) (Metric, error) {
+	switch m := Metric(s); m {
+	case MetricOrders, MetricRevenue, MetricCancellations, MetricNewProducts:
+		return m, nil
+	default:
+		return "", fmt.Errorf("%w: unknown metric %q", ErrInvalidArgument, s)
+	}
+}
+
+// GranularityQuarterHour is the granularity of the finest rollups. Series are
+// not queried in it.
+const GranularityQuarterHour Granularity = "quarter_hour"
+
+// RollupBucketSize is the length of the finest rollups. Zone offsets are
+// multiples of a quarter hour, so these rollups add up to the hours and days
+// of every zone.
+const RollupBucketSize = 15 * time.Minute
+
+// Rollup is the value of a metric in the UTC quarter hour or day starting at
+// Start.
+type Rollup struct {
+	Metric      Metric      `bson:"metric"`
+	Granularity Granularity `bson:"granularity"`
+	Start       time.Time   `bson:"start"`
+	Value       float64     `bson:"value"`
+}
+
+// RollupKey identifies the UTC quarter hour of a metric that a rollup delta
+// applies to.
+type RollupKey struct {
+	Metric Metric
+	Start  time.Time
+}
+
+// NewOrderRollupDeltas returns the change of the quarter-hour rollups when an
+// order goes from prev to next; either may be nil. Orders and revenue are
+// counted when the order was created, cancellations at the last change of the
+// cancelled order.
+func NewOrderRollupDeltas(prev, next *OrderProjection) map[RollupKey]float64 {
+	deltas := make(map[RollupKey]float64)
+	add := func(metric Metric, at time.Time, value float64) {
+		key := RollupKey{Metric: metric, Start: at.UTC().Truncate(RollupBucketSize)}
+		deltas[key] += value
+		if deltas[key] == 0 {
+			delete(deltas, key)
+		}
+	}
+	contribute := func(p *OrderProjection, sign float64) {
+		if p == nil {
+			return
+		}
+		add(MetricOrders, p.CreatedAt, sign)
+		if IsRevenueStatus(p.Status) {
+			add(MetricRevenue, p.CreatedAt, sign*p.Total)
+		}
+		if p.Status == EventStatusCancelled {
+			add(MetricCancellations, p.UpdatedAt, sign)
+		}
+	}
+
+	contribute(next, 1)
+	contribute(prev, -1)
+	return deltas
+}
+
+type TimeSeriesPoint struct {
+	Start time.Time
+	Value float64
+}
+
+// TimeSeriesQuery selects a metric over a range, bucketed by Granularity in
+// Location.
+type TimeSeriesQuery struct {
+	Metric      Metric
+	Range       DateRange
+	Granularity Granularity
+	Location    *time.Location
+}
+
+// BucketStart returns the start of the bucket of granularity that t falls in,
+// in the location of t. Weeks start on Monday. Hour buckets start at the full
+// local hour, also in zones whose offset is not a whole hour.
+func BucketStart(t time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case GranularityHour:
+		return t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	case GranularityWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// NextBucket returns the start of the bucket following the one starting at t.
+func NextBucket(t time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case GranularityHour:
+		return t.Add(time.Hour)
+	case GranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type Granularity string
+
+const (
+	GranularityHour  Granularity = "hour"
+	GranularityDay   Granularity = "day"
+	GranularityWeek  Granularity = "week"
+	GranularityMonth Granularity = "month"
+)
+
+func ParseGranularity(s string) (Granularity, error) {
+	switch g := Granularity(s); g {
+	case GranularityHour, GranularityDay, GranularityWeek, GranularityMonth:
+		return g, nil
+	case "":
+		return GranularityDay, nil
+	default:
+		return "", fmt.Errorf("%w: unknown granularity %q", ErrInvalidArgument, s)
+	}
+}
+
+// IsRevenueStatus reports whether an order in status counts towards revenue.
+// Pending orders are not paid yet, cancelled and refunded orders were undone.
+func IsRevenueStatus(status string) bool {
+	switch status {
+	case EventStatusPaid, EventStatusProcessing, EventStatusShipped, EventStatusDelivered, EventStatusCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// RevenueStatuses lists the statuses that count towards revenue.
+var RevenueStatuses = []string{EventStatusPaid, EventStatusProcessing, EventStatusShipped, EventStatusDelivered, EventStatusCompleted}
+
+// SoldStatuses lists the statuses of orders that were sold, including the
+// ones refunded in full afterwards.
+var SoldStatuses = append(slices.Clone(RevenueStatuses), EventStatusRefunded)
+
+// DateRange is the half-open range [From, To) of order creation times.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+type RevenuePoint struct {
+	PeriodStart time.Time
+	Revenue     float64
+	Orders      int
+	// Refunded is the amount refunded for returned items of the orders.
+	Refunded float64
+}
+
+// RevenueSummary sums up the revenue of a range. NetRevenue is Revenue less
+// what was refunded for returned items of those orders.
+type RevenueSummary struct {
+	Revenue           float64
+	RefundedRevenue   float64
+	NetRevenue        float64
+	Orders            int
+	AverageOrderValue float64
+	CompletedRevenue  float64
+	CompletedOrders   int
+	CancelledRevenue  float64
+	CancelledOrders   int
+}
+
+// SalesMetric is the measure products are ranked by.
+type SalesMetric string
+
+const (
+	SalesMetricUnits   SalesMetric = "units"
+	SalesMetricRevenue SalesMetric = "revenue"
+)
+
+// ParseSalesMetric parses a metric name, the empty string ranks by units.
+func ParseSalesMetric(s string) (SalesMetric, error) {
+	switch m := SalesMetric(s); m {
+	case SalesMetricUnits, SalesMetricRevenue:
+		return m, nil
+	case "":
+		return SalesMetricUnits, nil
+	default:
+		return "", fmt.Errorf("%w: unknown sales metric %q", ErrInvalidArgument, s)
+	}
+}
+
+type ProductSales struct {
+	ProductID string
+	Name      string
+	Units     int
+	Revenue   float64
+	Orders    int
+}
+
+// Value returns the sales of the product measured by metric.
+func (s ProductSales) Value(metric SalesMetric) float64 {
+	if metric == SalesMetricRevenue {
+		return s.Revenue
+	}
+	return float64(s.Units)
+}
+
+// ProductRefunds compares the units of a product sold with the units refunded
+// through returns. RefundRate is RefundedUnits divided by Units.
+type ProductRefunds struct {
+	ProductID      string
+	Name           string
+	Units          int
+	RefundedUnits  int
+	RefundedAmount float64
+	RefundRate     float64
+}
+
+// ProductSalesQuery selects product sales. An empty CategoryID matches all
+// categories and a zero Limit returns all products.
+type ProductSalesQuery struct {
+	Range      DateRange
+	SortBy     SalesMetric
+	CategoryID string
+	Limit      int
+}
+
+// ProductTrend compares the sales of a product in a window with the window
+// before it. Growth is the relative change of the ranking metric; it is zero
+// for products that had no sales in the previous window, which are marked New.
+type ProductTrend struct {
+	ProductID string
+	Name      string
+	Current   ProductSales
+	Previous  ProductSales
+	Growth    float64
+	New       bool
+}
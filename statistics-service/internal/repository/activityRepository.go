@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoActivityRepository keeps one document per user and UTC day with the
+// number of orders of that day, and the day of the first order of every buyer.
+type mongoActivityRepository struct {
+	activityCol *mongo.Collection
+	buyerCol    *mongo.Collection
+}
+
+func NewMongoActivityRepository(db *mongo.Database) ActivityRepository {
+	return &mongoActivityRepository{
+		activityCol: db.Collection(activityCollection),
+		buyerCol:    db.Collection(buyerCollection),
+	}
+}
+
+// AddOrders adds n to the orders of a user on a day. A day without orders is
+// removed, and the first order of the user is moved to the first day that
+// still has orders; a user without any is no longer a buyer.
+func (r *mongoActivityRepository) AddOrders(ctx context.Context, key domain.ActivityKey, n int) error {
+	id := key.UserID + "|" + key.Day.Format(time.DateOnly)
+	_, err := r.activityCol.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$inc":         bson.M{"orders": n},
+			"$setOnInsert": bson.M{"user_id": key.UserID, "day": key.Day},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+	if _, err := r.activityCol.DeleteOne(ctx, bson.M{"_id": id, "orders": bson.M{"$lte": 0}}); err != nil {
+		return err
+	}
+
+	var first struct {
+		Day time.Time `bson:"day"`
+	}
+	err = r.activityCol.FindOne(ctx,
+		bson.M{"user_id": key.UserID},
+		options.FindOne().SetSort(bson.M{"day": 1}),
+	).Decode(&first)
+	if err == mongo.ErrNoDocuments {
+		_, err = r.buyerCol.DeleteOne(ctx, bson.M{"_id": key.UserID})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = r.buyerCol.UpdateOne(ctx,
+		bson.M{"_id": key.UserID},
+		bson.M{"$set": bson.M{"first_order_at": first.Day}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// GetActiveUsers counts the active, new and returning users per period that
+// has activity, ordered by period.
+func (r *mongoActivityRepository) GetActiveUsers(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.ActiveUsersPoint, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"day": bson.M{"$gte": dateRange.From, "$lt": dateRange.To}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         buyerCollection,
+			"localField":   "user_id",
+			"foreignField": "_id",
+			"as":           "buyer",
+		}}},
+		{{Key: "$unwind", Value: "$buyer"}},
+		{{Key: "$set", Value: bson.M{
+			"period": dateTrunc("$day", granularity),
+			"first":  dateTrunc("$buyer.first_order_at", granularity),
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"period": "$period", "user": "$user_id"},
+			"new": bson.M{"$max": bson.M{"$eq": bson.A{"$period", "$first"}}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$_id.period",
+			"active": bson.M{"$sum": 1},
+			"new":    bson.M{"$sum": bson.M{"$cond": bson.A{"$new", 1, 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var rows []struct {
+		Start  time.Time `bson:"_id"`
+		Active int       `bson:"active"`
+		New    int       `bson:"new"`
+	}
+	if err := aggregate(ctx, r.activityCol, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	points := make([]domain.ActiveUsersPoint, len(rows))
+	for i, row := range rows {
+		points[i] = domain.ActiveUsersPoint{
+			Start:     row.Start.UTC(),
+			Active:    row.Active,
+			New:       row.New,
+			Returning: row.Active - row.New,
+		}
+	}
+	return points, nil
+}
+
+// GetCohortActivity counts the users of every monthly cohort starting in the
+// range that were active in each month since, ordered by cohort and month.
+func (r *mongoActivityRepository) GetCohortActivity(ctx context.Context, dateRange domain.DateRange) ([]domain.CohortActivity, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"day": bson.M{"$gte": dateRange.From}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         buyerCollection,
+			"localField":   "user_id",
+			"foreignField": "_id",
+			"as":           "buyer",
+		}}},
+		{{Key: "$unwind", Value: "$buyer"}},
+		{{Key: "$set", Value: bson.M{
+			"cohort": dateTrunc("$buyer.first_order_at", domain.GranularityMonth),
+			"month":  dateTrunc("$day", domain.GranularityMonth),
+		}}},
+		{{Key: "$match", Value: bson.M{"cohort": bson.M{"$gte": dateRange.From, "$lt": dateRange.To}}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"cohort": "$cohort", "month": "$month", "user": "$user_id"}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"cohort": "$_id.cohort", "month": "$_id.month"},
+			"users": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.cohort", Value: 1}, {Key: "_id.month", Value: 1}}}},
+	}
+
+	var rows []struct {
+		ID struct {
+			Cohort time.Time `bson:"cohort"`
+			Month  time.Time `bson:"month"`
+		} `bson:"_id"`
+		Users int `bson:"users"`
+	}
+	if err := aggregate(ctx, r.activityCol, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	activity := make([]domain.CohortActivity, len(rows))
+	for i, row := range rows {
+		activity[i] = domain.CohortActivity{
+			Cohort: row.ID.Cohort.UTC(),
+			Month:  row.ID.Month.UTC(),
+			Users:  row.Users,
+		}
+	}
+	return activity, nil
+}
+
+func (r *mongoActivityRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.activityCol.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "day", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "day", Value: 1}}},
+	})
+	return err
+}
+
+func dateTrunc(date string, granularity domain.Granularity) bson.M {
+	return bson.M{"$dateTrunc": bson.M{
+		"date":        date,
+		"unit":        string(granularity),
+		"startOfWeek": "monday",
+	}}
+}
+
+func aggregate(ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline, results any) error {
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, results)
+}
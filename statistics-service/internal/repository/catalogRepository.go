@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoCatalogRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoCatalogRepository(db *mongo.Database) CatalogRepository {
+	return &mongoCatalogRepository{
+		collection: db.Collection(catalogCollection),
+	}
+}
+
+func (r *mongoCatalogRepository) GetProduct(ctx context.Context, id string) (*domain.CatalogProduct, error) {
+	var product domain.CatalogProduct
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&product)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *mongoCatalogRepository) SaveProduct(ctx context.Context, product *domain.CatalogProduct) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": product.ID}, product, options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,33 @@
+package repository
+
+// Collections of the statistics database.
+const (
+	orderEventCollection       = "order_events"
+	inventoryEventCollection   = "inventory_events"
+	orderProjectionCollection  = "order_projections"
+	userProjectionCollection   = "user_order_projections"
+	globalProjectionCollection = "global_order_projections"
+	userBucketCollection       = "user_order_buckets"
+	catalogCollection          = "catalog_products"
+	rollupCollection           = "rollups"
+	activityCollection         = "user_activity"
+	buyerCollection            = "buyers"
+	deadLetterCollection       = "dead_letters"
+	rebuildCollection          = "projection_rebuilds"
+)
+
+// EventCollections hold the raw events as they were received.
+var EventCollections = []string{orderEventCollection, inventoryEventCollection}
+
+// ProjectionCollections hold everything derived from the events. A rebuild
+// recomputes all of them.
+var ProjectionCollections = []string{
+	orderProjectionCollection,
+	userProjectionCollection,
+	globalProjectionCollection,
+	userBucketCollection,
+	catalogCollection,
+	rollupCollection,
+	activityCollection,
+	buyerCollection,
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoDeadLetterRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoDeadLetterRepository(db *mongo.Database) DeadLetterRepository {
+	return &mongoDeadLetterRepository{
+		collection: db.Collection(deadLetterCollection),
+	}
+}
+
+func (r *mongoDeadLetterRepository) SaveDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error {
+	if deadLetter.ID.IsZero() {
+		deadLetter.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, deadLetter)
+	return err
+}
+
+func (r *mongoDeadLetterRepository) GetDeadLetter(ctx context.Context, id primitive.ObjectID) (*domain.DeadLetter, error) {
+	var deadLetter domain.DeadLetter
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&deadLetter)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+// ListDeadLetters returns a page of dead letters, newest first, and the number
+// of dead letters matching the filter.
+func (r *mongoDeadLetterRepository) ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]domain.DeadLetter, int64, error) {
+	query := bson.M{}
+	if filter.Subject != "" {
+		query["subject"] = filter.Subject
+	}
+	if !filter.IncludeReplayed {
+		query["replayed_at"] = bson.M{"$exists": false}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "failed_at", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.Limit)).
+		SetLimit(int64(filter.Limit))
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var deadLetters []domain.DeadLetter
+	if err := cursor.All(ctx, &deadLetters); err != nil {
+		return nil, 0, err
+	}
+	return deadLetters, total, nil
+}
+
+// ClaimReplay marks a dead letter as replayed before it is processed again. It
+// reports false when the dead letter was already replayed or is being replayed
+// by another call.
+func (r *mongoDeadLetterRepository) ClaimReplay(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "replayed_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"replayed_at": at}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// ReleaseReplay clears the replay mark of a dead letter whose replay failed.
+func (r *mongoDeadLetterRepository) ReleaseReplay(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$unset": bson.M{"replayed_at": ""}})
+	return err
+}
+
+func (r *mongoDeadLetterRepository) RecordFailure(ctx context.Context, id primitive.ObjectID, errMsg string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"error": errMsg, "failed_at": time.Now()},
+		"$inc": bson.M{"attempts": 1},
+	})
+	return err
+}
+
+// DeleteDeadLetters removes the given dead letters, or all of them when ids is
+// empty; callers must make sure an empty ids is meant. With replayedOnly only
+// dead letters that were replayed are removed.
+func (r *mongoDeadLetterRepository) DeleteDeadLetters(ctx context.Context, ids []primitive.ObjectID, replayedOnly bool) (int64, error) {
+	query := bson.M{}
+	if len(ids) > 0 {
+		query["_id"] = bson.M{"$in": ids}
+	}
+	if replayedOnly {
+		query["replayed_at"] = bson.M{"$exists": true}
+	}
+
+	result, err := r.collection.DeleteMany(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -2,13 +2,82 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type StatsRepository interface {
 	SaveOrderEvent(ctx context.Context, event *domain.OrderEvent) error
 	SaveInventoryEvent(ctx context.Context, event *domain.InventoryEvent) error
-	GetUserOrderStatistics(ctx context.Context, userID string) (*domain.UserOrderStatistics, error)
-	GetUserOrderEvents(ctx context.Context, userID string) ([]*domain.OrderEvent, error)
+	CountEvents(ctx context.Context) (int64, error)
+	ScanOrderEvents(ctx context.Context, after primitive.ObjectID, fn func(event *domain.OrderEvent) error) (primitive.ObjectID, error)
+	ScanInventoryEvents(ctx context.Context, after primitive.ObjectID, fn func(event *domain.InventoryEvent) error) (primitive.ObjectID, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+// ProjectionRepository stores the order statistics projections. They are
+// updated incrementally as events arrive, so reads do not scan events.
+type ProjectionRepository interface {
+	GetOrderProjection(ctx context.Context, orderID string) (*domain.OrderProjection, error)
+	ReplaceOrderProjection(ctx context.Context, projection *domain.OrderProjection) (*domain.OrderProjection, error)
+	ApplyUserDelta(ctx context.Context, userID string, delta domain.OrderStatsDelta) (int, error)
+	ApplyGlobalDelta(ctx context.Context, delta domain.OrderStatsDelta) error
+	AddToUserBucket(ctx context.Context, key domain.UserBucketKey, delta domain.UserBucketDelta) error
+	GetUserProjection(ctx context.Context, userID string) (*domain.UserOrderProjection, error)
+	GetUserBuckets(ctx context.Context, userID string) ([]domain.UserOrderBucket, error)
+	GetGlobalProjection(ctx context.Context) (*domain.GlobalOrderProjection, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+type DeadLetterRepository interface {
+	SaveDeadLetter(ctx context.Context, deadLetter *domain.DeadLetter) error
+	GetDeadLetter(ctx context.Context, id primitive.ObjectID) (*domain.DeadLetter, error)
+	ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]domain.DeadLetter, int64, error)
+	ClaimReplay(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error)
+	ReleaseReplay(ctx context.Context, id primitive.ObjectID) error
+	RecordFailure(ctx context.Context, id primitive.ObjectID, errMsg string) error
+	DeleteDeadLetters(ctx context.Context, ids []primitive.ObjectID, replayedOnly bool) (int64, error)
+}
+
+// SalesRepository aggregates revenue and units sold over order creation time.
+type SalesRepository interface {
+	GetRevenueByPeriod(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.RevenuePoint, error)
+	GetRevenueByStatus(ctx context.Context, dateRange domain.DateRange) (map[string]domain.RevenuePoint, error)
+	GetProductSales(ctx context.Context, query domain.ProductSalesQuery) ([]domain.ProductSales, error)
+	GetCategorySales(ctx context.Context, dateRange domain.DateRange) ([]domain.CategorySales, error)
+	GetProductRefunds(ctx context.Context, dateRange domain.DateRange, limit int) ([]domain.ProductRefunds, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+// CatalogRepository stores the product read model built from inventory events.
+type CatalogRepository interface {
+	GetProduct(ctx context.Context, id string) (*domain.CatalogProduct, error)
+	SaveProduct(ctx context.Context, product *domain.CatalogProduct) error
+}
+
+// RollupRepository stores quarter-hour and daily metric totals.
+type RollupRepository interface {
+	AddToRollups(ctx context.Context, metric domain.Metric, at time.Time, value float64) error
+	GetRollups(ctx context.Context, metric domain.Metric, granularity domain.Granularity, dateRange domain.DateRange) ([]domain.Rollup, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+// ActivityRepository records on which days users ordered.
+type ActivityRepository interface {
+	AddOrders(ctx context.Context, key domain.ActivityKey, n int) error
+	GetActiveUsers(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.ActiveUsersPoint, error)
+	GetCohortActivity(ctx context.Context, dateRange domain.DateRange) ([]domain.CohortActivity, error)
+	EnsureIndexes(ctx context.Context) error
+}
+
+// RebuildRepository records projection rebuilds and manages the shadow
+// database they write to.
+type RebuildRepository interface {
+	SaveRebuild(ctx context.Context, rebuild *domain.Rebuild) error
+	GetRebuild(ctx context.Context, id primitive.ObjectID) (*domain.Rebuild, error)
+	GetLatestRebuild(ctx context.Context) (*domain.Rebuild, error)
+	DropShadow(ctx context.Context) error
+	SwapShadow(ctx context.Context, collections []string) error
 }
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const globalProjectionID = "global"
+
+type mongoProjectionRepository struct {
+	orderCol  *mongo.Collection
+	userCol   *mongo.Collection
+	globalCol *mongo.Collection
+	bucketCol *mongo.Collection
+}
+
+func NewMongoProjectionRepository(db *mongo.Database) ProjectionRepository {
+	return &mongoProjectionRepository{
+		orderCol:  db.Collection(orderProjectionCollection),
+		userCol:   db.Collection(userProjectionCollection),
+		globalCol: db.Collection(globalProjectionCollection),
+		bucketCol: db.Collection(userBucketCollection),
+	}
+}
+
+func (r *mongoProjectionRepository) GetOrderProjection(ctx context.Context, orderID string) (*domain.OrderProjection, error) {
+	return decodeOrderProjection(r.orderCol.FindOne(ctx, bson.M{"_id": orderID}))
+}
+
+// ReplaceOrderProjection stores the projection of an order and returns the one
+// it replaced, or nil if the order was not projected before.
+func (r *mongoProjectionRepository) ReplaceOrderProjection(ctx context.Context, projection *domain.OrderProjection) (*domain.OrderProjection, error) {
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.Before)
+	return decodeOrderProjection(r.orderCol.FindOneAndReplace(ctx, bson.M{"_id": projection.OrderID}, projection, opts))
+}
+
+// ApplyUserDelta adds delta to the counters of a user and returns the number
+// of orders the user had before.
+func (r *mongoProjectionRepository) ApplyUserDelta(ctx context.Context, userID string, delta domain.OrderStatsDelta) (int, error) {
+	inc := deltaUpdate(delta)
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	var prev domain.UserOrderProjection
+	err := r.userCol.FindOneAndUpdate(ctx, bson.M{"_id": userID}, bson.M{"$inc": inc}, opts).Decode(&prev)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return 0, err
+	}
+	return prev.TotalOrders, nil
+}
+
+// AddToUserBucket adds delta to the order bucket of a user.
+func (r *mongoProjectionRepository) AddToUserBucket(ctx context.Context, key domain.UserBucketKey, delta domain.UserBucketDelta) error {
+	_, err := r.bucketCol.UpdateOne(ctx,
+		bson.M{"user_id": key.UserID, "start": key.Start},
+		bson.M{"$inc": bson.M{"orders": delta.Orders, "completed": delta.Completed}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// GetUserBuckets returns the order buckets of a user that hold orders.
+func (r *mongoProjectionRepository) GetUserBuckets(ctx context.Context, userID string) ([]domain.UserOrderBucket, error) {
+	cursor, err := r.bucketCol.Find(ctx, bson.M{"user_id": userID, "orders": bson.M{"$gt": 0}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []domain.UserOrderBucket
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+func (r *mongoProjectionRepository) ApplyGlobalDelta(ctx context.Context, delta domain.OrderStatsDelta) error {
+	inc := deltaUpdate(delta)
+	inc["total_users"] = delta.Users
+
+	_, err := r.globalCol.UpdateOne(ctx, bson.M{"_id": globalProjectionID}, bson.M{"$inc": inc}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *mongoProjectionRepository) GetUserProjection(ctx context.Context, userID string) (*domain.UserOrderProjection, error) {
+	var projection domain.UserOrderProjection
+	err := r.userCol.FindOne(ctx, bson.M{"_id": userID}).Decode(&projection)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &projection, nil
+}
+
+func (r *mongoProjectionRepository) GetGlobalProjection(ctx context.Context) (*domain.GlobalOrderProjection, error) {
+	var projection domain.GlobalOrderProjection
+	err := r.globalCol.FindOne(ctx, bson.M{"_id": globalProjectionID}).Decode(&projection)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &projection, nil
+}
+
+func (r *mongoProjectionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.bucketCol.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "start", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	return err
+}
+
+func deltaUpdate(delta domain.OrderStatsDelta) bson.M {
+	inc := bson.M{"total_orders": delta.Orders}
+	for status, n := range delta.Statuses {
+		inc["status_counts."+status] = n
+	}
+	return inc
+}
+
+func decodeOrderProjection(result *mongo.SingleResult) (*domain.OrderProjection, error) {
+	var projection domain.OrderProjection
+	if err := result.Decode(&projection); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &projection, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// codeNamespaceNotFound is reported when a renamed collection does not exist.
+const codeNamespaceNotFound = 26
+
+// previousPrefix names the live collections a swap sets aside in the shadow
+// database until all collections are swapped.
+const previousPrefix = "previous_"
+
+// mongoRebuildRepository keeps the rebuild runs in the live database. Rebuilds
+// write their collections to a separate shadow database on the same
+// deployment, from where they are renamed over the live collections.
+type mongoRebuildRepository struct {
+	collection *mongo.Collection
+	live       *mongo.Database
+	shadow     *mongo.Database
+}
+
+func NewMongoRebuildRepository(live, shadow *mongo.Database) RebuildRepository {
+	return &mongoRebuildRepository{
+		collection: live.Collection(rebuildCollection),
+		live:       live,
+		shadow:     shadow,
+	}
+}
+
+func (r *mongoRebuildRepository) SaveRebuild(ctx context.Context, rebuild *domain.Rebuild) error {
+	if rebuild.ID.IsZero() {
+		rebuild.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": rebuild.ID}, rebuild, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *mongoRebuildRepository) GetRebuild(ctx context.Context, id primitive.ObjectID) (*domain.Rebuild, error) {
+	return decodeRebuild(r.collection.FindOne(ctx, bson.M{"_id": id}))
+}
+
+func (r *mongoRebuildRepository) GetLatestRebuild(ctx context.Context) (*domain.Rebuild, error) {
+	return decodeRebuild(r.collection.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.M{"started_at": -1})))
+}
+
+func decodeRebuild(result *mongo.SingleResult) (*domain.Rebuild, error) {
+	var rebuild domain.Rebuild
+	if err := result.Decode(&rebuild); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rebuild, nil
+}
+
+// DropShadow removes what an earlier rebuild left in the shadow database.
+func (r *mongoRebuildRepository) DropShadow(ctx context.Context) error {
+	return r.shadow.Drop(ctx)
+}
+
+// SwapShadow replaces the live collections with their shadow copies. Each
+// live collection is renamed aside into the shadow database and its shadow
+// copy renamed in its place, so a collection is only missing between the two
+// renames. A collection the rebuild never wrote to replaces its live
+// counterpart by being missing. If a rename fails, the collections set aside
+// so far are renamed back, so the live database is not left with a mix of old
+// and rebuilt collections.
+func (r *mongoRebuildRepository) SwapShadow(ctx context.Context, collections []string) error {
+	var aside []string
+	for _, name := range collections {
+		err := r.rename(ctx, r.live.Name(), name, r.shadow.Name(), previousPrefix+name)
+		if err == nil {
+			aside = append(aside, name)
+			err = r.rename(ctx, r.shadow.Name(), name, r.live.Name(), name)
+		}
+		if err != nil {
+			r.restore(context.WithoutCancel(ctx), aside)
+			return fmt.Errorf("failed to swap in %s: %w", name, err)
+		}
+	}
+
+	for _, name := range collections {
+		if err := r.shadow.Collection(previousPrefix + name).Drop(ctx); err != nil {
+			log.Printf("Failed to drop the previous %s collection: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// restore renames the collections set aside by a failed swap back over the
+// live ones.
+func (r *mongoRebuildRepository) restore(ctx context.Context, collections []string) {
+	for _, name := range collections {
+		if err := r.rename(ctx, r.shadow.Name(), previousPrefix+name, r.live.Name(), name); err != nil {
+			log.Printf("Failed to restore %s after a failed swap: %v", name, err)
+		}
+	}
+}
+
+// rename renames a collection, replacing the target. Renaming a collection
+// that does not exist drops the target, so that none is left behind.
+func (r *mongoRebuildRepository) rename(ctx context.Context, fromDB, from, toDB, to string) error {
+	err := r.live.Client().Database("admin").RunCommand(ctx, bson.D{
+		{Key: "renameCollection", Value: fromDB + "." + from},
+		{Key: "to", Value: toDB + "." + to},
+		{Key: "dropTarget", Value: true},
+	}).Err()
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == codeNamespaceNotFound {
+		return r.live.Client().Database(toDB).Collection(to).Drop(ctx)
+	}
+	return err
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type mongoStatsRepository struct {
@@ -16,14 +19,17 @@ type mongoStatsRepository struct {
 
 func NewMongoStatsRepository(db *mongo.Database) StatsRepository {
 	return &mongoStatsRepository{
-		orderCol:     db.Collection("order_events"),
-		inventoryCol: db.Collection("inventory_events"),
+		orderCol:     db.Collection(orderEventCollection),
+		inventoryCol: db.Collection(inventoryEventCollection),
 	}
 }
 
 func (r *mongoStatsRepository) SaveOrderEvent(ctx context.Context, event *domain.OrderEvent) error {
 	result, err := r.orderCol.InsertOne(ctx, event)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("order event %s: %w", event.EventID, domain.ErrDuplicateEvent)
+		}
 		log.Printf("Failed to save order event: %v", err)
 		return err
 	}
@@ -34,6 +40,9 @@ func (r *mongoStatsRepository) SaveOrderEvent(ctx context.Context, event *domain
 func (r *mongoStatsRepository) SaveInventoryEvent(ctx context.Context, event *domain.InventoryEvent) error {
 	result, err := r.inventoryCol.InsertOne(ctx, event)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("inventory event %s: %w", event.EventID, domain.ErrDuplicateEvent)
+		}
 		log.Printf("Failed to save inventory event: %v", err)
 		return err
 	}
@@ -41,63 +50,83 @@ func (r *mongoStatsRepository) SaveInventoryEvent(ctx context.Context, event *do
 	return nil
 }
 
-func (r *mongoStatsRepository) GetUserOrderStatistics(ctx context.Context, userID string) (*domain.UserOrderStatistics, error) {
-	stats := &domain.UserOrderStatistics{
-		UserID:        userID,
-		OrdersPerHour: make(map[int]int),
+// EnsureIndexes makes event IDs unique. Events stored before they carried an
+// ID are left out of the index.
+func (r *mongoStatsRepository) EnsureIndexes(ctx context.Context) error {
+	for _, collection := range []*mongo.Collection{r.orderCol, r.inventoryCol} {
+		_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "event_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"event_id": bson.M{"$exists": true}}),
+		})
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	filter := bson.M{"userid": userID}
-	cursor, err := r.orderCol.Find(ctx, filter)
-	if err != nil {
-		log.Printf("Failed to query order events: %v", err)
-		return nil, err
+// CountEvents returns the number of stored order and inventory events.
+func (r *mongoStatsRepository) CountEvents(ctx context.Context) (int64, error) {
+	var total int64
+	for _, collection := range []*mongo.Collection{r.orderCol, r.inventoryCol} {
+		count, err := collection.CountDocuments(ctx, bson.M{})
+		if err != nil {
+			return 0, err
+		}
+		total += count
 	}
-	defer cursor.Close(ctx)
+	return total, nil
+}
 
-	for cursor.Next(ctx) {
-		var evt domain.OrderEvent
-		if err := cursor.Decode(&evt); err != nil {
-			log.Printf("Failed to decode order event: %v", err)
-			continue
+// ScanOrderEvents hands the order events stored after the event with ID after
+// to fn in the order they were stored and returns the ID of the last one. A
+// zero after starts at the first event.
+func (r *mongoStatsRepository) ScanOrderEvents(ctx context.Context, after primitive.ObjectID, fn func(event *domain.OrderEvent) error) (primitive.ObjectID, error) {
+	return scanEvents(ctx, r.orderCol, after, func(cursor *mongo.Cursor) error {
+		var event domain.OrderEvent
+		if err := cursor.Decode(&event); err != nil {
+			return err
 		}
-		stats.TotalOrders++
-		hour := evt.CreatedAt.Hour()
-		stats.OrdersPerHour[hour]++
+		return fn(&event)
+	})
+}
 
-		switch evt.Status {
-		case "S_COMPLETED":
-			stats.TotalCompletedOrders++
-		case "S_CANCELLED":
-			stats.TotalCancelledOrders++
+// ScanInventoryEvents is ScanOrderEvents for inventory events.
+func (r *mongoStatsRepository) ScanInventoryEvents(ctx context.Context, after primitive.ObjectID, fn func(event *domain.InventoryEvent) error) (primitive.ObjectID, error) {
+	return scanEvents(ctx, r.inventoryCol, after, func(cursor *mongo.Cursor) error {
+		var event domain.InventoryEvent
+		if err := cursor.Decode(&event); err != nil {
+			return err
 		}
-	}
-
-	if err := cursor.Err(); err != nil {
-		log.Printf("Cursor error: %v", err)
-		return nil, err
-	}
-
-	return stats, nil
+		return fn(&event)
+	})
 }
 
-func (r *mongoStatsRepository) GetUserOrderEvents(ctx context.Context, userID string) ([]*domain.OrderEvent, error) {
-	filter := bson.M{"userid": userID}
-	cursor, err := r.orderCol.Find(ctx, filter)
+func scanEvents(ctx context.Context, collection *mongo.Collection, after primitive.ObjectID, fn func(cursor *mongo.Cursor) error) (primitive.ObjectID, error) {
+	filter := bson.M{}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
 	if err != nil {
-		log.Printf("Failed to query order events: %v", err)
-		return nil, err
+		return after, err
 	}
 	defer cursor.Close(ctx)
 
-	var events []*domain.OrderEvent
+	last := after
 	for cursor.Next(ctx) {
-		var evt domain.OrderEvent
-		if err := cursor.Decode(&evt); err != nil {
-			log.Printf("Failed to decode order event: %v", err)
-			continue
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return last, err
+		}
+		if err := fn(cursor); err != nil {
+			return last, err
 		}
-		events = append(events, &evt)
+		last = doc.ID
 	}
-	return events, nil
+	return last, cursor.Err()
 }
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoRollupRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoRollupRepository(db *mongo.Database) RollupRepository {
+	return &mongoRollupRepository{
+		collection: db.Collection(rollupCollection),
+	}
+}
+
+// AddToRollups adds value to the quarter-hour and daily rollups of metric that
+// at falls in. Both use UTC boundaries.
+func (r *mongoRollupRepository) AddToRollups(ctx context.Context, metric domain.Metric, at time.Time, value float64) error {
+	at = at.UTC()
+	starts := map[domain.Granularity]time.Time{
+		domain.GranularityQuarterHour: at.Truncate(domain.RollupBucketSize),
+		domain.GranularityDay:         domain.BucketStart(at, domain.GranularityDay),
+	}
+
+	for granularity, start := range starts {
+		_, err := r.collection.UpdateOne(ctx,
+			bson.M{"metric": metric, "granularity": granularity, "start": start},
+			bson.M{"$inc": bson.M{"value": value}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRollups returns the rollups of metric and granularity that start in the
+// range, ordered by start.
+func (r *mongoRollupRepository) GetRollups(ctx context.Context, metric domain.Metric, granularity domain.Granularity, dateRange domain.DateRange) ([]domain.Rollup, error) {
+	filter := bson.M{
+		"metric":      metric,
+		"granularity": granularity,
+		"start":       bson.M{"$gte": dateRange.From, "$lt": dateRange.To},
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"start": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rollups []domain.Rollup
+	if err := cursor.All(ctx, &rollups); err != nil {
+		return nil, err
+	}
+	return rollups, nil
+}
+
+func (r *mongoRollupRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "metric", Value: 1}, {Key: "granularity", Value: 1}, {Key: "start", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	})
+	return err
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// mongoSalesRepository aggregates sales over the order projections, which hold
+// the latest state of every order, so orders with several events are counted
+// once.
+type mongoSalesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoSalesRepository(db *mongo.Database) SalesRepository {
+	return &mongoSalesRepository{
+		collection: db.Collection(orderProjectionCollection),
+	}
+}
+
+func (r *mongoSalesRepository) GetRevenueByPeriod(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.RevenuePoint, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: revenueMatch(dateRange)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     dateTrunc("$created_at", granularity),
+			"revenue": bson.M{"$sum": "$total"},
+			"orders":  bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var rows []struct {
+		PeriodStart time.Time `bson:"_id"`
+		Revenue     float64   `bson:"revenue"`
+		Orders      int       `bson:"orders"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	points := make([]domain.RevenuePoint, len(rows))
+	for i, row := range rows {
+		points[i] = domain.RevenuePoint{
+			PeriodStart: row.PeriodStart.UTC(),
+			Revenue:     row.Revenue,
+			Orders:      row.Orders,
+		}
+	}
+	return points, nil
+}
+
+// GetRevenueByStatus returns the order count and revenue per order status.
+func (r *mongoSalesRepository) GetRevenueByStatus(ctx context.Context, dateRange domain.DateRange) (map[string]domain.RevenuePoint, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: rangeMatch(dateRange)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$status",
+			"revenue":  bson.M{"$sum": "$total"},
+			"orders":   bson.M{"$sum": 1},
+			"refunded": bson.M{"$sum": "$refunded_total"},
+		}}},
+	}
+
+	var rows []struct {
+		Status   string  `bson:"_id"`
+		Revenue  float64 `bson:"revenue"`
+		Orders   int     `bson:"orders"`
+		Refunded float64 `bson:"refunded"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	byStatus := make(map[string]domain.RevenuePoint, len(rows))
+	for _, row := range rows {
+		byStatus[row.Status] = domain.RevenuePoint{Revenue: row.Revenue, Orders: row.Orders, Refunded: row.Refunded}
+	}
+	return byStatus, nil
+}
+
+// GetProductSales returns units, revenue and order count per product, ranked
+// by the query metric. Products are named from the catalog, and the category
+// filter applies to the category a product was in when it was ordered. A
+// limit of zero returns all products.
+func (r *mongoSalesRepository) GetProductSales(ctx context.Context, query domain.ProductSalesQuery) ([]domain.ProductSales, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: revenueMatch(query.Range)}},
+		{{Key: "$unwind", Value: "$items"}},
+	}
+	pipeline = append(pipeline, categoryStages()...)
+	if query.CategoryID != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"category": query.CategoryID}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":     "$items.product_id",
+			"name":    bson.M{"$first": "$product.name"},
+			"units":   bson.M{"$sum": "$items.quantity"},
+			"revenue": bson.M{"$sum": bson.M{"$multiply": bson.A{"$items.price", "$items.quantity"}}},
+			"orders":  bson.M{"$addToSet": "$_id"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"name":    1,
+			"units":   1,
+			"revenue": 1,
+			"orders":  bson.M{"$size": "$orders"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: string(query.SortBy), Value: -1}, {Key: "_id", Value: 1}}}},
+	)
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+	}
+
+	var rows []struct {
+		ProductID string  `bson:"_id"`
+		Name      string  `bson:"name"`
+		Units     int     `bson:"units"`
+		Revenue   float64 `bson:"revenue"`
+		Orders    int     `bson:"orders"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	sales := make([]domain.ProductSales, len(rows))
+	for i, row := range rows {
+		sales[i] = domain.ProductSales(row)
+	}
+	return sales, nil
+}
+
+// GetProductRefunds returns the units sold and refunded per product over the
+// orders that were sold in the range, ranked by refund rate. A limit of zero
+// returns all products.
+func (r *mongoSalesRepository) GetProductRefunds(ctx context.Context, dateRange domain.DateRange, limit int) ([]domain.ProductRefunds, error) {
+	match := rangeMatch(dateRange)
+	match["status"] = bson.M{"$in": domain.SoldStatuses}
+
+	refunded := bson.M{"$ifNull": bson.A{"$items.refunded_quantity", 0}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$items"}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         catalogCollection,
+			"localField":   "items.product_id",
+			"foreignField": "_id",
+			"as":           "product",
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             "$items.product_id",
+			"name":            bson.M{"$first": bson.M{"$first": "$product.name"}},
+			"units":           bson.M{"$sum": "$items.quantity"},
+			"refunded_units":  bson.M{"$sum": refunded},
+			"refunded_amount": bson.M{"$sum": bson.M{"$multiply": bson.A{"$items.price", refunded}}},
+		}}},
+		{{Key: "$set", Value: bson.M{"refund_rate": bson.M{"$cond": bson.A{
+			bson.M{"$gt": bson.A{"$units", 0}},
+			bson.M{"$divide": bson.A{"$refunded_units", "$units"}},
+			0,
+		}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "refund_rate", Value: -1}, {Key: "refunded_units", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	var rows []struct {
+		ProductID      string  `bson:"_id"`
+		Name           string  `bson:"name"`
+		Units          int     `bson:"units"`
+		RefundedUnits  int     `bson:"refunded_units"`
+		RefundedAmount float64 `bson:"refunded_amount"`
+		RefundRate     float64 `bson:"refund_rate"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	refunds := make([]domain.ProductRefunds, len(rows))
+	for i, row := range rows {
+		refunds[i] = domain.ProductRefunds(row)
+	}
+	return refunds, nil
+}
+
+// GetCategorySales returns units, revenue and order count per category. Order
+// items are attributed to the category their product was in when the order
+// was created; items of unknown products are grouped under an empty category.
+func (r *mongoSalesRepository) GetCategorySales(ctx context.Context, dateRange domain.DateRange) ([]domain.CategorySales, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: revenueMatch(dateRange)}},
+		{{Key: "$unwind", Value: "$items"}},
+	}
+	pipeline = append(pipeline, categoryStages()...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":     "$category",
+			"units":   bson.M{"$sum": "$items.quantity"},
+			"revenue": bson.M{"$sum": bson.M{"$multiply": bson.A{"$items.price", "$items.quantity"}}},
+			"orders":  bson.M{"$addToSet": "$_id"},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"units":   1,
+			"revenue": 1,
+			"orders":  bson.M{"$size": "$orders"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "revenue", Value: -1}, {Key: "_id", Value: 1}}}},
+	)
+
+	var rows []struct {
+		CategoryID string  `bson:"_id"`
+		Units      int     `bson:"units"`
+		Revenue    float64 `bson:"revenue"`
+		Orders     int     `bson:"orders"`
+	}
+	if err := r.aggregate(ctx, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	sales := make([]domain.CategorySales, len(rows))
+	for i, row := range rows {
+		sales[i] = domain.CategorySales(row)
+	}
+	return sales, nil
+}
+
+func (r *mongoSalesRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "status", Value: 1}}},
+	})
+	return err
+}
+
+func (r *mongoSalesRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline, results any) error {
+	return aggregate(ctx, r.collection, pipeline, results)
+}
+
+// categoryStages joins unwound order items with the catalog and sets category
+// to the category the product was in when the order was created. Items of
+// unknown products get an empty category.
+func categoryStages() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         catalogCollection,
+			"localField":   "items.product_id",
+			"foreignField": "_id",
+			"as":           "product",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$product", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$set", Value: bson.M{"category": bson.M{"$let": bson.M{
+			"vars": bson.M{"assigned": bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$product.categories", bson.A{}}},
+				"cond":  bson.M{"$lte": bson.A{"$$this.from", "$created_at"}},
+			}}},
+			"in": bson.M{"$ifNull": bson.A{
+				bson.M{"$last": "$$assigned.category_id"},
+				bson.M{"$first": "$product.categories.category_id"},
+				"",
+			}},
+		}}}}},
+	}
+}
+
+// rangeMatch matches the orders created in the range, leaving out the
+// tombstones of deleted orders.
+func rangeMatch(dateRange domain.DateRange) bson.M {
+	return bson.M{
+		"created_at": bson.M{"$gte": dateRange.From, "$lt": dateRange.To},
+		"deleted":    bson.M{"$ne": true},
+	}
+}
+
+func revenueMatch(dateRange domain.DateRange) bson.M {
+	match := rangeMatch(dateRange)
+	match["status"] = bson.M{"$in": domain.RevenueStatuses}
+	return match
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventProcessor processes a raw stream message with its headers the same way
+// the stream consumer does.
+type EventProcessor interface {
+	Process(ctx context.Context, subject string, headers map[string]string, data []byte) error
+}
+
+type DeadLetterUseCase interface {
+	ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]domain.DeadLetter, int64, error)
+	GetDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error)
+	PurgeDeadLetters(ctx context.Context, ids []string, replayedOnly, all bool) (int64, error)
+}
+
+type deadLetterUseCase struct {
+	repo      repository.DeadLetterRepository
+	processor EventProcessor
+}
+
+func NewDeadLetterUseCase(repo repository.DeadLetterRepository, processor EventProcessor) DeadLetterUseCase {
+	return &deadLetterUseCase{
+		repo:      repo,
+		processor: processor,
+	}
+}
+
+func (uc *deadLetterUseCase) ListDeadLetters(ctx context.Context, filter domain.DeadLetterFilter) ([]domain.DeadLetter, int64, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+	return uc.repo.ListDeadLetters(ctx, filter)
+}
+
+func (uc *deadLetterUseCase) GetDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid dead letter ID %q", domain.ErrInvalidArgument, id)
+	}
+
+	deadLetter, err := uc.repo.GetDeadLetter(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+	return deadLetter, nil
+}
+
+// ReplayDeadLetter processes the stored payload again. The dead letter is
+// claimed as replayed first, so concurrent replays apply it once. A failed
+// replay releases the claim, is recorded on the dead letter and is reported as
+// a failed precondition.
+func (uc *deadLetterUseCase) ReplayDeadLetter(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	deadLetter, err := uc.GetDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter.ReplayedAt != nil {
+		return nil, fmt.Errorf("%w: dead letter %s was already replayed", domain.ErrFailedPrecondition, id)
+	}
+
+	now := time.Now()
+	claimed, err := uc.repo.ClaimReplay(ctx, deadLetter.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("%w: dead letter %s was already replayed", domain.ErrFailedPrecondition, id)
+	}
+
+	if err := uc.processor.Process(ctx, deadLetter.Subject, deadLetter.Headers, deadLetter.Payload); err != nil {
+		if releaseErr := uc.repo.ReleaseReplay(ctx, deadLetter.ID); releaseErr != nil {
+			return nil, releaseErr
+		}
+		if recordErr := uc.repo.RecordFailure(ctx, deadLetter.ID, err.Error()); recordErr != nil {
+			return nil, recordErr
+		}
+		return nil, fmt.Errorf("%w: replay of dead letter %s failed: %v", domain.ErrFailedPrecondition, id, err)
+	}
+
+	deadLetter.ReplayedAt = &now
+	return deadLetter, nil
+}
+
+// PurgeDeadLetters removes the given dead letters. Removing all of them takes
+// the explicit all flag instead of ids, so an empty list never purges
+// everything by accident.
+func (uc *deadLetterUseCase) PurgeDeadLetters(ctx context.Context, ids []string, replayedOnly, all bool) (int64, error) {
+	switch {
+	case all && len(ids) > 0:
+		return 0, fmt.Errorf("%w: ids and all are mutually exclusive", domain.ErrInvalidArgument)
+	case !all && len(ids) == 0:
+		return 0, fmt.Errorf("%w: ids or all is required", domain.ErrInvalidArgument)
+	}
+
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	for i, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid dead letter ID %q", domain.ErrInvalidArgument, id)
+		}
+		objectIDs[i] = objectID
+	}
+	return uc.repo.DeleteDeadLetters(ctx, objectIDs, replayedOnly)
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// rebuildProgressInterval is the number of events between progress updates.
+const rebuildProgressInterval = 1000
+
+// ShadowFactory builds the statistics use case over the empty shadow
+// collections, and a processor that feeds raw stream messages into it.
+type ShadowFactory func(ctx context.Context) (StatsUseCase, EventProcessor, error)
+
+type StreamState struct {
+	Messages     uint64
+	LastSequence uint64
+}
+
+// EventStream replays the order and inventory streams.
+type EventStream interface {
+	StreamStates(ctx context.Context) (map[string]StreamState, error)
+	// ReplayStream hands the messages of stream with sequences in
+	// (after, until] to processor in order and calls progress after each.
+	ReplayStream(ctx context.Context, stream string, after, until uint64, processor EventProcessor, progress func(ctx context.Context) error) error
+}
+
+// EventConsumer is the live stream consumer. It is paused while a rebuild
+// catches up with the last events and swaps its collections in.
+type EventConsumer interface {
+	Pause()
+	Resume(ctx context.Context) error
+}
+
+type RebuildUseCase interface {
+	// StartRebuild starts a rebuild in the background.
+	StartRebuild(ctx context.Context, source domain.RebuildSource) (*domain.Rebuild, error)
+	// Rebuild runs a rebuild to completion.
+	Rebuild(ctx context.Context, source domain.RebuildSource) (*domain.Rebuild, error)
+	// GetRebuild returns a rebuild, the latest one for an empty ID.
+	GetRebuild(ctx context.Context, id string) (*domain.Rebuild, error)
+	// Backfill starts a rebuild from the stored events in the background if
+	// the projections were never built or were built with an older
+	// ProjectionVersion, and reports whether it did.
+	Backfill(ctx context.Context) (bool, error)
+}
+
+type rebuildUseCase struct {
+	rebuilds repository.RebuildRepository
+	events   repository.StatsRepository
+	shadow   ShadowFactory
+	stream   EventStream
+	consumer EventConsumer
+	running  atomic.Bool
+}
+
+// NewRebuildUseCase creates the rebuild use case. Without a stream only raw
+// events can be replayed, and without a consumer nothing is paused, which is
+// only safe while no other instance consumes the streams.
+func NewRebuildUseCase(
+	rebuilds repository.RebuildRepository,
+	events repository.StatsRepository,
+	shadow ShadowFactory,
+	stream EventStream,
+	consumer EventConsumer,
+) RebuildUseCase {
+	return &rebuildUseCase{
+		rebuilds: rebuilds,
+		events:   events,
+		shadow:   shadow,
+		stream:   stream,
+		consumer: consumer,
+	}
+}
+
+func (uc *rebuildUseCase) StartRebuild(ctx context.Context, source domain.RebuildSource) (*domain.Rebuild, error) {
+	rebuild, err := uc.begin(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	started := *rebuild
+
+	go uc.run(context.Background(), rebuild)
+	return &started, nil
+}
+
+func (uc *rebuildUseCase) Rebuild(ctx context.Context, source domain.RebuildSource) (*domain.Rebuild, error) {
+	rebuild, err := uc.begin(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.run(ctx, rebuild)
+	return rebuild, err
+}
+
+// Backfill projects the events stored before the projections existed or
+// before their layout changed. A database that holds events but never had a
+// rebuild of the current ProjectionVersion is rebuilt from them once;
+// afterwards the rebuild is recorded and the projections are kept up to date
+// by the consumers.
+func (uc *rebuildUseCase) Backfill(ctx context.Context) (bool, error) {
+	latest, err := uc.rebuilds.GetLatestRebuild(ctx)
+	if err != nil {
+		return false, err
+	}
+	if latest != nil && latest.Version >= domain.ProjectionVersion {
+		return false, nil
+	}
+
+	count, err := uc.events.CountEvents(ctx)
+	if err != nil {
+		return false, err
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	if _, err := uc.StartRebuild(ctx, domain.RebuildFromEvents); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (uc *rebuildUseCase) GetRebuild(ctx context.Context, id string) (*domain.Rebuild, error) {
+	var rebuild *domain.Rebuild
+	if id == "" {
+		latest, err := uc.rebuilds.GetLatestRebuild(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rebuild = latest
+	} else {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid rebuild ID %q", domain.ErrInvalidArgument, id)
+		}
+		rebuild, err = uc.rebuilds.GetRebuild(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if rebuild == nil {
+		return nil, domain.ErrRebuildNotFound
+	}
+	return rebuild, nil
+}
+
+// begin records a new rebuild. Only one rebuild runs at a time, since they
+// share the shadow collections.
+func (uc *rebuildUseCase) begin(ctx context.Context, source domain.RebuildSource) (*domain.Rebuild, error) {
+	if source == domain.RebuildFromStream && uc.stream == nil {
+		return nil, fmt.Errorf("%w: rebuilding from the stream is not available", domain.ErrFailedPrecondition)
+	}
+	if !uc.running.CompareAndSwap(false, true) {
+		return nil, fmt.Errorf("%w: a rebuild is already running", domain.ErrFailedPrecondition)
+	}
+
+	now := time.Now()
+	rebuild := &domain.Rebuild{
+		Source:    source,
+		Version:   domain.ProjectionVersion,
+		State:     domain.RebuildRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.rebuilds.SaveRebuild(ctx, rebuild); err != nil {
+		uc.running.Store(false)
+		return nil, err
+	}
+	return rebuild, nil
+}
+
+func (uc *rebuildUseCase) run(ctx context.Context, rebuild *domain.Rebuild) error {
+	defer uc.running.Store(false)
+
+	log.Printf("Rebuild %s: rebuilding projections from %s", rebuild.ID.Hex(), rebuild.Source)
+	err := uc.rebuild(ctx, rebuild)
+
+	now := time.Now()
+	rebuild.UpdatedAt = now
+	rebuild.FinishedAt = &now
+	if err != nil {
+		rebuild.State = domain.RebuildFailed
+		rebuild.Error = err.Error()
+		log.Printf("Rebuild %s failed after %d events: %v", rebuild.ID.Hex(), rebuild.Processed, err)
+	} else {
+		rebuild.State = domain.RebuildCompleted
+		log.Printf("Rebuild %s completed with %d events", rebuild.ID.Hex(), rebuild.Processed)
+	}
+
+	if saveErr := uc.rebuilds.SaveRebuild(ctx, rebuild); saveErr != nil {
+		log.Printf("Failed to save rebuild %s: %v", rebuild.ID.Hex(), saveErr)
+		if err == nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+// rebuild replays all events into fresh shadow collections. Events that
+// arrive meanwhile are replayed in a second pass with the live consumer
+// paused, so that none of them only reaches the old projections, and the
+// shadow collections are then swapped in.
+func (uc *rebuildUseCase) rebuild(ctx context.Context, rebuild *domain.Rebuild) error {
+	if err := uc.rebuilds.DropShadow(ctx); err != nil {
+		return fmt.Errorf("failed to reset shadow collections: %w", err)
+	}
+	shadow, processor, err := uc.shadow(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set up shadow collections: %w", err)
+	}
+
+	var replay func(ctx context.Context) error
+	collections := repository.ProjectionCollections
+	switch rebuild.Source {
+	case domain.RebuildFromStream:
+		states, err := uc.stream.StreamStates(ctx)
+		if err != nil {
+			return err
+		}
+		for _, state := range states {
+			rebuild.Total += int64(state.Messages)
+		}
+		replay = uc.streamReplay(rebuild, processor)
+		// The shadow event collections were rebuilt from the stream too.
+		collections = slices.Concat(repository.EventCollections, collections)
+	default:
+		rebuild.Total, err = uc.events.CountEvents(ctx)
+		if err != nil {
+			return err
+		}
+		replay = uc.eventReplay(rebuild, shadow)
+	}
+	if err := uc.rebuilds.SaveRebuild(ctx, rebuild); err != nil {
+		return err
+	}
+
+	if err := replay(ctx); err != nil {
+		return err
+	}
+
+	if uc.consumer != nil {
+		uc.consumer.Pause()
+		defer func() {
+			if err := uc.consumer.Resume(context.Background()); err != nil {
+				log.Printf("Failed to resume event consumers after rebuild %s: %v", rebuild.ID.Hex(), err)
+			}
+		}()
+	}
+	if err := replay(ctx); err != nil {
+		return err
+	}
+
+	if err := uc.rebuilds.SwapShadow(ctx, collections); err != nil {
+		return err
+	}
+	return nil
+}
+
+// eventReplay returns a function that replays the stored events not replayed
+// by its previous calls into shadow.
+func (uc *rebuildUseCase) eventReplay(rebuild *domain.Rebuild, shadow StatsUseCase) func(ctx context.Context) error {
+	var lastOrder, lastInventory primitive.ObjectID
+	return func(ctx context.Context) error {
+		var err error
+		lastOrder, err = uc.events.ScanOrderEvents(ctx, lastOrder, func(event *domain.OrderEvent) error {
+			if err := shadow.HandleOrderEvent(ctx, event); err != nil {
+				return err
+			}
+			return uc.advance(ctx, rebuild)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to replay order events: %w", err)
+		}
+
+		lastInventory, err = uc.events.ScanInventoryEvents(ctx, lastInventory, func(event *domain.InventoryEvent) error {
+			if err := shadow.HandleInventoryEvent(ctx, event); err != nil {
+				return err
+			}
+			return uc.advance(ctx, rebuild)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to replay inventory events: %w", err)
+		}
+		return nil
+	}
+}
+
+// streamReplay returns a function that replays the stream messages published
+// since its previous call into processor.
+func (uc *rebuildUseCase) streamReplay(rebuild *domain.Rebuild, processor EventProcessor) func(ctx context.Context) error {
+	replayed := make(map[string]uint64)
+	return func(ctx context.Context) error {
+		states, err := uc.stream.StreamStates(ctx)
+		if err != nil {
+			return err
+		}
+		for stream, state := range states {
+			err := uc.stream.ReplayStream(ctx, stream, replayed[stream], state.LastSequence, processor, func(ctx context.Context) error {
+				return uc.advance(ctx, rebuild)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to replay stream %s: %w", stream, err)
+			}
+			replayed[stream] = state.LastSequence
+		}
+		return nil
+	}
+}
+
+// advance counts a replayed event and records the progress now and then.
+func (uc *rebuildUseCase) advance(ctx context.Context, rebuild *domain.Rebuild) error {
+	rebuild.Processed++
+	if rebuild.Processed%rebuildProgressInterval != 0 {
+		return nil
+	}
+
+	rebuild.UpdatedAt = time.Now()
+	log.Printf("Rebuild %s: processed %d of %d events", rebuild.ID.Hex(), rebuild.Processed, rebuild.Total)
+	return uc.rebuilds.SaveRebuild(ctx, rebuild)
+}
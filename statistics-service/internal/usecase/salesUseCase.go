@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
+)
+
+const (
+	// defaultSalesWindow is the range used when a request leaves out the start.
+	defaultSalesWindow = 30 * 24 * time.Hour
+	// defaultTrendWindow is the window trending products are compared over.
+	defaultTrendWindow = 7 * 24 * time.Hour
+	defaultRankLimit   = 10
+	maxRankLimit       = 100
+)
+
+type SalesUseCase interface {
+	GetRevenue(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.RevenuePoint, error)
+	GetRevenueSummary(ctx context.Context, dateRange domain.DateRange) (*domain.RevenueSummary, error)
+	GetProductSales(ctx context.Context, dateRange domain.DateRange, limit int) ([]domain.ProductSales, error)
+	GetCategorySales(ctx context.Context, dateRange domain.DateRange) ([]domain.CategorySales, error)
+	GetProductRefunds(ctx context.Context, dateRange domain.DateRange, limit int) ([]domain.ProductRefunds, error)
+	GetTopProducts(ctx context.Context, query domain.ProductSalesQuery) ([]domain.ProductSales, error)
+	GetTrendingProducts(ctx context.Context, end time.Time, window time.Duration, query domain.ProductSalesQuery) ([]domain.ProductTrend, error)
+}
+
+type salesUseCase struct {
+	repo repository.SalesRepository
+}
+
+func NewSalesUseCase(repo repository.SalesRepository) SalesUseCase {
+	return &salesUseCase{repo: repo}
+}
+
+func (uc *salesUseCase) GetRevenue(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.RevenuePoint, error) {
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.GetRevenueByPeriod(ctx, dateRange, granularity)
+}
+
+// GetRevenueSummary returns the revenue of the orders in the range together
+// with the refunds for returned items, the average order value and the
+// revenue of completed and cancelled orders.
+func (uc *salesUseCase) GetRevenueSummary(ctx context.Context, dateRange domain.DateRange) (*domain.RevenueSummary, error) {
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus, err := uc.repo.GetRevenueByStatus(ctx, dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.RevenueSummary{}
+	for status, point := range byStatus {
+		if domain.IsRevenueStatus(status) {
+			summary.Revenue += point.Revenue
+			summary.RefundedRevenue += point.Refunded
+			summary.Orders += point.Orders
+		}
+		switch {
+		case domain.IsCompletedStatus(status):
+			summary.CompletedRevenue += point.Revenue
+			summary.CompletedOrders += point.Orders
+		case status == domain.EventStatusCancelled:
+			summary.CancelledRevenue += point.Revenue
+			summary.CancelledOrders += point.Orders
+		}
+	}
+	summary.NetRevenue = summary.Revenue - summary.RefundedRevenue
+	if summary.Orders > 0 {
+		summary.AverageOrderValue = summary.Revenue / float64(summary.Orders)
+	}
+	return summary, nil
+}
+
+func (uc *salesUseCase) GetProductSales(ctx context.Context, dateRange domain.DateRange, limit int) ([]domain.ProductSales, error) {
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidArgument)
+	}
+	return uc.repo.GetProductSales(ctx, domain.ProductSalesQuery{
+		Range:  dateRange,
+		SortBy: domain.SalesMetricRevenue,
+		Limit:  limit,
+	})
+}
+
+func (uc *salesUseCase) GetCategorySales(ctx context.Context, dateRange domain.DateRange) ([]domain.CategorySales, error) {
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.GetCategorySales(ctx, dateRange)
+}
+
+// GetProductRefunds returns the refund rate of every product sold in the
+// range, highest first.
+func (uc *salesUseCase) GetProductRefunds(ctx context.Context, dateRange domain.DateRange, limit int) ([]domain.ProductRefunds, error) {
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidArgument)
+	}
+	return uc.repo.GetProductRefunds(ctx, dateRange, limit)
+}
+
+// GetTopProducts ranks the products by the query metric over the query range.
+func (uc *salesUseCase) GetTopProducts(ctx context.Context, query domain.ProductSalesQuery) ([]domain.ProductSales, error) {
+	dateRange, err := normalizeRange(query.Range)
+	if err != nil {
+		return nil, err
+	}
+	query.Range = dateRange
+	if query.Limit, err = rankLimit(query.Limit); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetProductSales(ctx, query)
+}
+
+// GetTrendingProducts compares the window ending at end with the window
+// before it and returns the products whose sales grew, fastest growing first.
+// Products without sales in the previous window follow, best selling first.
+func (uc *salesUseCase) GetTrendingProducts(ctx context.Context, end time.Time, window time.Duration, query domain.ProductSalesQuery) ([]domain.ProductTrend, error) {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if window == 0 {
+		window = defaultTrendWindow
+	}
+	if window < 0 {
+		return nil, fmt.Errorf("%w: window must be positive", domain.ErrInvalidArgument)
+	}
+	limit, err := rankLimit(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both windows are read in full, a product may only rank in one of them.
+	query.Limit = 0
+	query.Range = domain.DateRange{From: end.Add(-window), To: end}
+	current, err := uc.repo.GetProductSales(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	query.Range = domain.DateRange{From: end.Add(-2 * window), To: end.Add(-window)}
+	previous, err := uc.repo.GetProductSales(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	previousByID := make(map[string]domain.ProductSales, len(previous))
+	for _, sales := range previous {
+		previousByID[sales.ProductID] = sales
+	}
+
+	var trends []domain.ProductTrend
+	for _, sales := range current {
+		trend := domain.ProductTrend{
+			ProductID: sales.ProductID,
+			Name:      sales.Name,
+			Current:   sales,
+			Previous:  previousByID[sales.ProductID],
+		}
+		before, now := trend.Previous.Value(query.SortBy), sales.Value(query.SortBy)
+		switch {
+		case before == 0:
+			trend.New = true
+		case now > before:
+			trend.Growth = (now - before) / before
+		default:
+			continue
+		}
+		trends = append(trends, trend)
+	}
+
+	slices.SortFunc(trends, func(a, b domain.ProductTrend) int {
+		if a.New != b.New {
+			if a.New {
+				return 1
+			}
+			return -1
+		}
+		if c := cmp.Compare(b.Growth, a.Growth); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Current.Value(query.SortBy), a.Current.Value(query.SortBy))
+	})
+	if len(trends) > limit {
+		trends = trends[:limit]
+	}
+	return trends, nil
+}
+
+func rankLimit(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidArgument)
+	case limit == 0:
+		return defaultRankLimit, nil
+	default:
+		return min(limit, maxRankLimit), nil
+	}
+}
+
+// normalizeRange fills in a missing end with now and a missing start with the
+// default window before the end.
+func normalizeRange(dateRange domain.DateRange) (domain.DateRange, error) {
+	if dateRange.To.IsZero() {
+		dateRange.To = time.Now()
+	}
+	if dateRange.From.IsZero() {
+		dateRange.From = dateRange.To.Add(-defaultSalesWindow)
+	}
+	if !dateRange.From.Before(dateRange.To) {
+		return dateRange, fmt.Errorf("%w: range start must be before its end", domain.ErrInvalidArgument)
+	}
+	return dateRange, nil
+}
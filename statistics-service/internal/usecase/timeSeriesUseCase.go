@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
+)
+
+// maxTimeSeriesPoints bounds the size of a time series response.
+const maxTimeSeriesPoints = 5000
+
+type TimeSeriesUseCase interface {
+	GetTimeSeries(ctx context.Context, query domain.TimeSeriesQuery) ([]domain.TimeSeriesPoint, error)
+}
+
+type timeSeriesUseCase struct {
+	rollups repository.RollupRepository
+}
+
+func NewTimeSeriesUseCase(rollups repository.RollupRepository) TimeSeriesUseCase {
+	return &timeSeriesUseCase{rollups: rollups}
+}
+
+// GetTimeSeries returns one point per bucket of the query range, including
+// buckets without data. Buckets are aligned to the query location. Daily
+// rollups are used for day, week and month series in UTC; every other series
+// is summed from quarter-hour rollups, which fall in one bucket of every zone.
+func (uc *timeSeriesUseCase) GetTimeSeries(ctx context.Context, query domain.TimeSeriesQuery) ([]domain.TimeSeriesPoint, error) {
+	if query.Location == nil {
+		query.Location = time.UTC
+	}
+	dateRange, err := normalizeRange(query.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []domain.TimeSeriesPoint
+	index := make(map[int64]int)
+	end := dateRange.To.In(query.Location)
+	for start := domain.BucketStart(dateRange.From.In(query.Location), query.Granularity); start.Before(end); start = domain.NextBucket(start, query.Granularity) {
+		if len(points) == maxTimeSeriesPoints {
+			return nil, fmt.Errorf("%w: range has more than %d %s points", domain.ErrInvalidArgument, maxTimeSeriesPoints, query.Granularity)
+		}
+		index[start.Unix()] = len(points)
+		points = append(points, domain.TimeSeriesPoint{Start: start})
+	}
+	if len(points) == 0 {
+		return points, nil
+	}
+
+	source := domain.GranularityQuarterHour
+	if isUTC(query.Location, dateRange.From) && query.Granularity != domain.GranularityHour {
+		source = domain.GranularityDay
+	}
+	rollups, err := uc.rollups.GetRollups(ctx, query.Metric, source, domain.DateRange{
+		From: points[0].Start,
+		To:   domain.NextBucket(points[len(points)-1].Start, query.Granularity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rollup := range rollups {
+		start := domain.BucketStart(rollup.Start.In(query.Location), query.Granularity)
+		if i, ok := index[start.Unix()]; ok {
+			points[i].Value += rollup.Value
+		}
+	}
+	return points, nil
+}
+
+// isUTC reports whether loc is UTC under any name: a zone with offset zero at
+// at that never changes.
+func isUTC(loc *time.Location, at time.Time) bool {
+	t := at.In(loc)
+	_, offset := t.Zone()
+	start, end := t.ZoneBounds()
+	return offset == 0 && start.IsZero() && end.IsZero()
+}
@@ -2,6 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
 	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
@@ -10,49 +14,317 @@ import (
 type StatsUseCase interface {
 	HandleOrderEvent(ctx context.Context, event *domain.OrderEvent) error
 	HandleInventoryEvent(ctx context.Context, event *domain.InventoryEvent) error
-	GetUserOrderStatistics(ctx context.Context, userID string) (*domain.UserOrderStatistics, error)
+	GetUserOrderStatistics(ctx context.Context, userID string, loc *time.Location) (*domain.UserOrderStatistics, error)
+	// GetUserHourlyOrderStatistics returns the completed orders of a user per
+	// UTC hour they were created in.
 	GetUserHourlyOrderStatistics(ctx context.Context, userID string) (map[int]int, error)
+	GetGlobalOrderStatistics(ctx context.Context) (*domain.GlobalOrderProjection, error)
+	GetActiveUsers(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.ActiveUsersPoint, error)
+	GetCohortRetention(ctx context.Context, dateRange domain.DateRange) ([]domain.Cohort, error)
 }
 
 type statsUseCase struct {
-	repo repository.StatsRepository
+	repo        repository.StatsRepository
+	projections repository.ProjectionRepository
+	catalog     repository.CatalogRepository
+	rollups     repository.RollupRepository
+	activity    repository.ActivityRepository
+	tx          Transactor
 }
 
-func NewStatsUseCase(repo repository.StatsRepository) StatsUseCase {
-	return &statsUseCase{repo: repo}
+func NewStatsUseCase(
+	repo repository.StatsRepository,
+	projections repository.ProjectionRepository,
+	catalog repository.CatalogRepository,
+	rollups repository.RollupRepository,
+	activity repository.ActivityRepository,
+	tx Transactor,
+) StatsUseCase {
+	return &statsUseCase{
+		repo:        repo,
+		projections: projections,
+		catalog:     catalog,
+		rollups:     rollups,
+		activity:    activity,
+		tx:          tx,
+	}
 }
 
+// HandleOrderEvent stores the event and updates the order projections in one
+// transaction, so a redelivered event never leaves them half updated.
 func (uc *statsUseCase) HandleOrderEvent(ctx context.Context, event *domain.OrderEvent) error {
 	if event == nil {
 		return nil
 	}
-	return uc.repo.SaveOrderEvent(ctx, event)
+
+	err := uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.SaveOrderEvent(ctx, event); err != nil {
+			return err
+		}
+		return uc.projectOrderEvent(ctx, event)
+	})
+	return ignoreDuplicate(err)
 }
 
 func (uc *statsUseCase) HandleInventoryEvent(ctx context.Context, event *domain.InventoryEvent) error {
 	if event == nil {
 		return nil
 	}
-	return uc.repo.SaveInventoryEvent(ctx, event)
+
+	err := uc.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.SaveInventoryEvent(ctx, event); err != nil {
+			return err
+		}
+		return uc.projectInventoryEvent(ctx, event)
+	})
+	return ignoreDuplicate(err)
 }
 
-func (uc *statsUseCase) GetUserOrderStatistics(ctx context.Context, userID string) (*domain.UserOrderStatistics, error) {
-	return uc.repo.GetUserOrderStatistics(ctx, userID)
+// ignoreDuplicate treats an event that was stored before as handled. Its
+// transaction was rolled back, so the projections count it once.
+func ignoreDuplicate(err error) error {
+	if errors.Is(err, domain.ErrDuplicateEvent) {
+		log.Printf("Ignoring %v", err)
+		return nil
+	}
+	return err
+}
+
+// GetUserOrderStatistics returns the order counters of a user with the hourly
+// distribution in loc.
+func (uc *statsUseCase) GetUserOrderStatistics(ctx context.Context, userID string, loc *time.Location) (*domain.UserOrderStatistics, error) {
+	projection, err := uc.projections.GetUserProjection(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if projection == nil {
+		projection = &domain.UserOrderProjection{UserID: userID}
+	}
+	buckets, err := uc.projections.GetUserBuckets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return projection.Statistics(buckets, loc), nil
 }
 
 func (uc *statsUseCase) GetUserHourlyOrderStatistics(ctx context.Context, userID string) (map[int]int, error) {
-	orders, err := uc.repo.GetUserOrderEvents(ctx, userID)
+	buckets, err := uc.projections.GetUserBuckets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.CompletedPerHour(buckets), nil
+}
+
+func (uc *statsUseCase) GetGlobalOrderStatistics(ctx context.Context) (*domain.GlobalOrderProjection, error) {
+	projection, err := uc.projections.GetGlobalProjection(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if projection == nil {
+		projection = &domain.GlobalOrderProjection{}
+	}
+	return projection, nil
+}
+
+// GetActiveUsers returns the distinct users that ordered per UTC period of the
+// range, split into new and returning users. Periods without orders are
+// included with zero counts.
+func (uc *statsUseCase) GetActiveUsers(ctx context.Context, dateRange domain.DateRange, granularity domain.Granularity) ([]domain.ActiveUsersPoint, error) {
+	if granularity == domain.GranularityHour {
+		return nil, fmt.Errorf("%w: active users are counted per day, week or month", domain.ErrInvalidArgument)
+	}
+	dateRange, err := normalizeRange(dateRange)
+	if err != nil {
+		return nil, err
+	}
+	dateRange.From = domain.BucketStart(dateRange.From.UTC(), granularity)
+
+	active, err := uc.activity.GetActiveUsers(ctx, dateRange, granularity)
+	if err != nil {
+		return nil, err
+	}
+
+	byStart := make(map[int64]domain.ActiveUsersPoint, len(active))
+	for _, point := range active {
+		byStart[point.Start.Unix()] = point
+	}
+
+	var points []domain.ActiveUsersPoint
+	for start := dateRange.From; start.Before(dateRange.To); start = domain.NextBucket(start, granularity) {
+		point, ok := byStart[start.Unix()]
+		if !ok {
+			point = domain.ActiveUsersPoint{Start: start}
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// GetCohortRetention returns the monthly cohorts starting in the range with
+// the share of their users that ordered again in every month since. Without a
+// range start the cohorts of the last twelve months are returned.
+func (uc *statsUseCase) GetCohortRetention(ctx context.Context, dateRange domain.DateRange) ([]domain.Cohort, error) {
+	if dateRange.To.IsZero() {
+		dateRange.To = time.Now()
+	}
+	if dateRange.From.IsZero() {
+		dateRange.From = dateRange.To.AddDate(-1, 0, 0)
+	}
+	if !dateRange.From.Before(dateRange.To) {
+		return nil, fmt.Errorf("%w: range start must be before its end", domain.ErrInvalidArgument)
+	}
+	dateRange.From = domain.BucketStart(dateRange.From.UTC(), domain.GranularityMonth)
+
+	activity, err := uc.activity.GetCohortActivity(ctx, dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var cohorts []domain.Cohort
+	for _, row := range activity {
+		if len(cohorts) == 0 || !cohorts[len(cohorts)-1].Start.Equal(row.Cohort) {
+			cohorts = append(cohorts, domain.Cohort{Start: row.Cohort})
+		}
+		cohort := &cohorts[len(cohorts)-1]
+		offset := domain.MonthsBetween(row.Cohort, row.Month)
+		if offset == 0 {
+			cohort.Size = row.Users
+		}
+		// Months without activity are filled in so that offsets match indexes.
+		for len(cohort.Retention) < offset {
+			cohort.Retention = append(cohort.Retention, domain.CohortPeriod{Offset: len(cohort.Retention)})
+		}
+		cohort.Retention = append(cohort.Retention, domain.CohortPeriod{Offset: offset, Users: row.Users})
+	}
 
-	hourlyStats := make(map[int]int)
-	for _, order := range orders {
-		if order.Status == domain.OrderStatusCompleted {
-			hour := order.CreatedAt.Hour()
-			hourlyStats[hour]++
+	for i := range cohorts {
+		cohort := &cohorts[i]
+		for j := range cohort.Retention {
+			if cohort.Size > 0 {
+				cohort.Retention[j].Rate = float64(cohort.Retention[j].Users) / float64(cohort.Size)
+			}
 		}
 	}
+	return cohorts, nil
+}
 
-	return hourlyStats, nil
+// projectOrderEvent replaces the projection of the order and applies the
+// difference to the old one to the user and global counters.
+func (uc *statsUseCase) projectOrderEvent(ctx context.Context, event *domain.OrderEvent) error {
+	current, err := uc.projections.GetOrderProjection(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order projection: %w", err)
+	}
+	if current != nil && current.IsStale(event.Sequence) {
+		log.Printf("Ignoring order event %s for order %s: sequence %d is not after %d", event.EventID, event.ID, event.Sequence, current.Sequence)
+		return nil
+	}
+	if current != nil && current.Deleted {
+		log.Printf("Ignoring order event %s for order %s: the order was deleted", event.EventID, event.ID)
+		return nil
+	}
+
+	// A deleted order leaves a tombstone with the sequence of the delete, so
+	// that events delivered after it cannot project the order again.
+	var prev, next *domain.OrderProjection
+	if event.EventType == "DELETED" {
+		prev, err = uc.projections.ReplaceOrderProjection(ctx, domain.NewOrderTombstone(event.ID, event.Sequence))
+	} else {
+		next = domain.NewOrderProjection(event)
+		prev, err = uc.projections.ReplaceOrderProjection(ctx, next)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update order projection: %w", err)
+	}
+	if prev != nil && prev.Deleted {
+		prev = nil
+	}
+
+	for key, n := range domain.NewActivityDeltas(prev, next) {
+		if err := uc.activity.AddOrders(ctx, key, n); err != nil {
+			return fmt.Errorf("failed to record user activity: %w", err)
+		}
+	}
+
+	for key, value := range domain.NewOrderRollupDeltas(prev, next) {
+		if err := uc.rollups.AddToRollups(ctx, key.Metric, key.Start, value); err != nil {
+			return fmt.Errorf("failed to update %s rollups: %w", key.Metric, err)
+		}
+	}
+
+	for key, delta := range domain.NewUserBucketDeltas(prev, next) {
+		if err := uc.projections.AddToUserBucket(ctx, key, delta); err != nil {
+			return fmt.Errorf("failed to update user order buckets: %w", err)
+		}
+	}
+
+	delta := domain.NewOrderStatsDelta(prev, next)
+	if delta.Empty() {
+		return nil
+	}
+
+	userID := event.UserID
+	if prev != nil {
+		userID = prev.UserID
+	}
+	prevOrders, err := uc.projections.ApplyUserDelta(ctx, userID, delta)
+	if err != nil {
+		return fmt.Errorf("failed to update user projection: %w", err)
+	}
+	delta.Users = domain.UserCountDelta(prevOrders, prevOrders+delta.Orders)
+
+	if err := uc.projections.ApplyGlobalDelta(ctx, delta); err != nil {
+		return fmt.Errorf("failed to update global projection: %w", err)
+	}
+	return nil
+}
+
+// projectInventoryEvent updates the catalog read model. Deleted products are
+// kept, flagged as deleted, so that their past sales keep their category.
+func (uc *statsUseCase) projectInventoryEvent(ctx context.Context, event *domain.InventoryEvent) error {
+	product, err := uc.catalog.GetProduct(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get catalog product: %w", err)
+	}
+	if product == nil {
+		product = &domain.CatalogProduct{ID: event.ID}
+	}
+	if event.Sequence > 0 {
+		if event.Sequence <= product.Sequence {
+			log.Printf("Ignoring inventory event %s for product %s: sequence %d is not after %d", event.EventID, event.ID, event.Sequence, product.Sequence)
+			return nil
+		}
+		product.Sequence = event.Sequence
+	}
+
+	at := event.UpdatedAt
+	if at.IsZero() {
+		at = event.CreatedAt
+	}
+
+	// A product is counted as new by the first event seen for it, whichever
+	// type it has, in the hour it was created.
+	if product.CreatedAt.IsZero() && !event.CreatedAt.IsZero() {
+		product.CreatedAt = event.CreatedAt
+		if err := uc.rollups.AddToRollups(ctx, domain.MetricNewProducts, event.CreatedAt, 1); err != nil {
+			return fmt.Errorf("failed to update %s rollups: %w", domain.MetricNewProducts, err)
+		}
+	}
+
+	if event.EventType == "DELETED" {
+		product.Deleted = true
+	} else {
+		product.AssignCategory(event.CategoryID, at)
+		// Older events only contribute their category assignment.
+		if !at.Before(product.UpdatedAt) {
+			product.Name = event.Name
+			product.Price = event.Price
+			product.UpdatedAt = at
+		}
+	}
+
+	if err := uc.catalog.SaveProduct(ctx, product); err != nil {
+		return fmt.Errorf("failed to save catalog product: %w", err)
+	}
+	return nil
 }
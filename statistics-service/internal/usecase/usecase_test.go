@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/domain"
+	"github.com/mephirious/advanced-programming-2/statistics-service/internal/repository"
+)
+
+// The fakes embed the repository interfaces and implement only what projecting
+// order events calls; anything else panics.
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeStatsRepository struct {
+	repository.StatsRepository
+}
+
+func (fakeStatsRepository) SaveOrderEvent(context.Context, *domain.OrderEvent) error {
+	return nil
+}
+
+type fakeProjectionRepository struct {
+	repository.ProjectionRepository
+	orders map[string]*domain.OrderProjection
+}
+
+func (r *fakeProjectionRepository) GetOrderProjection(_ context.Context, orderID string) (*domain.OrderProjection, error) {
+	return r.orders[orderID], nil
+}
+
+func (r *fakeProjectionRepository) ReplaceOrderProjection(_ context.Context, projection *domain.OrderProjection) (*domain.OrderProjection, error) {
+	prev := r.orders[projection.OrderID]
+	r.orders[projection.OrderID] = projection
+	return prev, nil
+}
+
+func (r *fakeProjectionRepository) ApplyUserDelta(context.Context, string, domain.OrderStatsDelta) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeProjectionRepository) ApplyGlobalDelta(context.Context, domain.OrderStatsDelta) error {
+	return nil
+}
+
+func (r *fakeProjectionRepository) AddToUserBucket(context.Context, domain.UserBucketKey, domain.UserBucketDelta) error {
+	return nil
+}
+
+type fakeRollupRepository struct {
+	repository.RollupRepository
+}
+
+func (fakeRollupRepository) AddToRollups(context.Context, domain.Metric, time.Time, float64) error {
+	return nil
+}
+
+// fakeActivityRepository keeps the orders per user and day like the Mongo
+// repository: days without orders are removed, and the users with a day left
+// are the buyers the cohorts are built from.
+type fakeActivityRepository struct {
+	repository.ActivityRepository
+	orders map[domain.ActivityKey]int
+}
+
+func (r *fakeActivityRepository) AddOrders(_ context.Context, key domain.ActivityKey, n int) error {
+	r.orders[key] += n
+	if r.orders[key] <= 0 {
+		delete(r.orders, key)
+	}
+	return nil
+}
+
+func newTestStatsUseCase() (*statsUseCase, *fakeActivityRepository) {
+	activity := &fakeActivityRepository{orders: make(map[domain.ActivityKey]int)}
+	uc := NewStatsUseCase(
+		fakeStatsRepository{},
+		&fakeProjectionRepository{orders: make(map[string]*domain.OrderProjection)},
+		nil,
+		fakeRollupRepository{},
+		activity,
+		fakeTransactor{},
+	).(*statsUseCase)
+	return uc, activity
+}
+
+func orderEvent(sequence int64, eventType, status string) *domain.OrderEvent {
+	createdAt := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+	return &domain.OrderEvent{
+		ID:        "67d3f5a5c2a1b4e3f0a1b2c3",
+		UserID:    "67d3f1e2c2a1b4e3f0a1b2a0",
+		Total:     39.98,
+		Status:    status,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt.Add(time.Duration(sequence) * time.Minute),
+		EventType: eventType,
+		Sequence:  sequence,
+	}
+}
+
+func TestCancelledOrdersRecordNoActivity(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []*domain.OrderEvent
+	}{
+		{"created then cancelled", []*domain.OrderEvent{
+			orderEvent(1, "CREATED", domain.EventStatusPending),
+			orderEvent(2, "CANCELLED", domain.EventStatusCancelled),
+		}},
+		{"paid then cancelled", []*domain.OrderEvent{
+			orderEvent(1, "CREATED", domain.EventStatusPending),
+			orderEvent(2, "UPDATED", domain.EventStatusPaid),
+			orderEvent(3, "CANCELLED", domain.EventStatusCancelled),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, activity := newTestStatsUseCase()
+			for _, event := range tt.events {
+				if err := uc.HandleOrderEvent(context.Background(), event); err != nil {
+					t.Fatalf("HandleOrderEvent(%s): %v", event.EventType, err)
+				}
+			}
+			if len(activity.orders) != 0 {
+				t.Errorf("activity = %v, want no buyer or cohort entry", activity.orders)
+			}
+		})
+	}
+}
+
+func TestPaidOrdersRecordActivity(t *testing.T) {
+	uc, activity := newTestStatsUseCase()
+	for _, event := range []*domain.OrderEvent{
+		orderEvent(1, "CREATED", domain.EventStatusPending),
+		orderEvent(2, "UPDATED", domain.EventStatusPaid),
+		orderEvent(3, "UPDATED", domain.EventStatusShipped),
+	} {
+		if err := uc.HandleOrderEvent(context.Background(), event); err != nil {
+			t.Fatalf("HandleOrderEvent(%s): %v", event.EventType, err)
+		}
+	}
+
+	key := domain.ActivityKey{
+		UserID: "67d3f1e2c2a1b4e3f0a1b2a0",
+		Day:    time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+	}
+	if got := activity.orders[key]; got != 1 || len(activity.orders) != 1 {
+		t.Errorf("activity = %v, want one order on %s", activity.orders, key.Day.Format(time.DateOnly))
+	}
+}
@@ -22,136 +22,2176 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type OrderStatus int32
+type UserOrderStatisticsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// IANA time zone of the hourly distribution, UTC when empty.
+	Timezone      string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserOrderStatisticsRequest) Reset() {
+	*x = UserOrderStatisticsRequest{}
+	mi := &file_stats_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserOrderStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserOrderStatisticsRequest) ProtoMessage() {}
+
+func (x *UserOrderStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserOrderStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*UserOrderStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserOrderStatisticsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserOrderStatisticsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type UserOrderStatisticsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TotalOrders          int32                  `protobuf:"varint,1,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
+	TotalCompletedOrders int32                  `protobuf:"varint,2,opt,name=total_completed_orders,json=totalCompletedOrders,proto3" json:"total_completed_orders,omitempty"`
+	TotalCancelledOrders int32                  `protobuf:"varint,3,opt,name=total_cancelled_orders,json=totalCancelledOrders,proto3" json:"total_cancelled_orders,omitempty"`
+	HourlyDistribution   map[string]int32       `protobuf:"bytes,4,rep,name=hourly_distribution,json=hourlyDistribution,proto3" json:"hourly_distribution,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UserOrderStatisticsResponse) Reset() {
+	*x = UserOrderStatisticsResponse{}
+	mi := &file_stats_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserOrderStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserOrderStatisticsResponse) ProtoMessage() {}
+
+func (x *UserOrderStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserOrderStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*UserOrderStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserOrderStatisticsResponse) GetTotalOrders() int32 {
+	if x != nil {
+		return x.TotalOrders
+	}
+	return 0
+}
+
+func (x *UserOrderStatisticsResponse) GetTotalCompletedOrders() int32 {
+	if x != nil {
+		return x.TotalCompletedOrders
+	}
+	return 0
+}
+
+func (x *UserOrderStatisticsResponse) GetTotalCancelledOrders() int32 {
+	if x != nil {
+		return x.TotalCancelledOrders
+	}
+	return 0
+}
+
+func (x *UserOrderStatisticsResponse) GetHourlyDistribution() map[string]int32 {
+	if x != nil {
+		return x.HourlyDistribution
+	}
+	return nil
+}
+
+type UserStatisticsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// IANA time zone of the most active hour, UTC when empty.
+	Timezone      string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStatisticsRequest) Reset() {
+	*x = UserStatisticsRequest{}
+	mi := &file_stats_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatisticsRequest) ProtoMessage() {}
+
+func (x *UserStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*UserStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserStatisticsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserStatisticsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type UserStatisticsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TotalUsers     int32                  `protobuf:"varint,2,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
+	UserOrderCount int32                  `protobuf:"varint,3,opt,name=user_order_count,json=userOrderCount,proto3" json:"user_order_count,omitempty"`
+	MostActiveHour int32                  `protobuf:"varint,4,opt,name=most_active_hour,json=mostActiveHour,proto3" json:"most_active_hour,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserStatisticsResponse) Reset() {
+	*x = UserStatisticsResponse{}
+	mi := &file_stats_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStatisticsResponse) ProtoMessage() {}
+
+func (x *UserStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*UserStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserStatisticsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserStatisticsResponse) GetTotalUsers() int32 {
+	if x != nil {
+		return x.TotalUsers
+	}
+	return 0
+}
+
+func (x *UserStatisticsResponse) GetUserOrderCount() int32 {
+	if x != nil {
+		return x.UserOrderCount
+	}
+	return 0
+}
+
+func (x *UserStatisticsResponse) GetMostActiveHour() int32 {
+	if x != nil {
+		return x.MostActiveHour
+	}
+	return 0
+}
+
+type DeadLetter struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Stream     string                 `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
+	Subject    string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Sequence   uint64                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Payload    []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Error      string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts   int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	FailedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	ReplayedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=replayed_at,json=replayedAt,proto3" json:"replayed_at,omitempty"`
+	// Event envelope headers, empty for messages published without them.
+	Headers       map[string]string `protobuf:"bytes,10,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_stats_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetReplayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReplayedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type ListDeadLettersRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Subject         string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	IncludeReplayed bool                   `protobuf:"varint,2,opt,name=include_replayed,json=includeReplayed,proto3" json:"include_replayed,omitempty"`
+	Page            int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit           int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_stats_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDeadLettersRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetIncludeReplayed() bool {
+	if x != nil {
+		return x.IncludeReplayed
+	}
+	return false
+}
+
+func (x *ListDeadLettersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_stats_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type DeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetterRequest) Reset() {
+	*x = DeadLetterRequest{}
+	mi := &file_stats_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterRequest) ProtoMessage() {}
+
+func (x *DeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*DeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeadLetterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeadLetterResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetter *DeadLetter            `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	// The payload decoded as JSON, empty when it cannot be decoded.
+	PayloadJson   string `protobuf:"bytes,2,opt,name=payload_json,json=payloadJson,proto3" json:"payload_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetterResponse) Reset() {
+	*x = DeadLetterResponse{}
+	mi := &file_stats_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterResponse) ProtoMessage() {}
+
+func (x *DeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*DeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+func (x *DeadLetterResponse) GetPayloadJson() string {
+	if x != nil {
+		return x.PayloadJson
+	}
+	return ""
+}
+
+type PurgeDeadLettersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Dead letters to remove. Either ids or all must be set.
+	Ids          []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	ReplayedOnly bool     `protobuf:"varint,2,opt,name=replayed_only,json=replayedOnly,proto3" json:"replayed_only,omitempty"`
+	// Remove every dead letter, or every replayed one with replayed_only.
+	All           bool `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersRequest) Reset() {
+	*x = PurgeDeadLettersRequest{}
+	mi := &file_stats_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersRequest) ProtoMessage() {}
+
+func (x *PurgeDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PurgeDeadLettersRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *PurgeDeadLettersRequest) GetReplayedOnly() bool {
+	if x != nil {
+		return x.ReplayedOnly
+	}
+	return false
+}
+
+func (x *PurgeDeadLettersRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type PurgeDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int64                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersResponse) Reset() {
+	*x = PurgeDeadLettersResponse{}
+	mi := &file_stats_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersResponse) ProtoMessage() {}
+
+func (x *PurgeDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PurgeDeadLettersResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+type RebuildProjectionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// events (default) replays the stored raw events, stream replays the
+	// JetStream streams and rebuilds the raw events as well.
+	Source        string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildProjectionsRequest) Reset() {
+	*x = RebuildProjectionsRequest{}
+	mi := &file_stats_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildProjectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildProjectionsRequest) ProtoMessage() {}
+
+func (x *RebuildProjectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildProjectionsRequest.ProtoReflect.Descriptor instead.
+func (*RebuildProjectionsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RebuildProjectionsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type GetRebuildRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The latest rebuild when empty.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRebuildRequest) Reset() {
+	*x = GetRebuildRequest{}
+	mi := &file_stats_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRebuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRebuildRequest) ProtoMessage() {}
+
+func (x *GetRebuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRebuildRequest.ProtoReflect.Descriptor instead.
+func (*GetRebuildRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetRebuildRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Rebuild struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// running, completed or failed.
+	State     string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Total     int64  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Processed int64  `protobuf:"varint,5,opt,name=processed,proto3" json:"processed,omitempty"`
+	// Share of the events processed, from 0 to 1.
+	Progress      float64                `protobuf:"fixed64,6,opt,name=progress,proto3" json:"progress,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rebuild) Reset() {
+	*x = Rebuild{}
+	mi := &file_stats_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rebuild) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rebuild) ProtoMessage() {}
+
+func (x *Rebuild) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rebuild.ProtoReflect.Descriptor instead.
+func (*Rebuild) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Rebuild) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rebuild) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Rebuild) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Rebuild) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Rebuild) GetProcessed() int64 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *Rebuild) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Rebuild) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Rebuild) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Rebuild) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Rebuild) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type RebuildResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rebuild       *Rebuild               `protobuf:"bytes,1,opt,name=rebuild,proto3" json:"rebuild,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildResponse) Reset() {
+	*x = RebuildResponse{}
+	mi := &file_stats_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildResponse) ProtoMessage() {}
+
+func (x *RebuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildResponse.ProtoReflect.Descriptor instead.
+func (*RebuildResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RebuildResponse) GetRebuild() *Rebuild {
+	if x != nil {
+		return x.Rebuild
+	}
+	return nil
+}
+
+// Ranges cover order creation times from `from` (inclusive) to `to`
+// (exclusive). `to` defaults to now and `from` to 30 days before `to`.
+type RevenueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// day (default), week or month.
+	Granularity   string `protobuf:"bytes,3,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenueRequest) Reset() {
+	*x = RevenueRequest{}
+	mi := &file_stats_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueRequest) ProtoMessage() {}
+
+func (x *RevenueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueRequest.ProtoReflect.Descriptor instead.
+func (*RevenueRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RevenueRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *RevenueRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *RevenueRequest) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+type RevenuePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Revenue       float64                `protobuf:"fixed64,2,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Orders        int32                  `protobuf:"varint,3,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenuePoint) Reset() {
+	*x = RevenuePoint{}
+	mi := &file_stats_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenuePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenuePoint) ProtoMessage() {}
+
+func (x *RevenuePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenuePoint.ProtoReflect.Descriptor instead.
+func (*RevenuePoint) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RevenuePoint) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *RevenuePoint) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *RevenuePoint) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type RevenueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*RevenuePoint        `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	TotalRevenue  float64                `protobuf:"fixed64,2,opt,name=total_revenue,json=totalRevenue,proto3" json:"total_revenue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenueResponse) Reset() {
+	*x = RevenueResponse{}
+	mi := &file_stats_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueResponse) ProtoMessage() {}
+
+func (x *RevenueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueResponse.ProtoReflect.Descriptor instead.
+func (*RevenueResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RevenueResponse) GetPoints() []*RevenuePoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *RevenueResponse) GetTotalRevenue() float64 {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return 0
+}
+
+type RevenueSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevenueSummaryRequest) Reset() {
+	*x = RevenueSummaryRequest{}
+	mi := &file_stats_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueSummaryRequest) ProtoMessage() {}
+
+func (x *RevenueSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueSummaryRequest.ProtoReflect.Descriptor instead.
+func (*RevenueSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevenueSummaryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *RevenueSummaryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type RevenueSummaryResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Revenue           float64                `protobuf:"fixed64,1,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Orders            int32                  `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	AverageOrderValue float64                `protobuf:"fixed64,3,opt,name=average_order_value,json=averageOrderValue,proto3" json:"average_order_value,omitempty"`
+	CompletedRevenue  float64                `protobuf:"fixed64,4,opt,name=completed_revenue,json=completedRevenue,proto3" json:"completed_revenue,omitempty"`
+	CompletedOrders   int32                  `protobuf:"varint,5,opt,name=completed_orders,json=completedOrders,proto3" json:"completed_orders,omitempty"`
+	CancelledRevenue  float64                `protobuf:"fixed64,6,opt,name=cancelled_revenue,json=cancelledRevenue,proto3" json:"cancelled_revenue,omitempty"`
+	CancelledOrders   int32                  `protobuf:"varint,7,opt,name=cancelled_orders,json=cancelledOrders,proto3" json:"cancelled_orders,omitempty"`
+	// Refunded for returned items of the revenue orders, and revenue less that.
+	RefundedRevenue float64 `protobuf:"fixed64,8,opt,name=refunded_revenue,json=refundedRevenue,proto3" json:"refunded_revenue,omitempty"`
+	NetRevenue      float64 `protobuf:"fixed64,9,opt,name=net_revenue,json=netRevenue,proto3" json:"net_revenue,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevenueSummaryResponse) Reset() {
+	*x = RevenueSummaryResponse{}
+	mi := &file_stats_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevenueSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevenueSummaryResponse) ProtoMessage() {}
+
+func (x *RevenueSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevenueSummaryResponse.ProtoReflect.Descriptor instead.
+func (*RevenueSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RevenueSummaryResponse) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetAverageOrderValue() float64 {
+	if x != nil {
+		return x.AverageOrderValue
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetCompletedRevenue() float64 {
+	if x != nil {
+		return x.CompletedRevenue
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetCompletedOrders() int32 {
+	if x != nil {
+		return x.CompletedOrders
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetCancelledRevenue() float64 {
+	if x != nil {
+		return x.CancelledRevenue
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetCancelledOrders() int32 {
+	if x != nil {
+		return x.CancelledOrders
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetRefundedRevenue() float64 {
+	if x != nil {
+		return x.RefundedRevenue
+	}
+	return 0
+}
+
+func (x *RevenueSummaryResponse) GetNetRevenue() float64 {
+	if x != nil {
+		return x.NetRevenue
+	}
+	return 0
+}
+
+type ProductSalesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Number of products to return, all when zero.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSalesRequest) Reset() {
+	*x = ProductSalesRequest{}
+	mi := &file_stats_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSalesRequest) ProtoMessage() {}
+
+func (x *ProductSalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSalesRequest.ProtoReflect.Descriptor instead.
+func (*ProductSalesRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ProductSalesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ProductSalesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ProductSalesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ProductSales struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Units         int32                  `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	Revenue       float64                `protobuf:"fixed64,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Orders        int32                  `protobuf:"varint,4,opt,name=orders,proto3" json:"orders,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSales) Reset() {
+	*x = ProductSales{}
+	mi := &file_stats_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSales) ProtoMessage() {}
+
+func (x *ProductSales) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSales.ProtoReflect.Descriptor instead.
+func (*ProductSales) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProductSales) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductSales) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *ProductSales) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
+}
+
+func (x *ProductSales) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *ProductSales) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ProductSalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductSales        `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSalesResponse) Reset() {
+	*x = ProductSalesResponse{}
+	mi := &file_stats_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSalesResponse) ProtoMessage() {}
+
+func (x *ProductSalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSalesResponse.ProtoReflect.Descriptor instead.
+func (*ProductSalesResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ProductSalesResponse) GetProducts() []*ProductSales {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type ProductRefundsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Number of products to return, all when zero.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductRefundsRequest) Reset() {
+	*x = ProductRefundsRequest{}
+	mi := &file_stats_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductRefundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductRefundsRequest) ProtoMessage() {}
+
+func (x *ProductRefundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductRefundsRequest.ProtoReflect.Descriptor instead.
+func (*ProductRefundsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProductRefundsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ProductRefundsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ProductRefundsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Units of a product sold in the range and refunded through returns.
+type ProductRefunds struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProductId      string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Units          int32                  `protobuf:"varint,3,opt,name=units,proto3" json:"units,omitempty"`
+	RefundedUnits  int32                  `protobuf:"varint,4,opt,name=refunded_units,json=refundedUnits,proto3" json:"refunded_units,omitempty"`
+	RefundedAmount float64                `protobuf:"fixed64,5,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	RefundRate     float64                `protobuf:"fixed64,6,opt,name=refund_rate,json=refundRate,proto3" json:"refund_rate,omitempty"` // refunded_units / units
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProductRefunds) Reset() {
+	*x = ProductRefunds{}
+	mi := &file_stats_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductRefunds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductRefunds) ProtoMessage() {}
+
+func (x *ProductRefunds) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductRefunds.ProtoReflect.Descriptor instead.
+func (*ProductRefunds) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ProductRefunds) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductRefunds) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductRefunds) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *ProductRefunds) GetRefundedUnits() int32 {
+	if x != nil {
+		return x.RefundedUnits
+	}
+	return 0
+}
+
+func (x *ProductRefunds) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *ProductRefunds) GetRefundRate() float64 {
+	if x != nil {
+		return x.RefundRate
+	}
+	return 0
+}
+
+type ProductRefundsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductRefunds      `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductRefundsResponse) Reset() {
+	*x = ProductRefundsResponse{}
+	mi := &file_stats_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductRefundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductRefundsResponse) ProtoMessage() {}
+
+func (x *ProductRefundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductRefundsResponse.ProtoReflect.Descriptor instead.
+func (*ProductRefundsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ProductRefundsResponse) GetProducts() []*ProductRefunds {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type CategorySalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorySalesRequest) Reset() {
+	*x = CategorySalesRequest{}
+	mi := &file_stats_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySalesRequest) ProtoMessage() {}
+
+func (x *CategorySalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySalesRequest.ProtoReflect.Descriptor instead.
+func (*CategorySalesRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CategorySalesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *CategorySalesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Sales of the products that were in the category when they were ordered. An
+// empty category_id groups products unknown to the statistics service.
+type CategorySales struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Units         int32                  `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	Revenue       float64                `protobuf:"fixed64,3,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	Orders        int32                  `protobuf:"varint,4,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorySales) Reset() {
+	*x = CategorySales{}
+	mi := &file_stats_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-const (
-	OrderStatus_S_PENDING   OrderStatus = 0
-	OrderStatus_S_COMPLETED OrderStatus = 1
-	OrderStatus_S_CANCELLED OrderStatus = 2
-)
+func (*CategorySales) ProtoMessage() {}
 
-// Enum value maps for OrderStatus.
-var (
-	OrderStatus_name = map[int32]string{
-		0: "S_PENDING",
-		1: "S_COMPLETED",
-		2: "S_CANCELLED",
+func (x *CategorySales) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	OrderStatus_value = map[string]int32{
-		"S_PENDING":   0,
-		"S_COMPLETED": 1,
-		"S_CANCELLED": 2,
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySales.ProtoReflect.Descriptor instead.
+func (*CategorySales) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CategorySales) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
 	}
-)
+	return ""
+}
 
-func (x OrderStatus) Enum() *OrderStatus {
-	p := new(OrderStatus)
-	*p = x
-	return p
+func (x *CategorySales) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
 }
 
-func (x OrderStatus) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+func (x *CategorySales) GetRevenue() float64 {
+	if x != nil {
+		return x.Revenue
+	}
+	return 0
 }
 
-func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_stats_proto_enumTypes[0].Descriptor()
+func (x *CategorySales) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
 }
 
-func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_stats_proto_enumTypes[0]
+type CategorySalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategorySales       `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x OrderStatus) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
+func (x *CategorySalesResponse) Reset() {
+	*x = CategorySalesResponse{}
+	mi := &file_stats_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-// Deprecated: Use OrderStatus.Descriptor instead.
-func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{0}
+func (x *CategorySalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type OrderEventType int32
+func (*CategorySalesResponse) ProtoMessage() {}
 
-const (
-	OrderEventType_CREATED   OrderEventType = 0
-	OrderEventType_UPDATED   OrderEventType = 1
-	OrderEventType_CANCELLED OrderEventType = 2
-	OrderEventType_DELETED   OrderEventType = 3
-)
+func (x *CategorySalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-// Enum value maps for OrderEventType.
-var (
-	OrderEventType_name = map[int32]string{
-		0: "CREATED",
-		1: "UPDATED",
-		2: "CANCELLED",
-		3: "DELETED",
-	}
-	OrderEventType_value = map[string]int32{
-		"CREATED":   0,
-		"UPDATED":   1,
-		"CANCELLED": 2,
-		"DELETED":   3,
+// Deprecated: Use CategorySalesResponse.ProtoReflect.Descriptor instead.
+func (*CategorySalesResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CategorySalesResponse) GetCategories() []*CategorySales {
+	if x != nil {
+		return x.Categories
 	}
-)
+	return nil
+}
+
+type TopProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// units (default) or revenue.
+	RankBy string `protobuf:"bytes,3,opt,name=rank_by,json=rankBy,proto3" json:"rank_by,omitempty"`
+	// Number of products to return, 10 when zero and at most 100.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only products that were in the category when they were ordered.
+	CategoryId    string `protobuf:"bytes,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopProductsRequest) Reset() {
+	*x = TopProductsRequest{}
+	mi := &file_stats_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopProductsRequest) ProtoMessage() {}
+
+func (x *TopProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (x OrderEventType) Enum() *OrderEventType {
-	p := new(OrderEventType)
-	*p = x
-	return p
+// Deprecated: Use TopProductsRequest.ProtoReflect.Descriptor instead.
+func (*TopProductsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TopProductsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
 }
 
-func (x OrderEventType) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+func (x *TopProductsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
 }
 
-func (OrderEventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_stats_proto_enumTypes[1].Descriptor()
+func (x *TopProductsRequest) GetRankBy() string {
+	if x != nil {
+		return x.RankBy
+	}
+	return ""
 }
 
-func (OrderEventType) Type() protoreflect.EnumType {
-	return &file_stats_proto_enumTypes[1]
+func (x *TopProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
 }
 
-func (x OrderEventType) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
+func (x *TopProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
 }
 
-// Deprecated: Use OrderEventType.Descriptor instead.
-func (OrderEventType) EnumDescriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{1}
+type TopProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductSales        `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopProductsResponse) Reset() {
+	*x = TopProductsResponse{}
+	mi := &file_stats_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopProductsResponse) ProtoMessage() {}
+
+func (x *TopProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopProductsResponse.ProtoReflect.Descriptor instead.
+func (*TopProductsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TopProductsResponse) GetProducts() []*ProductSales {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type TrendingProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// End of the current window, now when unset.
+	To *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
+	// Length of the current and the previous window in days, 7 when zero.
+	WindowDays int32 `protobuf:"varint,2,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// units (default) or revenue.
+	RankBy string `protobuf:"bytes,3,opt,name=rank_by,json=rankBy,proto3" json:"rank_by,omitempty"`
+	// Number of products to return, 10 when zero and at most 100.
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	CategoryId    string `protobuf:"bytes,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingProductsRequest) Reset() {
+	*x = TrendingProductsRequest{}
+	mi := &file_stats_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingProductsRequest) ProtoMessage() {}
+
+func (x *TrendingProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingProductsRequest.ProtoReflect.Descriptor instead.
+func (*TrendingProductsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TrendingProductsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *TrendingProductsRequest) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *TrendingProductsRequest) GetRankBy() string {
+	if x != nil {
+		return x.RankBy
+	}
+	return ""
+}
+
+func (x *TrendingProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *TrendingProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type ProductTrend struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Current   *ProductSales          `protobuf:"bytes,3,opt,name=current,proto3" json:"current,omitempty"`
+	Previous  *ProductSales          `protobuf:"bytes,4,opt,name=previous,proto3" json:"previous,omitempty"`
+	// Relative growth of the rank_by metric, 0.5 for 50%. Zero for new products.
+	Growth float64 `protobuf:"fixed64,5,opt,name=growth,proto3" json:"growth,omitempty"`
+	// The product had no sales in the previous window.
+	New           bool `protobuf:"varint,6,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductTrend) Reset() {
+	*x = ProductTrend{}
+	mi := &file_stats_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductTrend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductTrend) ProtoMessage() {}
+
+func (x *ProductTrend) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductTrend.ProtoReflect.Descriptor instead.
+func (*ProductTrend) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ProductTrend) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductTrend) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductTrend) GetCurrent() *ProductSales {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *ProductTrend) GetPrevious() *ProductSales {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *ProductTrend) GetGrowth() float64 {
+	if x != nil {
+		return x.Growth
+	}
+	return 0
+}
+
+func (x *ProductTrend) GetNew() bool {
+	if x != nil {
+		return x.New
+	}
+	return false
+}
+
+type TrendingProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductTrend        `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingProductsResponse) Reset() {
+	*x = TrendingProductsResponse{}
+	mi := &file_stats_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingProductsResponse) ProtoMessage() {}
+
+func (x *TrendingProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingProductsResponse.ProtoReflect.Descriptor instead.
+func (*TrendingProductsResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TrendingProductsResponse) GetProducts() []*ProductTrend {
+	if x != nil {
+		return x.Products
+	}
+	return nil
 }
 
-type OrderEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	Total         float64                `protobuf:"fixed64,4,opt,name=total,proto3" json:"total,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=statistics.OrderStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	EventType     OrderEventType         `protobuf:"varint,8,opt,name=event_type,json=eventType,proto3,enum=statistics.OrderEventType" json:"event_type,omitempty"`
+type TimeSeriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// orders, revenue, cancellations or new_products.
+	Metric string                 `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	From   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// hour, day (default), week or month.
+	Granularity string `protobuf:"bytes,4,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	// IANA time zone the buckets are aligned to, UTC when empty.
+	Timezone      string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderEvent) Reset() {
-	*x = OrderEvent{}
-	mi := &file_stats_proto_msgTypes[0]
+func (x *TimeSeriesRequest) Reset() {
+	*x = TimeSeriesRequest{}
+	mi := &file_stats_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderEvent) String() string {
+func (x *TimeSeriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderEvent) ProtoMessage() {}
+func (*TimeSeriesRequest) ProtoMessage() {}
 
-func (x *OrderEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[0]
+func (x *TimeSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -162,97 +2202,133 @@ func (x *OrderEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
-func (*OrderEvent) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use TimeSeriesRequest.ProtoReflect.Descriptor instead.
+func (*TimeSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *OrderEvent) GetId() string {
+func (x *TimeSeriesRequest) GetMetric() string {
 	if x != nil {
-		return x.Id
+		return x.Metric
 	}
 	return ""
 }
 
-func (x *OrderEvent) GetUserId() string {
+func (x *TimeSeriesRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UserId
+		return x.From
 	}
-	return ""
+	return nil
 }
 
-func (x *OrderEvent) GetItems() []*OrderItem {
+func (x *TimeSeriesRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Items
+		return x.To
 	}
 	return nil
 }
 
-func (x *OrderEvent) GetTotal() float64 {
+func (x *TimeSeriesRequest) GetGranularity() string {
 	if x != nil {
-		return x.Total
+		return x.Granularity
 	}
-	return 0
+	return ""
+}
+
+func (x *TimeSeriesRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type TimeSeriesPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Start of the bucket in the requested time zone, RFC 3339.
+	LocalStart    string  `protobuf:"bytes,2,opt,name=local_start,json=localStart,proto3" json:"local_start,omitempty"`
+	Value         float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeSeriesPoint) Reset() {
+	*x = TimeSeriesPoint{}
+	mi := &file_stats_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSeriesPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *OrderEvent) GetStatus() OrderStatus {
+func (*TimeSeriesPoint) ProtoMessage() {}
+
+func (x *TimeSeriesPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[35]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return OrderStatus_S_PENDING
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSeriesPoint.ProtoReflect.Descriptor instead.
+func (*TimeSeriesPoint) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *OrderEvent) GetCreatedAt() *timestamppb.Timestamp {
+func (x *TimeSeriesPoint) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.Start
 	}
 	return nil
 }
 
-func (x *OrderEvent) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *TimeSeriesPoint) GetLocalStart() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.LocalStart
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderEvent) GetEventType() OrderEventType {
+func (x *TimeSeriesPoint) GetValue() float64 {
 	if x != nil {
-		return x.EventType
+		return x.Value
 	}
-	return OrderEventType_CREATED
+	return 0
 }
 
-type InventoryEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	CategoryId    string                 `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	EventType     OrderEventType         `protobuf:"varint,9,opt,name=event_type,json=eventType,proto3,enum=statistics.OrderEventType" json:"event_type,omitempty"`
+type TimeSeriesResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Metric      string                 `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"`
+	Granularity string                 `protobuf:"bytes,2,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	Timezone    string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// One point per bucket of the range, zero for buckets without data.
+	Points        []*TimeSeriesPoint `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InventoryEvent) Reset() {
-	*x = InventoryEvent{}
-	mi := &file_stats_proto_msgTypes[1]
+func (x *TimeSeriesResponse) Reset() {
+	*x = TimeSeriesResponse{}
+	mi := &file_stats_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InventoryEvent) String() string {
+func (x *TimeSeriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InventoryEvent) ProtoMessage() {}
+func (*TimeSeriesResponse) ProtoMessage() {}
 
-func (x *InventoryEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[1]
+func (x *TimeSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,98 +2339,127 @@ func (x *InventoryEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InventoryEvent.ProtoReflect.Descriptor instead.
-func (*InventoryEvent) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use TimeSeriesResponse.ProtoReflect.Descriptor instead.
+func (*TimeSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *InventoryEvent) GetId() string {
+func (x *TimeSeriesResponse) GetMetric() string {
 	if x != nil {
-		return x.Id
+		return x.Metric
 	}
 	return ""
 }
 
-func (x *InventoryEvent) GetName() string {
+func (x *TimeSeriesResponse) GetGranularity() string {
 	if x != nil {
-		return x.Name
+		return x.Granularity
 	}
 	return ""
 }
 
-func (x *InventoryEvent) GetDescription() string {
+func (x *TimeSeriesResponse) GetTimezone() string {
 	if x != nil {
-		return x.Description
+		return x.Timezone
 	}
 	return ""
 }
 
-func (x *InventoryEvent) GetCategoryId() string {
+func (x *TimeSeriesResponse) GetPoints() []*TimeSeriesPoint {
 	if x != nil {
-		return x.CategoryId
+		return x.Points
 	}
-	return ""
+	return nil
 }
 
-func (x *InventoryEvent) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
+type ActiveUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// day (default), week or month, in UTC.
+	Granularity   string `protobuf:"bytes,3,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActiveUsersRequest) Reset() {
+	*x = ActiveUsersRequest{}
+	mi := &file_stats_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActiveUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *InventoryEvent) GetQuantity() int32 {
+func (*ActiveUsersRequest) ProtoMessage() {}
+
+func (x *ActiveUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[37]
 	if x != nil {
-		return x.Quantity
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveUsersRequest.ProtoReflect.Descriptor instead.
+func (*ActiveUsersRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *InventoryEvent) GetCreatedAt() *timestamppb.Timestamp {
+func (x *ActiveUsersRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.From
 	}
 	return nil
 }
 
-func (x *InventoryEvent) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *ActiveUsersRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdatedAt
+		return x.To
 	}
 	return nil
 }
 
-func (x *InventoryEvent) GetEventType() OrderEventType {
+func (x *ActiveUsersRequest) GetGranularity() string {
 	if x != nil {
-		return x.EventType
+		return x.Granularity
 	}
-	return OrderEventType_CREATED
+	return ""
 }
 
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ActiveUsersPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Distinct users that placed an order in the period.
+	ActiveUsers int32 `protobuf:"varint,2,opt,name=active_users,json=activeUsers,proto3" json:"active_users,omitempty"`
+	// Users whose first order was in the period.
+	NewUsers       int32 `protobuf:"varint,3,opt,name=new_users,json=newUsers,proto3" json:"new_users,omitempty"`
+	ReturningUsers int32 `protobuf:"varint,4,opt,name=returning_users,json=returningUsers,proto3" json:"returning_users,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_stats_proto_msgTypes[2]
+func (x *ActiveUsersPoint) Reset() {
+	*x = ActiveUsersPoint{}
+	mi := &file_stats_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *ActiveUsersPoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*ActiveUsersPoint) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[2]
+func (x *ActiveUsersPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,54 +2470,61 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ActiveUsersPoint.ProtoReflect.Descriptor instead.
+func (*ActiveUsersPoint) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *OrderItem) GetProductId() string {
+func (x *ActiveUsersPoint) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ProductId
+		return x.Start
 	}
-	return ""
+	return nil
 }
 
-func (x *OrderItem) GetQuantity() int32 {
+func (x *ActiveUsersPoint) GetActiveUsers() int32 {
 	if x != nil {
-		return x.Quantity
+		return x.ActiveUsers
 	}
 	return 0
 }
 
-func (x *OrderItem) GetPrice() float64 {
+func (x *ActiveUsersPoint) GetNewUsers() int32 {
 	if x != nil {
-		return x.Price
+		return x.NewUsers
 	}
 	return 0
 }
 
-type UserOrderStatisticsRequest struct {
+func (x *ActiveUsersPoint) GetReturningUsers() int32 {
+	if x != nil {
+		return x.ReturningUsers
+	}
+	return 0
+}
+
+type ActiveUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Points        []*ActiveUsersPoint    `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserOrderStatisticsRequest) Reset() {
-	*x = UserOrderStatisticsRequest{}
-	mi := &file_stats_proto_msgTypes[3]
+func (x *ActiveUsersResponse) Reset() {
+	*x = ActiveUsersResponse{}
+	mi := &file_stats_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserOrderStatisticsRequest) String() string {
+func (x *ActiveUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserOrderStatisticsRequest) ProtoMessage() {}
+func (*ActiveUsersResponse) ProtoMessage() {}
 
-func (x *UserOrderStatisticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[3]
+func (x *ActiveUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,43 +2535,43 @@ func (x *UserOrderStatisticsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserOrderStatisticsRequest.ProtoReflect.Descriptor instead.
-func (*UserOrderStatisticsRequest) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ActiveUsersResponse.ProtoReflect.Descriptor instead.
+func (*ActiveUsersResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *UserOrderStatisticsRequest) GetUserId() string {
+func (x *ActiveUsersResponse) GetPoints() []*ActiveUsersPoint {
 	if x != nil {
-		return x.UserId
+		return x.Points
 	}
-	return ""
+	return nil
 }
 
-type UserOrderStatisticsResponse struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	TotalOrders          int32                  `protobuf:"varint,1,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
-	TotalCompletedOrders int32                  `protobuf:"varint,2,opt,name=total_completed_orders,json=totalCompletedOrders,proto3" json:"total_completed_orders,omitempty"`
-	TotalCancelledOrders int32                  `protobuf:"varint,3,opt,name=total_cancelled_orders,json=totalCancelledOrders,proto3" json:"total_cancelled_orders,omitempty"`
-	HourlyDistribution   map[string]int32       `protobuf:"bytes,4,rep,name=hourly_distribution,json=hourlyDistribution,proto3" json:"hourly_distribution,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+// Cohorts are the months of the users' first orders. The range selects the
+// cohorts, by default the last twelve months.
+type CohortRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserOrderStatisticsResponse) Reset() {
-	*x = UserOrderStatisticsResponse{}
-	mi := &file_stats_proto_msgTypes[4]
+func (x *CohortRetentionRequest) Reset() {
+	*x = CohortRetentionRequest{}
+	mi := &file_stats_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserOrderStatisticsResponse) String() string {
+func (x *CohortRetentionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserOrderStatisticsResponse) ProtoMessage() {}
+func (*CohortRetentionRequest) ProtoMessage() {}
 
-func (x *UserOrderStatisticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[4]
+func (x *CohortRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -470,61 +2582,51 @@ func (x *UserOrderStatisticsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserOrderStatisticsResponse.ProtoReflect.Descriptor instead.
-func (*UserOrderStatisticsResponse) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *UserOrderStatisticsResponse) GetTotalOrders() int32 {
-	if x != nil {
-		return x.TotalOrders
-	}
-	return 0
-}
-
-func (x *UserOrderStatisticsResponse) GetTotalCompletedOrders() int32 {
-	if x != nil {
-		return x.TotalCompletedOrders
-	}
-	return 0
+// Deprecated: Use CohortRetentionRequest.ProtoReflect.Descriptor instead.
+func (*CohortRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *UserOrderStatisticsResponse) GetTotalCancelledOrders() int32 {
+func (x *CohortRetentionRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TotalCancelledOrders
+		return x.From
 	}
-	return 0
+	return nil
 }
 
-func (x *UserOrderStatisticsResponse) GetHourlyDistribution() map[string]int32 {
+func (x *CohortRetentionRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.HourlyDistribution
+		return x.To
 	}
 	return nil
 }
 
-type UserStatisticsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+type CohortPeriod struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Months since the cohort month.
+	MonthOffset int32 `protobuf:"varint,1,opt,name=month_offset,json=monthOffset,proto3" json:"month_offset,omitempty"`
+	Users       int32 `protobuf:"varint,2,opt,name=users,proto3" json:"users,omitempty"`
+	// Share of the cohort that ordered in the month.
+	Rate          float64 `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserStatisticsRequest) Reset() {
-	*x = UserStatisticsRequest{}
-	mi := &file_stats_proto_msgTypes[5]
+func (x *CohortPeriod) Reset() {
+	*x = CohortPeriod{}
+	mi := &file_stats_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserStatisticsRequest) String() string {
+func (x *CohortPeriod) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserStatisticsRequest) ProtoMessage() {}
+func (*CohortPeriod) ProtoMessage() {}
 
-func (x *UserStatisticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[5]
+func (x *CohortPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,43 +2637,56 @@ func (x *UserStatisticsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserStatisticsRequest.ProtoReflect.Descriptor instead.
-func (*UserStatisticsRequest) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CohortPeriod.ProtoReflect.Descriptor instead.
+func (*CohortPeriod) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *UserStatisticsRequest) GetUserId() string {
+func (x *CohortPeriod) GetMonthOffset() int32 {
 	if x != nil {
-		return x.UserId
+		return x.MonthOffset
 	}
-	return ""
+	return 0
 }
 
-type UserStatisticsResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TotalUsers     int32                  `protobuf:"varint,2,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
-	UserOrderCount int32                  `protobuf:"varint,3,opt,name=user_order_count,json=userOrderCount,proto3" json:"user_order_count,omitempty"`
-	MostActiveHour int32                  `protobuf:"varint,4,opt,name=most_active_hour,json=mostActiveHour,proto3" json:"most_active_hour,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *CohortPeriod) GetUsers() int32 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
 }
 
-func (x *UserStatisticsResponse) Reset() {
-	*x = UserStatisticsResponse{}
-	mi := &file_stats_proto_msgTypes[6]
+func (x *CohortPeriod) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type Cohort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Retention     []*CohortPeriod        `protobuf:"bytes,3,rep,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cohort) Reset() {
+	*x = Cohort{}
+	mi := &file_stats_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserStatisticsResponse) String() string {
+func (x *Cohort) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserStatisticsResponse) ProtoMessage() {}
+func (*Cohort) ProtoMessage() {}
 
-func (x *UserStatisticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_stats_proto_msgTypes[6]
+func (x *Cohort) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,37 +2697,74 @@ func (x *UserStatisticsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserStatisticsResponse.ProtoReflect.Descriptor instead.
-func (*UserStatisticsResponse) Descriptor() ([]byte, []int) {
-	return file_stats_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Cohort.ProtoReflect.Descriptor instead.
+func (*Cohort) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *UserStatisticsResponse) GetUserId() string {
+func (x *Cohort) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UserId
+		return x.Start
 	}
-	return ""
+	return nil
 }
 
-func (x *UserStatisticsResponse) GetTotalUsers() int32 {
+func (x *Cohort) GetSize() int32 {
 	if x != nil {
-		return x.TotalUsers
+		return x.Size
 	}
 	return 0
 }
 
-func (x *UserStatisticsResponse) GetUserOrderCount() int32 {
+func (x *Cohort) GetRetention() []*CohortPeriod {
 	if x != nil {
-		return x.UserOrderCount
+		return x.Retention
 	}
-	return 0
+	return nil
 }
 
-func (x *UserStatisticsResponse) GetMostActiveHour() int32 {
+type CohortRetentionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cohorts       []*Cohort              `protobuf:"bytes,1,rep,name=cohorts,proto3" json:"cohorts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CohortRetentionResponse) Reset() {
+	*x = CohortRetentionResponse{}
+	mi := &file_stats_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CohortRetentionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CohortRetentionResponse) ProtoMessage() {}
+
+func (x *CohortRetentionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[43]
 	if x != nil {
-		return x.MostActiveHour
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CohortRetentionResponse.ProtoReflect.Descriptor instead.
+func (*CohortRetentionResponse) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CohortRetentionResponse) GetCohorts() []*Cohort {
+	if x != nil {
+		return x.Cohorts
+	}
+	return nil
 }
 
 var File_stats_proto protoreflect.FileDescriptor
@@ -620,41 +2772,10 @@ var File_stats_proto protoreflect.FileDescriptor
 const file_stats_proto_rawDesc = "" +
 	"\n" +
 	"\vstats.proto\x12\n" +
-	"statistics\x1a\x1fgoogle/protobuf/timestamp.proto\"\xda\x02\n" +
-	"\n" +
-	"OrderEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12+\n" +
-	"\x05items\x18\x03 \x03(\v2\x15.statistics.OrderItemR\x05items\x12\x14\n" +
-	"\x05total\x18\x04 \x01(\x01R\x05total\x12/\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x17.statistics.OrderStatusR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
-	"\n" +
-	"event_type\x18\b \x01(\x0e2\x1a.statistics.OrderEventTypeR\teventType\"\xda\x02\n" +
-	"\x0eInventoryEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
-	"\vcategory_id\x18\x04 \x01(\tR\n" +
-	"categoryId\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x05R\bquantity\x129\n" +
-	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
-	"\n" +
-	"event_type\x18\t \x01(\x0e2\x1a.statistics.OrderEventTypeR\teventType\"\\\n" +
-	"\tOrderItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"5\n" +
+	"statistics\x1a\x1fgoogle/protobuf/timestamp.proto\"Q\n" +
 	"\x1aUserOrderStatisticsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xe5\x02\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\"\xe5\x02\n" +
 	"\x1bUserOrderStatisticsResponse\x12!\n" +
 	"\ftotal_orders\x18\x01 \x01(\x05R\vtotalOrders\x124\n" +
 	"\x16total_completed_orders\x18\x02 \x01(\x05R\x14totalCompletedOrders\x124\n" +
@@ -662,27 +2783,228 @@ const file_stats_proto_rawDesc = "" +
 	"\x13hourly_distribution\x18\x04 \x03(\v2?.statistics.UserOrderStatisticsResponse.HourlyDistributionEntryR\x12hourlyDistribution\x1aE\n" +
 	"\x17HourlyDistributionEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"0\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"L\n" +
 	"\x15UserStatisticsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa6\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\"\xa6\x01\n" +
 	"\x16UserStatisticsResponse\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vtotal_users\x18\x02 \x01(\x05R\n" +
 	"totalUsers\x12(\n" +
 	"\x10user_order_count\x18\x03 \x01(\x05R\x0euserOrderCount\x12(\n" +
-	"\x10most_active_hour\x18\x04 \x01(\x05R\x0emostActiveHour*>\n" +
-	"\vOrderStatus\x12\r\n" +
-	"\tS_PENDING\x10\x00\x12\x0f\n" +
-	"\vS_COMPLETED\x10\x01\x12\x0f\n" +
-	"\vS_CANCELLED\x10\x02*F\n" +
-	"\x0eOrderEventType\x12\v\n" +
-	"\aCREATED\x10\x00\x12\v\n" +
-	"\aUPDATED\x10\x01\x12\r\n" +
-	"\tCANCELLED\x10\x02\x12\v\n" +
-	"\aDELETED\x10\x032\xdb\x01\n" +
+	"\x10most_active_hour\x18\x04 \x01(\x05R\x0emostActiveHour\"\xa7\x03\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06stream\x18\x02 \x01(\tR\x06stream\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequence\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x127\n" +
+	"\tfailed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\x12;\n" +
+	"\vreplayed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"replayedAt\x12=\n" +
+	"\aheaders\x18\n" +
+	" \x03(\v2#.statistics.DeadLetter.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x87\x01\n" +
+	"\x16ListDeadLettersRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12)\n" +
+	"\x10include_replayed\x18\x02 \x01(\bR\x0fincludeReplayed\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"j\n" +
+	"\x17ListDeadLettersResponse\x129\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x16.statistics.DeadLetterR\vdeadLetters\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"#\n" +
+	"\x11DeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"p\n" +
+	"\x12DeadLetterResponse\x127\n" +
+	"\vdead_letter\x18\x01 \x01(\v2\x16.statistics.DeadLetterR\n" +
+	"deadLetter\x12!\n" +
+	"\fpayload_json\x18\x02 \x01(\tR\vpayloadJson\"b\n" +
+	"\x17PurgeDeadLettersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12#\n" +
+	"\rreplayed_only\x18\x02 \x01(\bR\freplayedOnly\x12\x10\n" +
+	"\x03all\x18\x03 \x01(\bR\x03all\"2\n" +
+	"\x18PurgeDeadLettersResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged\"3\n" +
+	"\x19RebuildProjectionsRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\"#\n" +
+	"\x11GetRebuildRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe0\x02\n" +
+	"\aRebuild\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tprocessed\x18\x05 \x01(\x03R\tprocessed\x12\x1a\n" +
+	"\bprogress\x18\x06 \x01(\x01R\bprogress\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"@\n" +
+	"\x0fRebuildResponse\x12-\n" +
+	"\arebuild\x18\x01 \x01(\v2\x13.statistics.RebuildR\arebuild\"\x8e\x01\n" +
+	"\x0eRevenueRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12 \n" +
+	"\vgranularity\x18\x03 \x01(\tR\vgranularity\"\x7f\n" +
+	"\fRevenuePoint\x12=\n" +
+	"\fperiod_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12\x18\n" +
+	"\arevenue\x18\x02 \x01(\x01R\arevenue\x12\x16\n" +
+	"\x06orders\x18\x03 \x01(\x05R\x06orders\"h\n" +
+	"\x0fRevenueResponse\x120\n" +
+	"\x06points\x18\x01 \x03(\v2\x18.statistics.RevenuePointR\x06points\x12#\n" +
+	"\rtotal_revenue\x18\x02 \x01(\x01R\ftotalRevenue\"s\n" +
+	"\x15RevenueSummaryRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xf6\x02\n" +
+	"\x16RevenueSummaryResponse\x12\x18\n" +
+	"\arevenue\x18\x01 \x01(\x01R\arevenue\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x05R\x06orders\x12.\n" +
+	"\x13average_order_value\x18\x03 \x01(\x01R\x11averageOrderValue\x12+\n" +
+	"\x11completed_revenue\x18\x04 \x01(\x01R\x10completedRevenue\x12)\n" +
+	"\x10completed_orders\x18\x05 \x01(\x05R\x0fcompletedOrders\x12+\n" +
+	"\x11cancelled_revenue\x18\x06 \x01(\x01R\x10cancelledRevenue\x12)\n" +
+	"\x10cancelled_orders\x18\a \x01(\x05R\x0fcancelledOrders\x12)\n" +
+	"\x10refunded_revenue\x18\b \x01(\x01R\x0frefundedRevenue\x12\x1f\n" +
+	"\vnet_revenue\x18\t \x01(\x01R\n" +
+	"netRevenue\"\x87\x01\n" +
+	"\x13ProductSalesRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x89\x01\n" +
+	"\fProductSales\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05units\x18\x02 \x01(\x05R\x05units\x12\x18\n" +
+	"\arevenue\x18\x03 \x01(\x01R\arevenue\x12\x16\n" +
+	"\x06orders\x18\x04 \x01(\x05R\x06orders\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\"L\n" +
+	"\x14ProductSalesResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.statistics.ProductSalesR\bproducts\"\x89\x01\n" +
+	"\x15ProductRefundsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xca\x01\n" +
+	"\x0eProductRefunds\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05units\x18\x03 \x01(\x05R\x05units\x12%\n" +
+	"\x0erefunded_units\x18\x04 \x01(\x05R\rrefundedUnits\x12'\n" +
+	"\x0frefunded_amount\x18\x05 \x01(\x01R\x0erefundedAmount\x12\x1f\n" +
+	"\vrefund_rate\x18\x06 \x01(\x01R\n" +
+	"refundRate\"P\n" +
+	"\x16ProductRefundsResponse\x126\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1a.statistics.ProductRefundsR\bproducts\"r\n" +
+	"\x14CategorySalesRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"x\n" +
+	"\rCategorySales\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x14\n" +
+	"\x05units\x18\x02 \x01(\x05R\x05units\x12\x18\n" +
+	"\arevenue\x18\x03 \x01(\x01R\arevenue\x12\x16\n" +
+	"\x06orders\x18\x04 \x01(\x05R\x06orders\"R\n" +
+	"\x15CategorySalesResponse\x129\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x19.statistics.CategorySalesR\n" +
+	"categories\"\xc0\x01\n" +
+	"\x12TopProductsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x17\n" +
+	"\arank_by\x18\x03 \x01(\tR\x06rankBy\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\tR\n" +
+	"categoryId\"K\n" +
+	"\x13TopProductsResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.statistics.ProductSalesR\bproducts\"\xb6\x01\n" +
+	"\x17TrendingProductsRequest\x12*\n" +
+	"\x02to\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1f\n" +
+	"\vwindow_days\x18\x02 \x01(\x05R\n" +
+	"windowDays\x12\x17\n" +
+	"\arank_by\x18\x03 \x01(\tR\x06rankBy\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\tR\n" +
+	"categoryId\"\xd5\x01\n" +
+	"\fProductTrend\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\acurrent\x18\x03 \x01(\v2\x18.statistics.ProductSalesR\acurrent\x124\n" +
+	"\bprevious\x18\x04 \x01(\v2\x18.statistics.ProductSalesR\bprevious\x12\x16\n" +
+	"\x06growth\x18\x05 \x01(\x01R\x06growth\x12\x10\n" +
+	"\x03new\x18\x06 \x01(\bR\x03new\"P\n" +
+	"\x18TrendingProductsResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.statistics.ProductTrendR\bproducts\"\xc5\x01\n" +
+	"\x11TimeSeriesRequest\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12 \n" +
+	"\vgranularity\x18\x04 \x01(\tR\vgranularity\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\"z\n" +
+	"\x0fTimeSeriesPoint\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x1f\n" +
+	"\vlocal_start\x18\x02 \x01(\tR\n" +
+	"localStart\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x01R\x05value\"\x9f\x01\n" +
+	"\x12TimeSeriesResponse\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12 \n" +
+	"\vgranularity\x18\x02 \x01(\tR\vgranularity\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x123\n" +
+	"\x06points\x18\x04 \x03(\v2\x1b.statistics.TimeSeriesPointR\x06points\"\x92\x01\n" +
+	"\x12ActiveUsersRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12 \n" +
+	"\vgranularity\x18\x03 \x01(\tR\vgranularity\"\xad\x01\n" +
+	"\x10ActiveUsersPoint\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12!\n" +
+	"\factive_users\x18\x02 \x01(\x05R\vactiveUsers\x12\x1b\n" +
+	"\tnew_users\x18\x03 \x01(\x05R\bnewUsers\x12'\n" +
+	"\x0freturning_users\x18\x04 \x01(\x05R\x0ereturningUsers\"K\n" +
+	"\x13ActiveUsersResponse\x124\n" +
+	"\x06points\x18\x01 \x03(\v2\x1c.statistics.ActiveUsersPointR\x06points\"t\n" +
+	"\x16CohortRetentionRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"[\n" +
+	"\fCohortPeriod\x12!\n" +
+	"\fmonth_offset\x18\x01 \x01(\x05R\vmonthOffset\x12\x14\n" +
+	"\x05users\x18\x02 \x01(\x05R\x05users\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\"\x86\x01\n" +
+	"\x06Cohort\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x126\n" +
+	"\tretention\x18\x03 \x03(\v2\x18.statistics.CohortPeriodR\tretention\"G\n" +
+	"\x17CohortRetentionResponse\x12,\n" +
+	"\acohorts\x18\x01 \x03(\v2\x12.statistics.CohortR\acohorts2\xc2\f\n" +
 	"\x11StatisticsService\x12j\n" +
 	"\x17GetUserOrdersStatistics\x12&.statistics.UserOrderStatisticsRequest\x1a'.statistics.UserOrderStatisticsResponse\x12Z\n" +
-	"\x11GetUserStatistics\x12!.statistics.UserStatisticsRequest\x1a\".statistics.UserStatisticsResponseBOZMgithub.com/mephirious/statistics-service/proto/statistics-service/proto;protob\x06proto3"
+	"\x11GetUserStatistics\x12!.statistics.UserStatisticsRequest\x1a\".statistics.UserStatisticsResponse\x12Q\n" +
+	"\x0eGetActiveUsers\x12\x1e.statistics.ActiveUsersRequest\x1a\x1f.statistics.ActiveUsersResponse\x12]\n" +
+	"\x12GetCohortRetention\x12\".statistics.CohortRetentionRequest\x1a#.statistics.CohortRetentionResponse\x12E\n" +
+	"\n" +
+	"GetRevenue\x12\x1a.statistics.RevenueRequest\x1a\x1b.statistics.RevenueResponse\x12Z\n" +
+	"\x11GetRevenueSummary\x12!.statistics.RevenueSummaryRequest\x1a\".statistics.RevenueSummaryResponse\x12T\n" +
+	"\x0fGetProductSales\x12\x1f.statistics.ProductSalesRequest\x1a .statistics.ProductSalesResponse\x12W\n" +
+	"\x10GetCategorySales\x12 .statistics.CategorySalesRequest\x1a!.statistics.CategorySalesResponse\x12Z\n" +
+	"\x11GetProductRefunds\x12!.statistics.ProductRefundsRequest\x1a\".statistics.ProductRefundsResponse\x12Q\n" +
+	"\x0eGetTopProducts\x12\x1e.statistics.TopProductsRequest\x1a\x1f.statistics.TopProductsResponse\x12`\n" +
+	"\x13GetTrendingProducts\x12#.statistics.TrendingProductsRequest\x1a$.statistics.TrendingProductsResponse\x12N\n" +
+	"\rGetTimeSeries\x12\x1d.statistics.TimeSeriesRequest\x1a\x1e.statistics.TimeSeriesResponse\x12Z\n" +
+	"\x0fListDeadLetters\x12\".statistics.ListDeadLettersRequest\x1a#.statistics.ListDeadLettersResponse\x12N\n" +
+	"\rGetDeadLetter\x12\x1d.statistics.DeadLetterRequest\x1a\x1e.statistics.DeadLetterResponse\x12Q\n" +
+	"\x10ReplayDeadLetter\x12\x1d.statistics.DeadLetterRequest\x1a\x1e.statistics.DeadLetterResponse\x12]\n" +
+	"\x10PurgeDeadLetters\x12#.statistics.PurgeDeadLettersRequest\x1a$.statistics.PurgeDeadLettersResponse\x12X\n" +
+	"\x12RebuildProjections\x12%.statistics.RebuildProjectionsRequest\x1a\x1b.statistics.RebuildResponse\x12H\n" +
+	"\n" +
+	"GetRebuild\x12\x1d.statistics.GetRebuildRequest\x1a\x1b.statistics.RebuildResponseBOZMgithub.com/mephirious/statistics-service/proto/statistics-service/proto;protob\x06proto3"
 
 var (
 	file_stats_proto_rawDescOnce sync.Once
@@ -696,40 +3018,143 @@ func file_stats_proto_rawDescGZIP() []byte {
 	return file_stats_proto_rawDescData
 }
 
-var file_stats_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_stats_proto_goTypes = []any{
-	(OrderStatus)(0),                    // 0: statistics.OrderStatus
-	(OrderEventType)(0),                 // 1: statistics.OrderEventType
-	(*OrderEvent)(nil),                  // 2: statistics.OrderEvent
-	(*InventoryEvent)(nil),              // 3: statistics.InventoryEvent
-	(*OrderItem)(nil),                   // 4: statistics.OrderItem
-	(*UserOrderStatisticsRequest)(nil),  // 5: statistics.UserOrderStatisticsRequest
-	(*UserOrderStatisticsResponse)(nil), // 6: statistics.UserOrderStatisticsResponse
-	(*UserStatisticsRequest)(nil),       // 7: statistics.UserStatisticsRequest
-	(*UserStatisticsResponse)(nil),      // 8: statistics.UserStatisticsResponse
-	nil,                                 // 9: statistics.UserOrderStatisticsResponse.HourlyDistributionEntry
-	(*timestamppb.Timestamp)(nil),       // 10: google.protobuf.Timestamp
+	(*UserOrderStatisticsRequest)(nil),  // 0: statistics.UserOrderStatisticsRequest
+	(*UserOrderStatisticsResponse)(nil), // 1: statistics.UserOrderStatisticsResponse
+	(*UserStatisticsRequest)(nil),       // 2: statistics.UserStatisticsRequest
+	(*UserStatisticsResponse)(nil),      // 3: statistics.UserStatisticsResponse
+	(*DeadLetter)(nil),                  // 4: statistics.DeadLetter
+	(*ListDeadLettersRequest)(nil),      // 5: statistics.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),     // 6: statistics.ListDeadLettersResponse
+	(*DeadLetterRequest)(nil),           // 7: statistics.DeadLetterRequest
+	(*DeadLetterResponse)(nil),          // 8: statistics.DeadLetterResponse
+	(*PurgeDeadLettersRequest)(nil),     // 9: statistics.PurgeDeadLettersRequest
+	(*PurgeDeadLettersResponse)(nil),    // 10: statistics.PurgeDeadLettersResponse
+	(*RebuildProjectionsRequest)(nil),   // 11: statistics.RebuildProjectionsRequest
+	(*GetRebuildRequest)(nil),           // 12: statistics.GetRebuildRequest
+	(*Rebuild)(nil),                     // 13: statistics.Rebuild
+	(*RebuildResponse)(nil),             // 14: statistics.RebuildResponse
+	(*RevenueRequest)(nil),              // 15: statistics.RevenueRequest
+	(*RevenuePoint)(nil),                // 16: statistics.RevenuePoint
+	(*RevenueResponse)(nil),             // 17: statistics.RevenueResponse
+	(*RevenueSummaryRequest)(nil),       // 18: statistics.RevenueSummaryRequest
+	(*RevenueSummaryResponse)(nil),      // 19: statistics.RevenueSummaryResponse
+	(*ProductSalesRequest)(nil),         // 20: statistics.ProductSalesRequest
+	(*ProductSales)(nil),                // 21: statistics.ProductSales
+	(*ProductSalesResponse)(nil),        // 22: statistics.ProductSalesResponse
+	(*ProductRefundsRequest)(nil),       // 23: statistics.ProductRefundsRequest
+	(*ProductRefunds)(nil),              // 24: statistics.ProductRefunds
+	(*ProductRefundsResponse)(nil),      // 25: statistics.ProductRefundsResponse
+	(*CategorySalesRequest)(nil),        // 26: statistics.CategorySalesRequest
+	(*CategorySales)(nil),               // 27: statistics.CategorySales
+	(*CategorySalesResponse)(nil),       // 28: statistics.CategorySalesResponse
+	(*TopProductsRequest)(nil),          // 29: statistics.TopProductsRequest
+	(*TopProductsResponse)(nil),         // 30: statistics.TopProductsResponse
+	(*TrendingProductsRequest)(nil),     // 31: statistics.TrendingProductsRequest
+	(*ProductTrend)(nil),                // 32: statistics.ProductTrend
+	(*TrendingProductsResponse)(nil),    // 33: statistics.TrendingProductsResponse
+	(*TimeSeriesRequest)(nil),           // 34: statistics.TimeSeriesRequest
+	(*TimeSeriesPoint)(nil),             // 35: statistics.TimeSeriesPoint
+	(*TimeSeriesResponse)(nil),          // 36: statistics.TimeSeriesResponse
+	(*ActiveUsersRequest)(nil),          // 37: statistics.ActiveUsersRequest
+	(*ActiveUsersPoint)(nil),            // 38: statistics.ActiveUsersPoint
+	(*ActiveUsersResponse)(nil),         // 39: statistics.ActiveUsersResponse
+	(*CohortRetentionRequest)(nil),      // 40: statistics.CohortRetentionRequest
+	(*CohortPeriod)(nil),                // 41: statistics.CohortPeriod
+	(*Cohort)(nil),                      // 42: statistics.Cohort
+	(*CohortRetentionResponse)(nil),     // 43: statistics.CohortRetentionResponse
+	nil,                                 // 44: statistics.UserOrderStatisticsResponse.HourlyDistributionEntry
+	nil,                                 // 45: statistics.DeadLetter.HeadersEntry
+	(*timestamppb.Timestamp)(nil),       // 46: google.protobuf.Timestamp
 }
 var file_stats_proto_depIdxs = []int32{
-	4,  // 0: statistics.OrderEvent.items:type_name -> statistics.OrderItem
-	0,  // 1: statistics.OrderEvent.status:type_name -> statistics.OrderStatus
-	10, // 2: statistics.OrderEvent.created_at:type_name -> google.protobuf.Timestamp
-	10, // 3: statistics.OrderEvent.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 4: statistics.OrderEvent.event_type:type_name -> statistics.OrderEventType
-	10, // 5: statistics.InventoryEvent.created_at:type_name -> google.protobuf.Timestamp
-	10, // 6: statistics.InventoryEvent.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 7: statistics.InventoryEvent.event_type:type_name -> statistics.OrderEventType
-	9,  // 8: statistics.UserOrderStatisticsResponse.hourly_distribution:type_name -> statistics.UserOrderStatisticsResponse.HourlyDistributionEntry
-	5,  // 9: statistics.StatisticsService.GetUserOrdersStatistics:input_type -> statistics.UserOrderStatisticsRequest
-	7,  // 10: statistics.StatisticsService.GetUserStatistics:input_type -> statistics.UserStatisticsRequest
-	6,  // 11: statistics.StatisticsService.GetUserOrdersStatistics:output_type -> statistics.UserOrderStatisticsResponse
-	8,  // 12: statistics.StatisticsService.GetUserStatistics:output_type -> statistics.UserStatisticsResponse
-	11, // [11:13] is the sub-list for method output_type
-	9,  // [9:11] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	44, // 0: statistics.UserOrderStatisticsResponse.hourly_distribution:type_name -> statistics.UserOrderStatisticsResponse.HourlyDistributionEntry
+	46, // 1: statistics.DeadLetter.failed_at:type_name -> google.protobuf.Timestamp
+	46, // 2: statistics.DeadLetter.replayed_at:type_name -> google.protobuf.Timestamp
+	45, // 3: statistics.DeadLetter.headers:type_name -> statistics.DeadLetter.HeadersEntry
+	4,  // 4: statistics.ListDeadLettersResponse.dead_letters:type_name -> statistics.DeadLetter
+	4,  // 5: statistics.DeadLetterResponse.dead_letter:type_name -> statistics.DeadLetter
+	46, // 6: statistics.Rebuild.started_at:type_name -> google.protobuf.Timestamp
+	46, // 7: statistics.Rebuild.updated_at:type_name -> google.protobuf.Timestamp
+	46, // 8: statistics.Rebuild.finished_at:type_name -> google.protobuf.Timestamp
+	13, // 9: statistics.RebuildResponse.rebuild:type_name -> statistics.Rebuild
+	46, // 10: statistics.RevenueRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 11: statistics.RevenueRequest.to:type_name -> google.protobuf.Timestamp
+	46, // 12: statistics.RevenuePoint.period_start:type_name -> google.protobuf.Timestamp
+	16, // 13: statistics.RevenueResponse.points:type_name -> statistics.RevenuePoint
+	46, // 14: statistics.RevenueSummaryRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 15: statistics.RevenueSummaryRequest.to:type_name -> google.protobuf.Timestamp
+	46, // 16: statistics.ProductSalesRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 17: statistics.ProductSalesRequest.to:type_name -> google.protobuf.Timestamp
+	21, // 18: statistics.ProductSalesResponse.products:type_name -> statistics.ProductSales
+	46, // 19: statistics.ProductRefundsRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 20: statistics.ProductRefundsRequest.to:type_name -> google.protobuf.Timestamp
+	24, // 21: statistics.ProductRefundsResponse.products:type_name -> statistics.ProductRefunds
+	46, // 22: statistics.CategorySalesRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 23: statistics.CategorySalesRequest.to:type_name -> google.protobuf.Timestamp
+	27, // 24: statistics.CategorySalesResponse.categories:type_name -> statistics.CategorySales
+	46, // 25: statistics.TopProductsRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 26: statistics.TopProductsRequest.to:type_name -> google.protobuf.Timestamp
+	21, // 27: statistics.TopProductsResponse.products:type_name -> statistics.ProductSales
+	46, // 28: statistics.TrendingProductsRequest.to:type_name -> google.protobuf.Timestamp
+	21, // 29: statistics.ProductTrend.current:type_name -> statistics.ProductSales
+	21, // 30: statistics.ProductTrend.previous:type_name -> statistics.ProductSales
+	32, // 31: statistics.TrendingProductsResponse.products:type_name -> statistics.ProductTrend
+	46, // 32: statistics.TimeSeriesRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 33: statistics.TimeSeriesRequest.to:type_name -> google.protobuf.Timestamp
+	46, // 34: statistics.TimeSeriesPoint.start:type_name -> google.protobuf.Timestamp
+	35, // 35: statistics.TimeSeriesResponse.points:type_name -> statistics.TimeSeriesPoint
+	46, // 36: statistics.ActiveUsersRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 37: statistics.ActiveUsersRequest.to:type_name -> google.protobuf.Timestamp
+	46, // 38: statistics.ActiveUsersPoint.start:type_name -> google.protobuf.Timestamp
+	38, // 39: statistics.ActiveUsersResponse.points:type_name -> statistics.ActiveUsersPoint
+	46, // 40: statistics.CohortRetentionRequest.from:type_name -> google.protobuf.Timestamp
+	46, // 41: statistics.CohortRetentionRequest.to:type_name -> google.protobuf.Timestamp
+	46, // 42: statistics.Cohort.start:type_name -> google.protobuf.Timestamp
+	41, // 43: statistics.Cohort.retention:type_name -> statistics.CohortPeriod
+	42, // 44: statistics.CohortRetentionResponse.cohorts:type_name -> statistics.Cohort
+	0,  // 45: statistics.StatisticsService.GetUserOrdersStatistics:input_type -> statistics.UserOrderStatisticsRequest
+	2,  // 46: statistics.StatisticsService.GetUserStatistics:input_type -> statistics.UserStatisticsRequest
+	37, // 47: statistics.StatisticsService.GetActiveUsers:input_type -> statistics.ActiveUsersRequest
+	40, // 48: statistics.StatisticsService.GetCohortRetention:input_type -> statistics.CohortRetentionRequest
+	15, // 49: statistics.StatisticsService.GetRevenue:input_type -> statistics.RevenueRequest
+	18, // 50: statistics.StatisticsService.GetRevenueSummary:input_type -> statistics.RevenueSummaryRequest
+	20, // 51: statistics.StatisticsService.GetProductSales:input_type -> statistics.ProductSalesRequest
+	26, // 52: statistics.StatisticsService.GetCategorySales:input_type -> statistics.CategorySalesRequest
+	23, // 53: statistics.StatisticsService.GetProductRefunds:input_type -> statistics.ProductRefundsRequest
+	29, // 54: statistics.StatisticsService.GetTopProducts:input_type -> statistics.TopProductsRequest
+	31, // 55: statistics.StatisticsService.GetTrendingProducts:input_type -> statistics.TrendingProductsRequest
+	34, // 56: statistics.StatisticsService.GetTimeSeries:input_type -> statistics.TimeSeriesRequest
+	5,  // 57: statistics.StatisticsService.ListDeadLetters:input_type -> statistics.ListDeadLettersRequest
+	7,  // 58: statistics.StatisticsService.GetDeadLetter:input_type -> statistics.DeadLetterRequest
+	7,  // 59: statistics.StatisticsService.ReplayDeadLetter:input_type -> statistics.DeadLetterRequest
+	9,  // 60: statistics.StatisticsService.PurgeDeadLetters:input_type -> statistics.PurgeDeadLettersRequest
+	11, // 61: statistics.StatisticsService.RebuildProjections:input_type -> statistics.RebuildProjectionsRequest
+	12, // 62: statistics.StatisticsService.GetRebuild:input_type -> statistics.GetRebuildRequest
+	1,  // 63: statistics.StatisticsService.GetUserOrdersStatistics:output_type -> statistics.UserOrderStatisticsResponse
+	3,  // 64: statistics.StatisticsService.GetUserStatistics:output_type -> statistics.UserStatisticsResponse
+	39, // 65: statistics.StatisticsService.GetActiveUsers:output_type -> statistics.ActiveUsersResponse
+	43, // 66: statistics.StatisticsService.GetCohortRetention:output_type -> statistics.CohortRetentionResponse
+	17, // 67: statistics.StatisticsService.GetRevenue:output_type -> statistics.RevenueResponse
+	19, // 68: statistics.StatisticsService.GetRevenueSummary:output_type -> statistics.RevenueSummaryResponse
+	22, // 69: statistics.StatisticsService.GetProductSales:output_type -> statistics.ProductSalesResponse
+	28, // 70: statistics.StatisticsService.GetCategorySales:output_type -> statistics.CategorySalesResponse
+	25, // 71: statistics.StatisticsService.GetProductRefunds:output_type -> statistics.ProductRefundsResponse
+	30, // 72: statistics.StatisticsService.GetTopProducts:output_type -> statistics.TopProductsResponse
+	33, // 73: statistics.StatisticsService.GetTrendingProducts:output_type -> statistics.TrendingProductsResponse
+	36, // 74: statistics.StatisticsService.GetTimeSeries:output_type -> statistics.TimeSeriesResponse
+	6,  // 75: statistics.StatisticsService.ListDeadLetters:output_type -> statistics.ListDeadLettersResponse
+	8,  // 76: statistics.StatisticsService.GetDeadLetter:output_type -> statistics.DeadLetterResponse
+	8,  // 77: statistics.StatisticsService.ReplayDeadLetter:output_type -> statistics.DeadLetterResponse
+	10, // 78: statistics.StatisticsService.PurgeDeadLetters:output_type -> statistics.PurgeDeadLettersResponse
+	14, // 79: statistics.StatisticsService.RebuildProjections:output_type -> statistics.RebuildResponse
+	14, // 80: statistics.StatisticsService.GetRebuild:output_type -> statistics.RebuildResponse
+	63, // [63:81] is the sub-list for method output_type
+	45, // [45:63] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_stats_proto_init() }
@@ -742,14 +3167,13 @@ func file_stats_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stats_proto_rawDesc), len(file_stats_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      0,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_stats_proto_goTypes,
 		DependencyIndexes: file_stats_proto_depIdxs,
-		EnumInfos:         file_stats_proto_enumTypes,
 		MessageInfos:      file_stats_proto_msgTypes,
 	}.Build()
 	File_stats_proto = out.File
@@ -21,6 +21,22 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	StatisticsService_GetUserOrdersStatistics_FullMethodName = "/statistics.StatisticsService/GetUserOrdersStatistics"
 	StatisticsService_GetUserStatistics_FullMethodName       = "/statistics.StatisticsService/GetUserStatistics"
+	StatisticsService_GetActiveUsers_FullMethodName          = "/statistics.StatisticsService/GetActiveUsers"
+	StatisticsService_GetCohortRetention_FullMethodName      = "/statistics.StatisticsService/GetCohortRetention"
+	StatisticsService_GetRevenue_FullMethodName              = "/statistics.StatisticsService/GetRevenue"
+	StatisticsService_GetRevenueSummary_FullMethodName       = "/statistics.StatisticsService/GetRevenueSummary"
+	StatisticsService_GetProductSales_FullMethodName         = "/statistics.StatisticsService/GetProductSales"
+	StatisticsService_GetCategorySales_FullMethodName        = "/statistics.StatisticsService/GetCategorySales"
+	StatisticsService_GetProductRefunds_FullMethodName       = "/statistics.StatisticsService/GetProductRefunds"
+	StatisticsService_GetTopProducts_FullMethodName          = "/statistics.StatisticsService/GetTopProducts"
+	StatisticsService_GetTrendingProducts_FullMethodName     = "/statistics.StatisticsService/GetTrendingProducts"
+	StatisticsService_GetTimeSeries_FullMethodName           = "/statistics.StatisticsService/GetTimeSeries"
+	StatisticsService_ListDeadLetters_FullMethodName         = "/statistics.StatisticsService/ListDeadLetters"
+	StatisticsService_GetDeadLetter_FullMethodName           = "/statistics.StatisticsService/GetDeadLetter"
+	StatisticsService_ReplayDeadLetter_FullMethodName        = "/statistics.StatisticsService/ReplayDeadLetter"
+	StatisticsService_PurgeDeadLetters_FullMethodName        = "/statistics.StatisticsService/PurgeDeadLetters"
+	StatisticsService_RebuildProjections_FullMethodName      = "/statistics.StatisticsService/RebuildProjections"
+	StatisticsService_GetRebuild_FullMethodName              = "/statistics.StatisticsService/GetRebuild"
 )
 
 // StatisticsServiceClient is the client API for StatisticsService service.
@@ -29,6 +45,26 @@ const (
 type StatisticsServiceClient interface {
 	GetUserOrdersStatistics(ctx context.Context, in *UserOrderStatisticsRequest, opts ...grpc.CallOption) (*UserOrderStatisticsResponse, error)
 	GetUserStatistics(ctx context.Context, in *UserStatisticsRequest, opts ...grpc.CallOption) (*UserStatisticsResponse, error)
+	// Platform-wide user metrics, admin only.
+	GetActiveUsers(ctx context.Context, in *ActiveUsersRequest, opts ...grpc.CallOption) (*ActiveUsersResponse, error)
+	GetCohortRetention(ctx context.Context, in *CohortRetentionRequest, opts ...grpc.CallOption) (*CohortRetentionResponse, error)
+	// Sales analytics over all orders, admin only.
+	GetRevenue(ctx context.Context, in *RevenueRequest, opts ...grpc.CallOption) (*RevenueResponse, error)
+	GetRevenueSummary(ctx context.Context, in *RevenueSummaryRequest, opts ...grpc.CallOption) (*RevenueSummaryResponse, error)
+	GetProductSales(ctx context.Context, in *ProductSalesRequest, opts ...grpc.CallOption) (*ProductSalesResponse, error)
+	GetCategorySales(ctx context.Context, in *CategorySalesRequest, opts ...grpc.CallOption) (*CategorySalesResponse, error)
+	GetProductRefunds(ctx context.Context, in *ProductRefundsRequest, opts ...grpc.CallOption) (*ProductRefundsResponse, error)
+	GetTopProducts(ctx context.Context, in *TopProductsRequest, opts ...grpc.CallOption) (*TopProductsResponse, error)
+	GetTrendingProducts(ctx context.Context, in *TrendingProductsRequest, opts ...grpc.CallOption) (*TrendingProductsResponse, error)
+	GetTimeSeries(ctx context.Context, in *TimeSeriesRequest, opts ...grpc.CallOption) (*TimeSeriesResponse, error)
+	// Dead-letter administration, admin only.
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	GetDeadLetter(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*DeadLetterResponse, error)
+	ReplayDeadLetter(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*DeadLetterResponse, error)
+	PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error)
+	// Projection rebuilds, admin only.
+	RebuildProjections(ctx context.Context, in *RebuildProjectionsRequest, opts ...grpc.CallOption) (*RebuildResponse, error)
+	GetRebuild(ctx context.Context, in *GetRebuildRequest, opts ...grpc.CallOption) (*RebuildResponse, error)
 }
 
 type statisticsServiceClient struct {
@@ -59,12 +95,192 @@ func (c *statisticsServiceClient) GetUserStatistics(ctx context.Context, in *Use
 	return out, nil
 }
 
+func (c *statisticsServiceClient) GetActiveUsers(ctx context.Context, in *ActiveUsersRequest, opts ...grpc.CallOption) (*ActiveUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActiveUsersResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetActiveUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetCohortRetention(ctx context.Context, in *CohortRetentionRequest, opts ...grpc.CallOption) (*CohortRetentionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CohortRetentionResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetCohortRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetRevenue(ctx context.Context, in *RevenueRequest, opts ...grpc.CallOption) (*RevenueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevenueResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetRevenue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetRevenueSummary(ctx context.Context, in *RevenueSummaryRequest, opts ...grpc.CallOption) (*RevenueSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevenueSummaryResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetRevenueSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetProductSales(ctx context.Context, in *ProductSalesRequest, opts ...grpc.CallOption) (*ProductSalesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductSalesResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetProductSales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetCategorySales(ctx context.Context, in *CategorySalesRequest, opts ...grpc.CallOption) (*CategorySalesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategorySalesResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetCategorySales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetProductRefunds(ctx context.Context, in *ProductRefundsRequest, opts ...grpc.CallOption) (*ProductRefundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProductRefundsResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetProductRefunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetTopProducts(ctx context.Context, in *TopProductsRequest, opts ...grpc.CallOption) (*TopProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopProductsResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetTopProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetTrendingProducts(ctx context.Context, in *TrendingProductsRequest, opts ...grpc.CallOption) (*TrendingProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrendingProductsResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetTrendingProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetTimeSeries(ctx context.Context, in *TimeSeriesRequest, opts ...grpc.CallOption) (*TimeSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeSeriesResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetTimeSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetDeadLetter(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*DeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeadLetterResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) ReplayDeadLetter(ctx context.Context, in *DeadLetterRequest, opts ...grpc.CallOption) (*DeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeadLetterResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_ReplayDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) PurgeDeadLetters(ctx context.Context, in *PurgeDeadLettersRequest, opts ...grpc.CallOption) (*PurgeDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeadLettersResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_PurgeDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) RebuildProjections(ctx context.Context, in *RebuildProjectionsRequest, opts ...grpc.CallOption) (*RebuildResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_RebuildProjections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statisticsServiceClient) GetRebuild(ctx context.Context, in *GetRebuildRequest, opts ...grpc.CallOption) (*RebuildResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildResponse)
+	err := c.cc.Invoke(ctx, StatisticsService_GetRebuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatisticsServiceServer is the server API for StatisticsService service.
 // All implementations must embed UnimplementedStatisticsServiceServer
 // for forward compatibility.
 type StatisticsServiceServer interface {
 	GetUserOrdersStatistics(context.Context, *UserOrderStatisticsRequest) (*UserOrderStatisticsResponse, error)
 	GetUserStatistics(context.Context, *UserStatisticsRequest) (*UserStatisticsResponse, error)
+	// Platform-wide user metrics, admin only.
+	GetActiveUsers(context.Context, *ActiveUsersRequest) (*ActiveUsersResponse, error)
+	GetCohortRetention(context.Context, *CohortRetentionRequest) (*CohortRetentionResponse, error)
+	// Sales analytics over all orders, admin only.
+	GetRevenue(context.Context, *RevenueRequest) (*RevenueResponse, error)
+	GetRevenueSummary(context.Context, *RevenueSummaryRequest) (*RevenueSummaryResponse, error)
+	GetProductSales(context.Context, *ProductSalesRequest) (*ProductSalesResponse, error)
+	GetCategorySales(context.Context, *CategorySalesRequest) (*CategorySalesResponse, error)
+	GetProductRefunds(context.Context, *ProductRefundsRequest) (*ProductRefundsResponse, error)
+	GetTopProducts(context.Context, *TopProductsRequest) (*TopProductsResponse, error)
+	GetTrendingProducts(context.Context, *TrendingProductsRequest) (*TrendingProductsResponse, error)
+	GetTimeSeries(context.Context, *TimeSeriesRequest) (*TimeSeriesResponse, error)
+	// Dead-letter administration, admin only.
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	GetDeadLetter(context.Context, *DeadLetterRequest) (*DeadLetterResponse, error)
+	ReplayDeadLetter(context.Context, *DeadLetterRequest) (*DeadLetterResponse, error)
+	PurgeDeadLetters(context.Context, *PurgeDeadLettersRequest) (*PurgeDeadLettersResponse, error)
+	// Projection rebuilds, admin only.
+	RebuildProjections(context.Context, *RebuildProjectionsRequest) (*RebuildResponse, error)
+	GetRebuild(context.Context, *GetRebuildRequest) (*RebuildResponse, error)
 	mustEmbedUnimplementedStatisticsServiceServer()
 }
 
@@ -81,6 +297,54 @@ func (UnimplementedStatisticsServiceServer) GetUserOrdersStatistics(context.Cont
 func (UnimplementedStatisticsServiceServer) GetUserStatistics(context.Context, *UserStatisticsRequest) (*UserStatisticsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserStatistics not implemented")
 }
+func (UnimplementedStatisticsServiceServer) GetActiveUsers(context.Context, *ActiveUsersRequest) (*ActiveUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveUsers not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetCohortRetention(context.Context, *CohortRetentionRequest) (*CohortRetentionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCohortRetention not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetRevenue(context.Context, *RevenueRequest) (*RevenueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevenue not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetRevenueSummary(context.Context, *RevenueSummaryRequest) (*RevenueSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevenueSummary not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetProductSales(context.Context, *ProductSalesRequest) (*ProductSalesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductSales not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetCategorySales(context.Context, *CategorySalesRequest) (*CategorySalesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategorySales not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetProductRefunds(context.Context, *ProductRefundsRequest) (*ProductRefundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductRefunds not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetTopProducts(context.Context, *TopProductsRequest) (*TopProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopProducts not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetTrendingProducts(context.Context, *TrendingProductsRequest) (*TrendingProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingProducts not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetTimeSeries(context.Context, *TimeSeriesRequest) (*TimeSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeSeries not implemented")
+}
+func (UnimplementedStatisticsServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetDeadLetter(context.Context, *DeadLetterRequest) (*DeadLetterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetter not implemented")
+}
+func (UnimplementedStatisticsServiceServer) ReplayDeadLetter(context.Context, *DeadLetterRequest) (*DeadLetterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetter not implemented")
+}
+func (UnimplementedStatisticsServiceServer) PurgeDeadLetters(context.Context, *PurgeDeadLettersRequest) (*PurgeDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeadLetters not implemented")
+}
+func (UnimplementedStatisticsServiceServer) RebuildProjections(context.Context, *RebuildProjectionsRequest) (*RebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildProjections not implemented")
+}
+func (UnimplementedStatisticsServiceServer) GetRebuild(context.Context, *GetRebuildRequest) (*RebuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRebuild not implemented")
+}
 func (UnimplementedStatisticsServiceServer) mustEmbedUnimplementedStatisticsServiceServer() {}
 func (UnimplementedStatisticsServiceServer) testEmbeddedByValue()                           {}
 
@@ -138,6 +402,294 @@ func _StatisticsService_GetUserStatistics_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StatisticsService_GetActiveUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActiveUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetActiveUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetActiveUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetActiveUsers(ctx, req.(*ActiveUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetCohortRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CohortRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetCohortRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetCohortRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetCohortRetention(ctx, req.(*CohortRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetRevenue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevenueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetRevenue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetRevenue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetRevenue(ctx, req.(*RevenueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetRevenueSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevenueSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetRevenueSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetRevenueSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetRevenueSummary(ctx, req.(*RevenueSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetProductSales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductSalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetProductSales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetProductSales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetProductSales(ctx, req.(*ProductSalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetCategorySales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategorySalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetCategorySales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetCategorySales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetCategorySales(ctx, req.(*CategorySalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetProductRefunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductRefundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetProductRefunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetProductRefunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetProductRefunds(ctx, req.(*ProductRefundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetTopProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetTopProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetTopProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetTopProducts(ctx, req.(*TopProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetTrendingProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrendingProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetTrendingProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetTrendingProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetTrendingProducts(ctx, req.(*TrendingProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetTimeSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetTimeSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetTimeSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetTimeSeries(ctx, req.(*TimeSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetDeadLetter(ctx, req.(*DeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).ReplayDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_ReplayDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).ReplayDeadLetter(ctx, req.(*DeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_PurgeDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).PurgeDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_PurgeDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).PurgeDeadLetters(ctx, req.(*PurgeDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_RebuildProjections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildProjectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).RebuildProjections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_RebuildProjections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).RebuildProjections(ctx, req.(*RebuildProjectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatisticsService_GetRebuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRebuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatisticsServiceServer).GetRebuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StatisticsService_GetRebuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatisticsServiceServer).GetRebuild(ctx, req.(*GetRebuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StatisticsService_ServiceDesc is the grpc.ServiceDesc for StatisticsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +705,70 @@ var StatisticsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserStatistics",
 			Handler:    _StatisticsService_GetUserStatistics_Handler,
 		},
+		{
+			MethodName: "GetActiveUsers",
+			Handler:    _StatisticsService_GetActiveUsers_Handler,
+		},
+		{
+			MethodName: "GetCohortRetention",
+			Handler:    _StatisticsService_GetCohortRetention_Handler,
+		},
+		{
+			MethodName: "GetRevenue",
+			Handler:    _StatisticsService_GetRevenue_Handler,
+		},
+		{
+			MethodName: "GetRevenueSummary",
+			Handler:    _StatisticsService_GetRevenueSummary_Handler,
+		},
+		{
+			MethodName: "GetProductSales",
+			Handler:    _StatisticsService_GetProductSales_Handler,
+		},
+		{
+			MethodName: "GetCategorySales",
+			Handler:    _StatisticsService_GetCategorySales_Handler,
+		},
+		{
+			MethodName: "GetProductRefunds",
+			Handler:    _StatisticsService_GetProductRefunds_Handler,
+		},
+		{
+			MethodName: "GetTopProducts",
+			Handler:    _StatisticsService_GetTopProducts_Handler,
+		},
+		{
+			MethodName: "GetTrendingProducts",
+			Handler:    _StatisticsService_GetTrendingProducts_Handler,
+		},
+		{
+			MethodName: "GetTimeSeries",
+			Handler:    _StatisticsService_GetTimeSeries_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _StatisticsService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "GetDeadLetter",
+			Handler:    _StatisticsService_GetDeadLetter_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetter",
+			Handler:    _StatisticsService_ReplayDeadLetter_Handler,
+		},
+		{
+			MethodName: "PurgeDeadLetters",
+			Handler:    _StatisticsService_PurgeDeadLetters_Handler,
+		},
+		{
+			MethodName: "RebuildProjections",
+			Handler:    _StatisticsService_RebuildProjections_Handler,
+		},
+		{
+			MethodName: "GetRebuild",
+			Handler:    _StatisticsService_GetRebuild_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stats.proto",